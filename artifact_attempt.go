@@ -0,0 +1,34 @@
+// Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package weles
+
+import "github.com/go-openapi/strfmt"
+
+// ArtifactAttempt describes single attempt of downloading an artifact.
+// Attempts form download history of the artifact.
+type ArtifactAttempt struct {
+	ID int64 `db:",primarykey, autoincrement"`
+	// Path identifies the artifact.
+	Path ArtifactPath
+	// Number of the attempt starting from 1.
+	Number int
+	// Timestamp is time of starting the attempt.
+	Timestamp strfmt.DateTime
+	// Offset is number of bytes downloaded by previous attempts. It is greater
+	// than 0 if transfer was resumed.
+	Offset int64
+	// Error describes failure of the attempt. It is empty if attempt succeeded.
+	Error string
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // ArtifactFilter is used to filter results from ArtifactDB.
@@ -36,14 +37,49 @@ type ArtifactFilter struct {
 	// alias
 	Alias []ArtifactAlias `json:"Alias"`
 
+	// selects artifacts with alias containing any of given substrings.
+	AliasContains []string `json:"AliasContains"`
+
+	// selects artifacts with alias matching any of given regular expressions.
+	AliasRegexp []string `json:"AliasRegexp"`
+
+	// selects artifacts created after given time.
+	// Format: date-time
+	CreatedAfter strfmt.DateTime `json:"CreatedAfter,omitempty"`
+
+	// selects artifacts created before given time.
+	// Format: date-time
+	CreatedBefore strfmt.DateTime `json:"CreatedBefore,omitempty"`
+
+	// e tag
+	ETag []string `json:"ETag"`
+
 	// job ID
 	JobID []JobID `json:"JobID"`
 
+	// selects artifacts of at most given size in bytes. 0 means no limit.
+	MaxSize int64 `json:"MaxSize,omitempty"`
+
+	// mime type
+	MimeType []string `json:"MimeType"`
+
+	// selects artifacts of at least given size in bytes.
+	MinSize int64 `json:"MinSize,omitempty"`
+
+	// s h a256
+	SHA256 []string `json:"SHA256"`
+
 	// status
 	Status []ArtifactStatus `json:"Status"`
 
 	// type
 	Type []ArtifactType `json:"Type"`
+
+	// selects artifacts with URI containing any of given substrings.
+	URIContains []string `json:"URIContains"`
+
+	// selects artifacts with URI matching any of given regular expressions.
+	URIRegexp []string `json:"URIRegexp"`
 }
 
 // Validate validates this artifact filter
@@ -54,6 +90,14 @@ func (m *ArtifactFilter) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateCreatedAfter(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCreatedBefore(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateJobID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -92,6 +136,32 @@ func (m *ArtifactFilter) validateAlias(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ArtifactFilter) validateCreatedAfter(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CreatedAfter) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("CreatedAfter", "body", "date-time", m.CreatedAfter.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ArtifactFilter) validateCreatedBefore(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CreatedBefore) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("CreatedBefore", "body", "date-time", m.CreatedBefore.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *ArtifactFilter) validateJobID(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.JobID) { // not required
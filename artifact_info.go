@@ -33,18 +33,45 @@ import (
 type ArtifactInfo struct {
 	ArtifactDescription
 
+	// is current download rate of the artifact in bytes per second.
+	DownloadRate int64 `json:"DownloadRate,omitempty" db:"-"`
+
+	// is ETag returned by the source of the artifact.
+	ETag string `json:"ETag,omitempty"`
+
 	// unique identification of the artifact.
 	ID int64 `json:"ID,omitempty" db:",primarykey, autoincrement"`
 
+	// is Last-Modified value returned by the source of the artifact.
+	LastModified string `json:"LastModified,omitempty"`
+
+	// is detected MIME type of the ready artifact.
+	MimeType string `json:"MimeType,omitempty"`
+
 	// path
 	Path ArtifactPath `json:"Path,omitempty"`
 
+	// is set if the artifact is never purged by retention policy.
+	Pinned bool `json:"Pinned,omitempty"`
+
+	// is number of bytes of the artifact downloaded so far.
+	ReceivedBytes int64 `json:"ReceivedBytes,omitempty" db:"-"`
+
+	// is hex encoded SHA256 digest of the ready artifact.
+	SHA256 string `json:"SHA256,omitempty"`
+
+	// is size of the ready artifact in bytes.
+	Size int64 `json:"Size,omitempty"`
+
 	// status
 	Status ArtifactStatus `json:"Status,omitempty"`
 
 	// is date of creating the artifact.
 	// Format: date-time
 	Timestamp strfmt.DateTime `json:"Timestamp,omitempty"`
+
+	// is size of the artifact reported by the server. It is -1 if the size is unknown.
+	TotalBytes int64 `json:"TotalBytes,omitempty" db:"-"`
 }
 
 // UnmarshalJSON unmarshals this object from a JSON structure
@@ -58,25 +85,61 @@ func (m *ArtifactInfo) UnmarshalJSON(raw []byte) error {
 
 	// now for regular properties
 	var propsArtifactInfo struct {
+		DownloadRate int64 `json:"DownloadRate,omitempty"`
+
+		ETag string `json:"ETag,omitempty"`
+
 		ID int64 `json:"ID,omitempty"`
 
+		LastModified string `json:"LastModified,omitempty"`
+
+		MimeType string `json:"MimeType,omitempty"`
+
 		Path ArtifactPath `json:"Path,omitempty"`
 
+		Pinned bool `json:"Pinned,omitempty"`
+
+		ReceivedBytes int64 `json:"ReceivedBytes,omitempty"`
+
+		SHA256 string `json:"SHA256,omitempty"`
+
+		Size int64 `json:"Size,omitempty"`
+
 		Status ArtifactStatus `json:"Status,omitempty"`
 
 		Timestamp strfmt.DateTime `json:"Timestamp,omitempty"`
+
+		TotalBytes int64 `json:"TotalBytes,omitempty"`
 	}
 	if err := swag.ReadJSON(raw, &propsArtifactInfo); err != nil {
 		return err
 	}
+	m.DownloadRate = propsArtifactInfo.DownloadRate
+
+	m.ETag = propsArtifactInfo.ETag
+
 	m.ID = propsArtifactInfo.ID
 
+	m.LastModified = propsArtifactInfo.LastModified
+
+	m.MimeType = propsArtifactInfo.MimeType
+
 	m.Path = propsArtifactInfo.Path
 
+	m.Pinned = propsArtifactInfo.Pinned
+
+	m.ReceivedBytes = propsArtifactInfo.ReceivedBytes
+
+	m.SHA256 = propsArtifactInfo.SHA256
+
+	m.Size = propsArtifactInfo.Size
+
 	m.Status = propsArtifactInfo.Status
 
 	m.Timestamp = propsArtifactInfo.Timestamp
 
+	m.TotalBytes = propsArtifactInfo.TotalBytes
+
 	return nil
 }
 
@@ -92,22 +155,58 @@ func (m ArtifactInfo) MarshalJSON() ([]byte, error) {
 
 	// now for regular properties
 	var propsArtifactInfo struct {
+		DownloadRate int64 `json:"DownloadRate,omitempty"`
+
+		ETag string `json:"ETag,omitempty"`
+
 		ID int64 `json:"ID,omitempty"`
 
+		LastModified string `json:"LastModified,omitempty"`
+
+		MimeType string `json:"MimeType,omitempty"`
+
 		Path ArtifactPath `json:"Path,omitempty"`
 
+		Pinned bool `json:"Pinned,omitempty"`
+
+		ReceivedBytes int64 `json:"ReceivedBytes,omitempty"`
+
+		SHA256 string `json:"SHA256,omitempty"`
+
+		Size int64 `json:"Size,omitempty"`
+
 		Status ArtifactStatus `json:"Status,omitempty"`
 
 		Timestamp strfmt.DateTime `json:"Timestamp,omitempty"`
+
+		TotalBytes int64 `json:"TotalBytes,omitempty"`
 	}
+	propsArtifactInfo.DownloadRate = m.DownloadRate
+
+	propsArtifactInfo.ETag = m.ETag
+
 	propsArtifactInfo.ID = m.ID
 
+	propsArtifactInfo.LastModified = m.LastModified
+
+	propsArtifactInfo.MimeType = m.MimeType
+
 	propsArtifactInfo.Path = m.Path
 
+	propsArtifactInfo.Pinned = m.Pinned
+
+	propsArtifactInfo.ReceivedBytes = m.ReceivedBytes
+
+	propsArtifactInfo.SHA256 = m.SHA256
+
+	propsArtifactInfo.Size = m.Size
+
 	propsArtifactInfo.Status = m.Status
 
 	propsArtifactInfo.Timestamp = m.Timestamp
 
+	propsArtifactInfo.TotalBytes = m.TotalBytes
+
 	jsonDataPropsArtifactInfo, errArtifactInfo := swag.WriteJSON(propsArtifactInfo)
 	if errArtifactInfo != nil {
 		return nil, errArtifactInfo
@@ -30,8 +30,23 @@ import (
 )
 
 // ArtifactSortBy denotes the key for sorting list of all artifacts.
+//
 // * ID - sorting by artifact ID.
 //
+// * Timestamp - sorting by date of creation of the artifact.
+//
+// * JobID - sorting by ID of the job the artifact belongs to.
+//
+// * Type - sorting by type of the artifact.
+//
+// * Status - sorting by status of the artifact.
+//
+// * Alias - sorting by alias of the artifact.
+//
+// * Size - sorting by size of the artifact file.
+//
+// When sorting is applied, and there are many artifacts with the same value of the key, they will be sorted by ID in the same order.
+//
 // swagger:model ArtifactSortBy
 type ArtifactSortBy string
 
@@ -39,6 +54,24 @@ const (
 
 	// ArtifactSortByID captures enum value "ID"
 	ArtifactSortByID ArtifactSortBy = "ID"
+
+	// ArtifactSortByTimestamp captures enum value "Timestamp"
+	ArtifactSortByTimestamp ArtifactSortBy = "Timestamp"
+
+	// ArtifactSortByJobID captures enum value "JobID"
+	ArtifactSortByJobID ArtifactSortBy = "JobID"
+
+	// ArtifactSortByType captures enum value "Type"
+	ArtifactSortByType ArtifactSortBy = "Type"
+
+	// ArtifactSortByStatus captures enum value "Status"
+	ArtifactSortByStatus ArtifactSortBy = "Status"
+
+	// ArtifactSortByAlias captures enum value "Alias"
+	ArtifactSortByAlias ArtifactSortBy = "Alias"
+
+	// ArtifactSortBySize captures enum value "Size"
+	ArtifactSortBySize ArtifactSortBy = "Size"
 )
 
 // for schema
@@ -46,7 +79,7 @@ var artifactSortByEnum []interface{}
 
 func init() {
 	var res []ArtifactSortBy
-	if err := json.Unmarshal([]byte(`["ID",""]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["ID","Timestamp","JobID","Type","Status","Alias","Size",""]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
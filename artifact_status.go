@@ -39,6 +39,10 @@ import (
 //
 // * PENDING - artifact download has not started yet.
 //
+// * PURGED - file has been removed according to retention policy.
+//
+// * CANCELED - download has been canceled (e.g. Job has been canceled or failed).
+//
 // swagger:model ArtifactStatus
 type ArtifactStatus string
 
@@ -55,6 +59,12 @@ const (
 
 	// ArtifactStatusPENDING captures enum value "PENDING"
 	ArtifactStatusPENDING ArtifactStatus = "PENDING"
+
+	// ArtifactStatusPURGED captures enum value "PURGED"
+	ArtifactStatusPURGED ArtifactStatus = "PURGED"
+
+	// ArtifactStatusCANCELED captures enum value "CANCELED"
+	ArtifactStatusCANCELED ArtifactStatus = "CANCELED"
 )
 
 // for schema
@@ -62,7 +72,7 @@ var artifactStatusEnum []interface{}
 
 func init() {
 	var res []ArtifactStatus
-	if err := json.Unmarshal([]byte(`["DOWNLOADING","READY","FAILED","PENDING",""]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["DOWNLOADING","READY","FAILED","PENDING","PURGED","CANCELED",""]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -19,4 +19,23 @@ package weles
 type ArtifactStatusChange struct {
 	Path      ArtifactPath
 	NewStatus ArtifactStatus
+	// Cached is set when artifact became ready by reusing a cached file.
+	Cached bool
+	// ETag and LastModified are returned by the source of the artifact. They are
+	// set when downloaded artifact becomes ready.
+	ETag         string
+	LastModified string
+	// Progress is set in notifications reporting progress of downloading
+	// the artifact. Its status is not changed by them.
+	Progress *ArtifactProgress
+}
+
+// ArtifactProgress describes progress of downloading an artifact.
+type ArtifactProgress struct {
+	// Received is number of bytes downloaded so far.
+	Received int64
+	// Total is size of the artifact reported by the server or -1 if it is unknown.
+	Total int64
+	// Rate is current download rate in bytes per second.
+	Rate int64
 }
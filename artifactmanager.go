@@ -18,6 +18,12 @@
 
 package weles
 
+import "io"
+
+// UploadedArtifactURIPrefix prefixes URIs of artifacts uploaded directly to ArtifactDB.
+// Such URI is completed with ID of the artifact, e.g. weles://artifact/12.
+const UploadedArtifactURIPrefix = "weles://artifact/"
+
 // ArtifactManager provides access to content in ArtifactDB required for Job execution.
 // It provides data from ArtifactDB for lookup and retrieval.
 // It is responsible for downloading job artifacts to ArtifactDB.
@@ -26,15 +32,52 @@ type ArtifactManager interface {
 	ListArtifact(filter ArtifactFilter, sorter ArtifactSorter, paginator ArtifactPagination,
 	) ([]ArtifactInfo, ListInfo, error)
 
-	// Push inserts artifact to ArtifactDB and returns its path.
-	PushArtifact(artifact ArtifactDescription, ch chan ArtifactStatusChange) (ArtifactPath, error)
+	// Push inserts artifact to ArtifactDB and returns its path. Artifact is downloaded
+	// with priority of the Job it belongs to.
+	PushArtifact(artifact ArtifactDescription, priority Priority, ch chan ArtifactStatusChange,
+	) (ArtifactPath, error)
 
 	// Create constructs ArtifactPath in ArtifactDB, but no file is created.
 	CreateArtifact(artifact ArtifactDescription) (ArtifactPath, error)
 
+	// UploadArtifact stores content in ArtifactDB as a ready to use artifact.
+	UploadArtifact(artifact ArtifactDescription, content io.Reader) (ArtifactInfo, error)
+
 	// GetFileInfo retrieves information about an artifact from ArtifactDB.
 	GetArtifactInfo(path ArtifactPath) (ArtifactInfo, error)
 
+	// GetArtifactInfoByID retrieves information about an artifact identified by its ID.
+	GetArtifactInfoByID(id int64) (ArtifactInfo, error)
+
+	// UseArtifact retrieves information about an artifact identified by its ID and records
+	// that it is used by a Job, e.g. uploaded artifact referenced in Job's config.
+	// Artifacts used by active Jobs are never purged.
+	UseArtifact(id int64, job JobID) (ArtifactInfo, error)
+
+	// GetArtifactAttempts retrieves history of download attempts of an artifact.
+	GetArtifactAttempts(path ArtifactPath) ([]ArtifactAttempt, error)
+
+	// SetArtifactStatus changes status of an artifact (e.g. when it fails verification).
+	// Cached file downloaded from URI of failed artifact is not reused.
+	SetArtifactStatus(change ArtifactStatusChange) error
+
+	// DeleteArtifact removes file and ArtifactDB record of an artifact identified by its ID.
+	// Artifacts which are being downloaded or are used by active Jobs cannot be deleted.
+	DeleteArtifact(id int64) error
+
+	// DeleteJobArtifacts removes files and ArtifactDB records of all artifacts of a Job.
+	// Nothing is deleted if any of the artifacts is being downloaded or is used by
+	// an active Job.
+	DeleteJobArtifacts(job JobID) error
+
+	// CancelJobArtifacts cancels downloads of all artifacts of a Job which are queued
+	// or in progress. Canceled artifacts get CANCELED status.
+	CancelJobArtifacts(job JobID) error
+
+	// SetArtifactPinned sets whether an artifact identified by its ID is protected
+	// from being purged by retention policy.
+	SetArtifactPinned(id int64, pinned bool) error
+
 	// Close gracefully closes ArtifactManager.
 	Close() error
 }
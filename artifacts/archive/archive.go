@@ -0,0 +1,225 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// Package archive is responsible for packing artifacts of a job into a single archive.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+// Supported archive formats.
+const (
+	TarGz = "tar.gz"
+	Zip   = "zip"
+)
+
+// ManifestName is the name of archive entry describing archived artifacts.
+const ManifestName = "manifest.json"
+
+// ErrUnknownFormat is returned when archive format is not supported.
+var ErrUnknownFormat = errors.New("unknown archive format")
+
+// Entry describes single archived artifact in the manifest.
+type Entry struct {
+	File         string               `json:"File"`
+	ID           int64                `json:"ID"`
+	JobID        weles.JobID          `json:"JobID"`
+	Type         weles.ArtifactType   `json:"Type"`
+	Alias        weles.ArtifactAlias  `json:"Alias,omitempty"`
+	URI          weles.ArtifactURI    `json:"URI,omitempty"`
+	Status       weles.ArtifactStatus `json:"Status"`
+	Size         int64                `json:"Size"`
+	SHA256       string               `json:"SHA256,omitempty"`
+	MimeType     string               `json:"MimeType,omitempty"`
+	ETag         string               `json:"ETag,omitempty"`
+	LastModified string               `json:"LastModified,omitempty"`
+	Timestamp    string               `json:"Timestamp,omitempty"`
+}
+
+// writer abstracts adding files to tar and zip archives.
+type writer interface {
+	add(name string, size int64, mtime time.Time, r io.Reader) error
+	Close() error
+}
+
+// Supported returns true if archive format f is supported.
+func Supported(f string) bool {
+	return f == TarGz || f == Zip
+}
+
+// Extension returns file name extension of archive format f.
+func Extension(f string) string {
+	return "." + f
+}
+
+// Write packs files of artifacts into archive of format f written to w. Manifest describing
+// archived artifacts is written as the first entry.
+func Write(w io.Writer, f string, artifacts []weles.ArtifactInfo) error {
+	var aw writer
+	switch f {
+	case TarGz:
+		aw = newTarGzWriter(w)
+	case Zip:
+		aw = &zipWriter{zip.NewWriter(w)}
+	default:
+		return ErrUnknownFormat
+	}
+
+	manifest := Manifest(artifacts)
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		aw.Close()
+		return err
+	}
+	err = aw.add(ManifestName, int64(len(data)), time.Now(), strings.NewReader(string(data)))
+	for i := 0; err == nil && i < len(artifacts); i++ {
+		err = addFile(aw, manifest[i].File, string(artifacts[i].Path))
+	}
+	if cerr := aw.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Manifest returns manifest entries of artifacts with unique archive file names.
+func Manifest(artifacts []weles.ArtifactInfo) []Entry {
+	names := make(map[string]bool, len(artifacts))
+	entries := make([]Entry, len(artifacts))
+	for i, a := range artifacts {
+		name := entryName(a)
+		if names[name] {
+			ext := path.Ext(name)
+			name = fmt.Sprintf("%s_%d%s", strings.TrimSuffix(name, ext), a.ID, ext)
+		}
+		names[name] = true
+		entries[i] = Entry{
+			File:         name,
+			ID:           a.ID,
+			JobID:        a.JobID,
+			Type:         a.Type,
+			Alias:        a.Alias,
+			URI:          a.URI,
+			Status:       a.Status,
+			Size:         a.Size,
+			SHA256:       a.SHA256,
+			MimeType:     a.MimeType,
+			ETag:         a.ETag,
+			LastModified: a.LastModified,
+			Timestamp:    a.Timestamp.String(),
+		}
+	}
+	return entries
+}
+
+// entryName builds archive file name of artifact from its type and alias. Base name of the
+// artifact's path is used if alias is empty.
+func entryName(a weles.ArtifactInfo) string {
+	name := sanitize(string(a.Alias))
+	if name == "" {
+		name = filepath.Base(string(a.Path))
+	}
+	return path.Join(string(a.Type), name)
+}
+
+// sanitize replaces characters which are not safe in archive file names.
+func sanitize(s string) string {
+	return strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\' || r < ' ' || r == 0x7f:
+			return '_'
+		}
+		return r
+	}, s), ". ")
+}
+
+// addFile adds file from path p to archive aw as name.
+func addFile(aw writer, name, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return aw.add(name, fi.Size(), fi.ModTime(), f)
+}
+
+// tarGzWriter writes gzip compressed tar archive.
+type tarGzWriter struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+func newTarGzWriter(w io.Writer) *tarGzWriter {
+	gz := gzip.NewWriter(w)
+	return &tarGzWriter{gz: gz, tw: tar.NewWriter(gz)}
+}
+
+func (t *tarGzWriter) add(name string, size int64, mtime time.Time, r io.Reader) error {
+	err := t.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: mtime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.CopyN(t.tw, r, size)
+	return err
+}
+
+func (t *tarGzWriter) Close() error {
+	if err := t.tw.Close(); err != nil {
+		t.gz.Close()
+		return err
+	}
+	return t.gz.Close()
+}
+
+// zipWriter writes zip archive.
+type zipWriter struct {
+	*zip.Writer
+}
+
+func (z *zipWriter) add(name string, size int64, mtime time.Time, r io.Reader) error {
+	w, err := z.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: mtime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.CopyN(w, r, size)
+	return err
+}
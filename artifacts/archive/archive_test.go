@@ -0,0 +1,171 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+var _ = Describe("Archive", func() {
+
+	var (
+		tmpDir    string
+		artifacts []weles.ArtifactInfo
+	)
+
+	newArtifact := func(id int64, t weles.ArtifactType, alias, content string) weles.ArtifactInfo {
+		f, err := ioutil.TempFile(tmpDir, "weles-")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.WriteString(content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+		return weles.ArtifactInfo{
+			ArtifactDescription: weles.ArtifactDescription{
+				JobID: 7,
+				Type:  t,
+				Alias: weles.ArtifactAlias(alias),
+			},
+			ID:     id,
+			Path:   weles.ArtifactPath(f.Name()),
+			Status: weles.ArtifactStatusREADY,
+			Size:   int64(len(content)),
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "weles-")
+		Expect(err).ToNot(HaveOccurred())
+		artifacts = []weles.ArtifactInfo{
+			newArtifact(1, weles.ArtifactTypeRESULT, "goldenUnicorn.log", "golden"),
+			newArtifact(2, weles.ArtifactTypeRESULT, "goldenUnicorn.log", "silver"),
+			newArtifact(3, weles.ArtifactTypeTEST, "../bronze/Yak", "bronze"),
+			newArtifact(4, weles.ArtifactTypeIMAGE, "", "brass"),
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	expectedFiles := func() map[string]string {
+		return map[string]string{
+			"RESULT/goldenUnicorn.log":                          "golden",
+			"RESULT/goldenUnicorn_2.log":                        "silver",
+			"TEST/_bronze_Yak":                                  "bronze",
+			"IMAGE/" + filepath.Base(string(artifacts[3].Path)): "brass",
+		}
+	}
+
+	checkManifest := func(data []byte) {
+		var manifest []Entry
+		Expect(json.Unmarshal(data, &manifest)).To(Succeed())
+		Expect(manifest).To(Equal(Manifest(artifacts)))
+		Expect(manifest).To(HaveLen(len(artifacts)))
+	}
+
+	It("should name entries after type and alias", func() {
+		names := []string{}
+		for _, e := range Manifest(artifacts) {
+			names = append(names, e.File)
+		}
+		Expect(names).To(ConsistOf(
+			"RESULT/goldenUnicorn.log",
+			"RESULT/goldenUnicorn_2.log",
+			"TEST/_bronze_Yak",
+			"IMAGE/"+filepath.Base(string(artifacts[3].Path))))
+	})
+
+	It("should write tar.gz archive", func() {
+		var buf bytes.Buffer
+		Expect(Write(&buf, TarGz, artifacts)).To(Succeed())
+
+		gz, err := gzip.NewReader(&buf)
+		Expect(err).ToNot(HaveOccurred())
+		tr := tar.NewReader(gz)
+
+		hdr, err := tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hdr.Name).To(Equal(ManifestName))
+		data, err := ioutil.ReadAll(tr)
+		Expect(err).ToNot(HaveOccurred())
+		checkManifest(data)
+
+		files := map[string]string{}
+		for {
+			hdr, err = tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+			data, err = ioutil.ReadAll(tr)
+			Expect(err).ToNot(HaveOccurred())
+			files[hdr.Name] = string(data)
+		}
+		Expect(files).To(Equal(expectedFiles()))
+	})
+
+	It("should write zip archive", func() {
+		var buf bytes.Buffer
+		Expect(Write(&buf, Zip, artifacts)).To(Succeed())
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(zr.File).ToNot(BeEmpty())
+		Expect(zr.File[0].Name).To(Equal(ManifestName))
+
+		files := map[string]string{}
+		for i, f := range zr.File {
+			r, err := f.Open()
+			Expect(err).ToNot(HaveOccurred())
+			data, err := ioutil.ReadAll(r)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.Close()).To(Succeed())
+			if i == 0 {
+				checkManifest(data)
+				continue
+			}
+			files[f.Name] = string(data)
+		}
+		Expect(files).To(Equal(expectedFiles()))
+	})
+
+	It("should fail on unknown format", func() {
+		var buf bytes.Buffer
+		Expect(Write(&buf, "rar", artifacts)).To(Equal(ErrUnknownFormat))
+		Expect(buf.Len()).To(BeZero())
+	})
+
+	It("should fail when artifact file is missing", func() {
+		Expect(os.Remove(string(artifacts[1].Path))).To(Succeed())
+		var buf bytes.Buffer
+		Expect(Write(&buf, TarGz, artifacts)).ToNot(Succeed())
+	})
+})
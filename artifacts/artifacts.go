@@ -18,12 +18,16 @@
 package artifacts
 
 import (
+	"context"
 	"errors"
+	"io"
 	"io/ioutil"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-openapi/strfmt"
@@ -35,13 +39,17 @@ import (
 
 // ArtifactDownloader downloads requested file if there is need to.
 type ArtifactDownloader interface {
-	// Download starts downloading requested artifact.
-	Download(URI weles.ArtifactURI, path weles.ArtifactPath, ch chan weles.ArtifactStatusChange,
-	) error
+	// Download starts downloading requested artifact. Download is canceled with ctx.
+	Download(ctx context.Context, URI weles.ArtifactURI, path weles.ArtifactPath,
+		priority weles.Priority, ch chan weles.ArtifactStatusChange) error
 
 	// CheckInCache checks if file already exists in ArtifactDB.
 	CheckInCache(URI weles.ArtifactURI) (weles.ArtifactInfo, error)
 
+	// Invalidate removes file downloaded from URI from cache, so that it is downloaded
+	// again when requested.
+	Invalidate(URI weles.ArtifactURI)
+
 	// Close waits for all jobs to finish, and gracefully closes ArtifactDownloader.
 	Close()
 }
@@ -55,20 +63,68 @@ type Storage struct {
 	dir        string
 	downloader ArtifactDownloader
 	notifier   chan weles.ArtifactStatusChange
+	attempts   chan weles.ArtifactAttempt
+	// progress of artifacts being downloaded. It is not stored in db as it changes
+	// frequently and is meaningful only during download.
+	progress      map[weles.ArtifactPath]weles.ArtifactProgress
+	progressMutex sync.Mutex
+	// downloads of artifacts which are queued or in progress mapped by their paths.
+	downloads      map[weles.ArtifactPath]download
+	downloadsMutex sync.Mutex
+	// users maps IDs of artifacts to Jobs using them besides their owners.
+	users map[int64]map[weles.JobID]bool
+	// jobs provides statuses of Jobs. It is set by StartCollector.
+	jobs       weles.JobManager
+	usersMutex sync.Mutex
+	// metadata tracks computation of metadata of artifacts that became ready.
+	metadata sync.WaitGroup
+	// metadataMutex serializes computing and storing metadata, so metadata
+	// computed from outdated content of a file never overwrites a newer one.
+	metadataMutex sync.Mutex
+	// listening is closed when all notifications about status changes are handled.
+	listening chan struct{}
+	// done is closed to stop the collector.
+	done      chan struct{}
+	collector sync.WaitGroup
+}
+
+// download identifies Job of an artifact being downloaded and cancels the download.
+type download struct {
+	job    weles.JobID
+	cancel context.CancelFunc
 }
 
+// cacheDir is a subdirectory of ArtifactDB directory used for caching downloaded files.
+const cacheDir = "cache"
+
 func newArtifactManager(db, dir string, notifierCap, workersCount, queueCap int,
-) (weles.ArtifactManager, error) {
+	cacheSize int64, retry downloader.RetryPolicy, limits downloader.LimitPolicy,
+	client *http.Client, localRoot string) (*Storage, error) {
 	err := os.MkdirAll(dir, os.ModePerm)
 	if err != nil {
 		return nil, err
 	}
+	var cache *downloader.Cache
+	if cacheSize > 0 {
+		cache, err = downloader.NewCache(filepath.Join(dir, cacheDir), cacheSize)
+		if err != nil {
+			return nil, err
+		}
+	}
 	notifier := make(chan weles.ArtifactStatusChange, notifierCap)
+	attempts := make(chan weles.ArtifactAttempt, notifierCap)
 
 	am := Storage{
-		dir:        dir,
-		downloader: downloader.NewDownloader(notifier, workersCount, queueCap),
-		notifier:   notifier,
+		dir: dir,
+		downloader: downloader.NewDownloader(notifier, attempts, workersCount, queueCap, cache,
+			retry, limits, client, localRoot),
+		notifier:  notifier,
+		attempts:  attempts,
+		progress:  make(map[weles.ArtifactPath]weles.ArtifactProgress),
+		downloads: make(map[weles.ArtifactPath]download),
+		users:     make(map[int64]map[weles.JobID]bool),
+		listening: make(chan struct{}),
+		done:      make(chan struct{}),
 	}
 	err = am.db.Open(db)
 	if err != nil {
@@ -76,26 +132,37 @@ func newArtifactManager(db, dir string, notifierCap, workersCount, queueCap int,
 	}
 
 	go am.listenToChanges()
+	go am.listenToAttempts()
 
 	return &am, nil
 }
 
 // NewArtifactManager returns initialized Storage implementing ArtifactManager interface.
-// If db or dir is empy, default value will be used.
+// If db or dir is empy, default value will be used. Downloaded files are cached
+// up to cacheSize bytes, caching is disabled if cacheSize is 0. Failed downloads
+// are retried according to retry policy. Downloads are limited according to limits.
+// HTTP(S) requests are sent with client or default HTTP client if it is nil.
+// Artifacts with file URIs are copied only from localRoot directory and only if it is set.
 func NewArtifactManager(db, dir string, notifierCap, workersCount, queueCap int,
-) (weles.ArtifactManager, error) {
-	return newArtifactManager(filepath.Join(dir, db), dir, notifierCap, workersCount, queueCap)
+	cacheSize int64, retry downloader.RetryPolicy, limits downloader.LimitPolicy,
+	client *http.Client, localRoot string) (*Storage, error) {
+	return newArtifactManager(filepath.Join(dir, db), dir, notifierCap, workersCount, queueCap,
+		cacheSize, retry, limits, client, localRoot)
 }
 
 // ListArtifact is part of implementation of ArtifactManager interface.
 func (s *Storage) ListArtifact(filter weles.ArtifactFilter, sorter weles.ArtifactSorter,
 	paginator weles.ArtifactPagination) ([]weles.ArtifactInfo, weles.ListInfo, error) {
 
-	return s.db.Filter(filter, sorter, paginator)
+	artifacts, info, err := s.db.Filter(filter, sorter, paginator)
+	for i := range artifacts {
+		s.fillProgress(&artifacts[i])
+	}
+	return artifacts, info, err
 }
 
 // PushArtifact is part of implementation of ArtifactManager interface.
-func (s *Storage) PushArtifact(artifact weles.ArtifactDescription,
+func (s *Storage) PushArtifact(artifact weles.ArtifactDescription, priority weles.Priority,
 	ch chan weles.ArtifactStatusChange) (weles.ArtifactPath, error) {
 
 	path, err := s.CreateArtifact(artifact)
@@ -103,8 +170,14 @@ func (s *Storage) PushArtifact(artifact weles.ArtifactDescription,
 		return "", err
 	}
 
-	err = s.downloader.Download(artifact.URI, path, ch)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.downloadsMutex.Lock()
+	s.downloads[path] = download{job: artifact.JobID, cancel: cancel}
+	s.downloadsMutex.Unlock()
+
+	err = s.downloader.Download(ctx, artifact.URI, path, priority, ch)
 	if err != nil {
+		s.forgetDownload(path)
 		err2 := s.db.SetStatus(weles.ArtifactStatusChange{
 			Path:      path,
 			NewStatus: weles.ArtifactStatusFAILED,
@@ -138,15 +211,273 @@ func (s *Storage) CreateArtifact(artifact weles.ArtifactDescription) (weles.Arti
 	return path, nil
 }
 
+// UploadArtifact is part of implementation of ArtifactManager interface.
+// Uploaded file is removed if the artifact cannot be stored.
+func (s *Storage) UploadArtifact(artifact weles.ArtifactDescription, content io.Reader,
+) (ai weles.ArtifactInfo, err error) {
+	path, err := s.getNewPath(artifact)
+	if err != nil {
+		return weles.ArtifactInfo{}, err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if err2 := os.Remove(string(path)); err2 != nil && !os.IsNotExist(err2) {
+			log.Println("failed to remove uploaded file: " + err2.Error())
+		}
+	}()
+
+	err = writeFile(path, content)
+	if err != nil {
+		return weles.ArtifactInfo{}, err
+	}
+
+	ai = weles.ArtifactInfo{
+		ArtifactDescription: artifact,
+		Path:                path,
+		Status:              weles.ArtifactStatusREADY,
+		Timestamp:           strfmt.DateTime(time.Now().UTC()),
+	}
+	err = fillMetadata(&ai)
+	if err != nil {
+		return weles.ArtifactInfo{}, err
+	}
+	err = s.db.InsertArtifactInfo(&ai)
+	if err != nil {
+		return weles.ArtifactInfo{}, err
+	}
+	return ai, nil
+}
+
 // GetArtifactInfo is part of implementation of ArtifactManager interface.
 func (s *Storage) GetArtifactInfo(path weles.ArtifactPath) (weles.ArtifactInfo, error) {
-	return s.db.SelectPath(path)
+	ai, err := s.db.SelectPath(path)
+	s.fillProgress(&ai)
+	return ai, err
+}
+
+// GetArtifactInfoByID is part of implementation of ArtifactManager interface.
+func (s *Storage) GetArtifactInfoByID(id int64) (weles.ArtifactInfo, error) {
+	ai, err := s.db.SelectID(id)
+	s.fillProgress(&ai)
+	return ai, err
+}
+
+// UseArtifact is part of implementation of ArtifactManager interface.
+func (s *Storage) UseArtifact(id int64, job weles.JobID) (weles.ArtifactInfo, error) {
+	ai, err := s.GetArtifactInfoByID(id)
+	if err != nil {
+		return ai, err
+	}
+	s.usersMutex.Lock()
+	defer s.usersMutex.Unlock()
+	if s.users[id] == nil {
+		s.users[id] = make(map[weles.JobID]bool)
+	}
+	s.users[id][job] = true
+	return ai, nil
+}
+
+// artifactUsers returns Jobs using the artifact besides its owner.
+func (s *Storage) artifactUsers(id int64) []weles.JobID {
+	s.usersMutex.Lock()
+	defer s.usersMutex.Unlock()
+	users := make([]weles.JobID, 0, len(s.users[id]))
+	for j := range s.users[id] {
+		users = append(users, j)
+	}
+	return users
+}
+
+// checkNotInUse returns ErrArtifactInUse if any of the artifacts belongs to or
+// is used by an active Job. Statuses of Jobs are unknown before StartCollector
+// is called, so no artifact is considered used then.
+func (s *Storage) checkNotInUse(artifacts ...weles.ArtifactInfo) error {
+	s.usersMutex.Lock()
+	jobs := s.jobs
+	s.usersMutex.Unlock()
+	if jobs == nil {
+		return nil
+	}
+	var ids []weles.JobID
+	for _, ai := range artifacts {
+		ids = append(append(ids, ai.JobID), s.artifactUsers(ai.ID)...)
+	}
+	statuses, err := jobStatuses(jobs, ids)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if isActive(statuses[id]) {
+			return weles.ErrArtifactInUse
+		}
+	}
+	return nil
+}
+
+// forgetUser stops tracking usage of the artifact by the Job.
+func (s *Storage) forgetUser(id int64, job weles.JobID) {
+	s.usersMutex.Lock()
+	defer s.usersMutex.Unlock()
+	delete(s.users[id], job)
+	if len(s.users[id]) == 0 {
+		delete(s.users, id)
+	}
+}
+
+// GetArtifactAttempts is part of implementation of ArtifactManager interface.
+func (s *Storage) GetArtifactAttempts(path weles.ArtifactPath) ([]weles.ArtifactAttempt, error) {
+	return s.db.SelectAttempts(path)
+}
+
+// SetArtifactStatus is part of implementation of ArtifactManager interface.
+// Metadata of the artifact is refreshed when it becomes ready as its file
+// may have been modified or, as for pulled results, created without ArtifactManager.
+// If the artifact fails (e.g. its checksum does not match), cached file downloaded
+// from its URI is invalidated, as it may be corrupted too. Metadata of partial file
+// left by failed artifact is stored, so that its size is known to the collector.
+func (s *Storage) SetArtifactStatus(change weles.ArtifactStatusChange) error {
+	err := s.db.SetStatus(change)
+	if err != nil {
+		return err
+	}
+	if change.NewStatus == weles.ArtifactStatusFAILED {
+		ai, err := s.db.SelectPath(change.Path)
+		if err != nil {
+			return err
+		}
+		if ai.URI != "" {
+			s.downloader.Invalidate(ai.URI)
+		}
+		if fi, err := os.Stat(string(ai.Path)); err != nil || !fi.Mode().IsRegular() {
+			return nil
+		}
+		return s.refreshMetadata(change.Path)
+	}
+	if change.NewStatus != weles.ArtifactStatusREADY {
+		return nil
+	}
+	return s.refreshMetadata(change.Path)
+}
+
+// refreshMetadata computes metadata of the file of artifact in path and stores it.
+func (s *Storage) refreshMetadata(path weles.ArtifactPath) error {
+	s.metadataMutex.Lock()
+	defer s.metadataMutex.Unlock()
+	ai, err := s.db.SelectPath(path)
+	if err != nil {
+		return err
+	}
+	err = fillMetadata(&ai)
+	if err != nil {
+		return err
+	}
+	return s.db.SetMetadata(ai)
+}
+
+// DeleteArtifact is part of implementation of ArtifactManager interface.
+func (s *Storage) DeleteArtifact(id int64) error {
+	ai, err := s.db.SelectID(id)
+	if err != nil {
+		return err
+	}
+	if isBusy(ai.Status) {
+		return weles.ErrArtifactNotReady
+	}
+	if err = s.checkNotInUse(ai); err != nil {
+		return err
+	}
+	return s.delete(ai)
+}
+
+// DeleteJobArtifacts is part of implementation of ArtifactManager interface.
+// Directory of the job is removed if it becomes empty.
+func (s *Storage) DeleteJobArtifacts(job weles.JobID) error {
+	artifacts, err := s.db.SelectJob(job)
+	if err != nil {
+		return err
+	}
+	if len(artifacts) == 0 {
+		return weles.ErrArtifactNotFound
+	}
+	for _, ai := range artifacts {
+		if isBusy(ai.Status) {
+			return weles.ErrArtifactNotReady
+		}
+	}
+	if err = s.checkNotInUse(artifacts...); err != nil {
+		return err
+	}
+	dirs := make(map[string]bool)
+	for _, ai := range artifacts {
+		if err = s.delete(ai); err != nil {
+			return err
+		}
+		dirs[filepath.Dir(string(ai.Path))] = true
+	}
+	for dir := range dirs {
+		// Directories are removed only if they are empty, so errors are expected.
+		_ = os.Remove(dir)
+	}
+	_ = os.Remove(filepath.Join(s.dir, strconv.FormatUint(uint64(job), 10)))
+	return nil
+}
+
+// CancelJobArtifacts is part of implementation of ArtifactManager interface.
+func (s *Storage) CancelJobArtifacts(job weles.JobID) error {
+	s.downloadsMutex.Lock()
+	defer s.downloadsMutex.Unlock()
+	for _, d := range s.downloads {
+		if d.job == job {
+			d.cancel()
+		}
+	}
+	return nil
+}
+
+// forgetDownload stops tracking download of the artifact and releases its resources.
+func (s *Storage) forgetDownload(path weles.ArtifactPath) {
+	s.downloadsMutex.Lock()
+	defer s.downloadsMutex.Unlock()
+	if d, ok := s.downloads[path]; ok {
+		d.cancel()
+		delete(s.downloads, path)
+	}
+}
+
+// SetArtifactPinned is part of implementation of ArtifactManager interface.
+func (s *Storage) SetArtifactPinned(id int64, pinned bool) error {
+	ai, err := s.db.SelectID(id)
+	if err != nil {
+		return err
+	}
+	return s.db.SetPinned(ai.Path, pinned)
+}
+
+// isBusy returns true if artifact in given status is being downloaded.
+func isBusy(status weles.ArtifactStatus) bool {
+	return status == weles.ArtifactStatusPENDING || status == weles.ArtifactStatusDOWNLOADING
+}
+
+// delete removes file and record of the artifact.
+func (s *Storage) delete(ai weles.ArtifactInfo) error {
+	err := os.Remove(string(ai.Path))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.db.Delete(ai.Path)
 }
 
 // Close closes Storage's ArtifactDB.
 func (s *Storage) Close() error {
+	close(s.done)
+	s.collector.Wait()
 	s.downloader.Close()
 	close(s.notifier)
+	close(s.attempts)
+	<-s.listening
+	s.metadata.Wait()
 	return s.db.Close()
 }
 
@@ -179,14 +510,84 @@ func (s *Storage) getNewPath(ad weles.ArtifactDescription) (weles.ArtifactPath,
 	return weles.ArtifactPath(f.Name()), err
 }
 
+// writeFile copies content to the file at path.
+func writeFile(path weles.ArtifactPath, content io.Reader) (err error) {
+	f, err := os.OpenFile(string(path), os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err2 := f.Close(); err == nil {
+			err = err2
+		}
+	}()
+	_, err = io.Copy(f, content)
+	return err
+}
+
 // listenToChanges updates artifact's status in db every time Storage is notified
 // about status change.
 func (s *Storage) listenToChanges() {
+	defer close(s.listening)
 	for change := range s.notifier {
+		if s.updateProgress(change) {
+			continue
+		}
+		if !isBusy(change.NewStatus) {
+			s.forgetDownload(change.Path)
+		}
 		// Error handled in SetStatus function.
 		err := s.db.SetStatus(change)
 		if err != nil {
 			log.Println("Failed to set status of artifact.")
+			continue
+		}
+		if change.NewStatus == weles.ArtifactStatusREADY {
+			// Hashing big files takes time, so it should not block status updates.
+			s.metadata.Add(1)
+			go func(change weles.ArtifactStatusChange) {
+				defer s.metadata.Done()
+				s.storeMetadata(change)
+			}(change)
+		}
+	}
+}
+
+// listenToAttempts stores history of download attempts in db.
+func (s *Storage) listenToAttempts() {
+	for attempt := range s.attempts {
+		attempt := attempt
+		if err := s.db.InsertAttempt(&attempt); err != nil {
+			log.Println("Failed to store download attempt of artifact.")
 		}
 	}
 }
+
+// updateProgress stores progress of the artifact reported in change. Progress
+// is forgotten when download is finished. It returns true if change only reports
+// progress and status of the artifact should not be updated.
+func (s *Storage) updateProgress(change weles.ArtifactStatusChange) bool {
+	s.progressMutex.Lock()
+	defer s.progressMutex.Unlock()
+	if change.Progress != nil {
+		s.progress[change.Path] = *change.Progress
+		return true
+	}
+	if change.NewStatus != weles.ArtifactStatusDOWNLOADING {
+		delete(s.progress, change.Path)
+	}
+	return false
+}
+
+// fillProgress sets download progress of the artifact if it is being downloaded.
+func (s *Storage) fillProgress(ai *weles.ArtifactInfo) {
+	s.progressMutex.Lock()
+	defer s.progressMutex.Unlock()
+	p, ok := s.progress[ai.Path]
+	if !ok {
+		return
+	}
+	ai.ReceivedBytes = p.Received
+	ai.TotalBytes = p.Total
+	ai.DownloadRate = p.Rate
+}
@@ -18,7 +18,10 @@
 package artifacts
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -26,8 +29,14 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/golang/mock/gomock"
 
 	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/artifacts/downloader"
+	"github.com/SamsungSLAV/weles/mock"
 
 	_ "github.com/mattn/go-sqlite3"
 	. "github.com/onsi/ginkgo"
@@ -49,6 +58,9 @@ With gently smiling jaws!
 
 -Lewis Carroll`
 
+	poemSum := sha256.Sum256([]byte(poem))
+	poemSHA256 := hex.EncodeToString(poemSum[:])
+
 	var (
 		testDir string
 		dbPath  string
@@ -90,7 +102,8 @@ With gently smiling jaws!
 		Expect(err).ToNot(HaveOccurred())
 		dbPath = filepath.Join(testDir, "test.db")
 
-		silverKangaroo, err = newArtifactManager(dbPath, testDir, 100, 16, 100)
+		silverKangaroo, err = newArtifactManager(dbPath, testDir, 100, 16, 100, 0,
+			downloader.DefaultRetryPolicy, downloader.LimitPolicy{}, nil, "")
 		//TODO add tests against different notifier cap, queue cap and workers count.
 		Expect(err).ToNot(HaveOccurred())
 	})
@@ -196,7 +209,8 @@ With gently smiling jaws!
 		)
 
 		DescribeTable("NewArtifactManager()", func(db, dir string) {
-			copperPanda, err := NewArtifactManager(db, dir, 100, 16, 100)
+			copperPanda, err := NewArtifactManager(db, dir, 100, 16, 100, 0,
+				downloader.DefaultRetryPolicy, downloader.LimitPolicy{}, nil, "")
 			//TODO: add tests against different notifier cap and workers count.
 			Expect(err).ToNot(HaveOccurred())
 
@@ -252,7 +266,7 @@ With gently smiling jaws!
 				defer ts.Close()
 				ad.URI = weles.ArtifactURI(ts.URL)
 
-				path, err := silverKangaroo.PushArtifact(ad, ch)
+				path, err := silverKangaroo.PushArtifact(ad, weles.MEDIUM, ch)
 
 				Expect(err).ToNot(HaveOccurred())
 
@@ -289,9 +303,435 @@ With gently smiling jaws!
 
 				By("Check if artifact is in ArtifactDB")
 				Expect(checkPathInDb(path)).To(BeTrue())
+
+				if finalStatus == weles.ArtifactStatusREADY {
+					By("Check if metadata of artifact is stored")
+					Eventually(func() string {
+						ai, err := silverKangaroo.GetArtifactInfo(path)
+						Expect(err).ToNot(HaveOccurred())
+						return ai.SHA256
+					}).Should(Equal(poemSHA256))
+					ai, err := silverKangaroo.GetArtifactInfo(path)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(ai.Size).To(BeEquivalentTo(len(poem)))
+					Expect(ai.MimeType).To(Equal("text/plain; charset=utf-8"))
+				}
+
+				By("Check if download attempt is recorded")
+				Eventually(func() []weles.ArtifactAttempt {
+					attempts, err := silverKangaroo.GetArtifactAttempts(path)
+					Expect(err).ToNot(HaveOccurred())
+					return attempts
+				}).Should(HaveLen(1))
 			},
 			Entry("push artifact to db and download file", ad, weles.ArtifactStatusREADY),
 			Entry("do not push an invalid artifact", adInvalid, weles.ArtifactStatusFAILED),
 		)
+
+		It("should share download of the same URI between artifacts", func() {
+			var requests int32
+			release := make(chan struct{})
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				<-release
+				fmt.Fprint(w, poem)
+			}))
+			defer ts.Close()
+			shared := ad
+			shared.URI = weles.ArtifactURI(ts.URL)
+
+			first, err := silverKangaroo.PushArtifact(shared, weles.MEDIUM, ch)
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(func() int32 { return atomic.LoadInt32(&requests) }).Should(
+				BeEquivalentTo(1))
+			second, err := silverKangaroo.PushArtifact(shared, weles.MEDIUM, ch)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(second).NotTo(Equal(first))
+			close(release)
+
+			for _, path := range []weles.ArtifactPath{first, second} {
+				Eventually(func() weles.ArtifactStatus {
+					ai, err := silverKangaroo.GetArtifactInfo(path)
+					Expect(err).ToNot(HaveOccurred())
+					return ai.Status
+				}).Should(Equal(weles.ArtifactStatusREADY))
+				content, err := ioutil.ReadFile(string(path))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal(poem))
+			}
+			Expect(atomic.LoadInt32(&requests)).To(BeEquivalentTo(1))
+		})
+
+		It("should cancel downloads of artifacts of a Job", func() {
+			release := make(chan struct{})
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-release
+				fmt.Fprint(w, poem)
+			}))
+			defer ts.Close()
+			canceled := ad
+			canceled.URI = weles.ArtifactURI(ts.URL + "/canceled")
+			other := ad
+			other.JobID = job + 1
+			other.URI = weles.ArtifactURI(ts.URL + "/other")
+
+			canceledPath, err := silverKangaroo.PushArtifact(canceled, weles.MEDIUM, ch)
+			Expect(err).ToNot(HaveOccurred())
+			otherPath, err := silverKangaroo.PushArtifact(other, weles.MEDIUM, ch)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(silverKangaroo.CancelJobArtifacts(job)).To(Succeed())
+			status := func(path weles.ArtifactPath) func() weles.ArtifactStatus {
+				return func() weles.ArtifactStatus {
+					ai, err := silverKangaroo.GetArtifactInfo(path)
+					Expect(err).ToNot(HaveOccurred())
+					return ai.Status
+				}
+			}
+			Eventually(status(canceledPath)).Should(Equal(weles.ArtifactStatusCANCELED))
+			close(release)
+
+			Eventually(status(otherPath)).Should(Equal(weles.ArtifactStatusREADY))
+			Expect(string(canceledPath)).NotTo(BeAnExistingFile())
+		})
+	})
+	Describe("download progress", func() {
+		It("should expose progress of artifact being downloaded", func() {
+			path, err := silverKangaroo.CreateArtifact(weles.ArtifactDescription{
+				Alias: "progress",
+				JobID: job,
+				Type:  weles.ArtifactTypeIMAGE,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			storage := silverKangaroo.(*Storage)
+			progress := weles.ArtifactProgress{Received: 10, Total: 40, Rate: 5}
+
+			Expect(storage.updateProgress(weles.ArtifactStatusChange{
+				Path:      path,
+				NewStatus: weles.ArtifactStatusDOWNLOADING,
+				Progress:  &progress,
+			})).To(BeTrue())
+			ai, err := silverKangaroo.GetArtifactInfo(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ai.ReceivedBytes).To(BeEquivalentTo(10))
+			Expect(ai.TotalBytes).To(BeEquivalentTo(40))
+			Expect(ai.DownloadRate).To(BeEquivalentTo(5))
+
+			Expect(storage.updateProgress(weles.ArtifactStatusChange{
+				Path:      path,
+				NewStatus: weles.ArtifactStatusREADY,
+			})).To(BeFalse())
+			ai, err = silverKangaroo.GetArtifactInfoByID(ai.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ai.ReceivedBytes).To(BeZero())
+			Expect(ai.TotalBytes).To(BeZero())
+		})
+	})
+
+	Describe("SetArtifactStatus", func() {
+		It("should refresh metadata of artifact which becomes ready", func() {
+			path, err := silverKangaroo.CreateArtifact(weles.ArtifactDescription{
+				Alias: "modified",
+				JobID: job,
+				Type:  weles.ArtifactTypeIMAGE,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ioutil.WriteFile(string(path), []byte(poem), 0644)).To(Succeed())
+
+			err = silverKangaroo.SetArtifactStatus(weles.ArtifactStatusChange{
+				Path:      path,
+				NewStatus: weles.ArtifactStatusREADY,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			ai, err := silverKangaroo.GetArtifactInfo(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ai.Status).To(Equal(weles.ArtifactStatusREADY))
+			Expect(ai.Size).To(BeEquivalentTo(len(poem)))
+			Expect(ai.SHA256).To(Equal(poemSHA256))
+		})
+
+		Describe("pulled result", func() {
+			var path weles.ArtifactPath
+
+			BeforeEach(func() {
+				// Result is created as for a pull action and its file is then
+				// copied from the device bypassing ArtifactManager.
+				var err error
+				path, err = silverKangaroo.CreateArtifact(weles.ArtifactDescription{
+					Alias: "pulled",
+					JobID: job,
+					Type:  weles.ArtifactTypeRESULT,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ioutil.WriteFile(string(path), []byte(poem), 0644)).To(Succeed())
+			})
+
+			It("should have metadata filled when it becomes ready", func() {
+				err := silverKangaroo.SetArtifactStatus(weles.ArtifactStatusChange{
+					Path:      path,
+					NewStatus: weles.ArtifactStatusREADY,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				list, _, err := silverKangaroo.ListArtifact(weles.ArtifactFilter{
+					JobID:  []weles.JobID{job},
+					Type:   []weles.ArtifactType{weles.ArtifactTypeRESULT},
+					Status: []weles.ArtifactStatus{weles.ArtifactStatusREADY},
+				}, weles.ArtifactSorter{}, weles.ArtifactPagination{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(list).To(HaveLen(1))
+				Expect(list[0].Path).To(Equal(path))
+				Expect(list[0].Size).To(BeEquivalentTo(len(poem)))
+				Expect(list[0].SHA256).To(Equal(poemSHA256))
+				Expect(list[0].MimeType).To(Equal("text/plain; charset=utf-8"))
+			})
+
+			It("should have size of partial file stored when it fails", func() {
+				err := silverKangaroo.SetArtifactStatus(weles.ArtifactStatusChange{
+					Path:      path,
+					NewStatus: weles.ArtifactStatusFAILED,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				ai, err := silverKangaroo.GetArtifactInfo(path)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ai.Status).To(Equal(weles.ArtifactStatusFAILED))
+				Expect(ai.Size).To(BeEquivalentTo(len(poem)))
+			})
+		})
+
+		It("should invalidate cached file of failed artifact", func() {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+				r *http.Request) {
+				w.Header().Set("ETag", `"crocodile"`)
+				fmt.Fprint(w, poem)
+			}))
+			defer ts.Close()
+			cacheDir, err := ioutil.TempDir("", "test-weles-")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(cacheDir)
+			goldenEagle, err := newArtifactManager(filepath.Join(cacheDir, "test.db"), cacheDir,
+				100, 1, 100, 1024, downloader.DefaultRetryPolicy, downloader.LimitPolicy{}, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			defer goldenEagle.Close()
+
+			ch := make(chan weles.ArtifactStatusChange, 20)
+			uri := weles.ArtifactURI(ts.URL)
+			path, err := goldenEagle.PushArtifact(weles.ArtifactDescription{
+				Alias: "cached",
+				JobID: job,
+				Type:  weles.ArtifactTypeIMAGE,
+				URI:   uri,
+			}, weles.MEDIUM, ch)
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(func() weles.ArtifactStatus {
+				ai, erro := goldenEagle.GetArtifactInfo(path)
+				Expect(erro).ToNot(HaveOccurred())
+				return ai.Status
+			}).Should(Equal(weles.ArtifactStatusREADY))
+			_, err = goldenEagle.downloader.CheckInCache(uri)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(goldenEagle.SetArtifactStatus(weles.ArtifactStatusChange{
+				Path:      path,
+				NewStatus: weles.ArtifactStatusFAILED,
+			})).To(Succeed())
+			_, err = goldenEagle.downloader.CheckInCache(uri)
+			Expect(err).To(Equal(downloader.ErrNotInCache))
+		})
+	})
+
+	Describe("deleting and pinning", func() {
+		upload := func(job weles.JobID) weles.ArtifactInfo {
+			ai, err := silverKangaroo.UploadArtifact(weles.ArtifactDescription{
+				Alias: "crocodile",
+				JobID: job,
+				Type:  weles.ArtifactTypeRESULT,
+			}, strings.NewReader(poem))
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			return ai
+		}
+
+		It("should delete file and record of artifact", func() {
+			ai := upload(job)
+
+			Expect(silverKangaroo.DeleteArtifact(ai.ID)).To(Succeed())
+
+			Expect(string(ai.Path)).NotTo(BeAnExistingFile())
+			Expect(checkPathInDb(ai.Path)).To(BeFalse())
+			Expect(silverKangaroo.DeleteArtifact(ai.ID)).To(Equal(weles.ErrArtifactNotFound))
+		})
+
+		downloading := func() weles.ArtifactPath {
+			path, err := silverKangaroo.CreateArtifact(description)
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			ExpectWithOffset(1, silverKangaroo.SetArtifactStatus(weles.ArtifactStatusChange{
+				Path:      path,
+				NewStatus: weles.ArtifactStatusDOWNLOADING,
+			})).To(Succeed())
+			return path
+		}
+
+		It("should not delete artifact being downloaded", func() {
+			path := downloading()
+			ai, err := silverKangaroo.GetArtifactInfo(path)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(silverKangaroo.DeleteArtifact(ai.ID)).To(Equal(weles.ErrArtifactNotReady))
+			Expect(checkPathInDb(path)).To(BeTrue())
+		})
+
+		It("should delete all artifacts of job and its directory", func() {
+			first := upload(job)
+			second := upload(job)
+			other := upload(job + 1)
+
+			Expect(silverKangaroo.DeleteJobArtifacts(job)).To(Succeed())
+
+			Expect(checkPathInDb(first.Path)).To(BeFalse())
+			Expect(checkPathInDb(second.Path)).To(BeFalse())
+			Expect(checkPathInDb(other.Path)).To(BeTrue())
+			Expect(filepath.Join(testDir, strconv.Itoa(int(job)))).NotTo(BeADirectory())
+			Expect(silverKangaroo.DeleteJobArtifacts(job)).To(Equal(weles.ErrArtifactNotFound))
+		})
+
+		It("should not delete artifacts of job if any is being downloaded", func() {
+			ai := upload(job)
+			downloading()
+
+			Expect(silverKangaroo.DeleteJobArtifacts(job)).To(Equal(weles.ErrArtifactNotReady))
+			Expect(string(ai.Path)).To(BeAnExistingFile())
+		})
+
+		Describe("artifacts of active jobs", func() {
+			const activeJob weles.JobID = 7
+			var ctrl *gomock.Controller
+
+			BeforeEach(func() {
+				ctrl = gomock.NewController(GinkgoT())
+				jm := mock.NewMockJobManager(ctrl)
+				jm.EXPECT().ListJobs(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					[]weles.JobInfo{
+						{JobID: job, Status: weles.JobStatusCOMPLETED},
+						{JobID: activeJob, Status: weles.JobStatusRUNNING},
+					}, weles.ListInfo{}, nil).AnyTimes()
+				silverKangaroo.(*Storage).StartCollector(RetentionPolicy{}, jm)
+			})
+
+			AfterEach(func() {
+				ctrl.Finish()
+			})
+
+			It("should not delete artifact of active job", func() {
+				ai := upload(activeJob)
+
+				Expect(silverKangaroo.DeleteArtifact(ai.ID)).To(Equal(weles.ErrArtifactInUse))
+				Expect(silverKangaroo.DeleteJobArtifacts(activeJob)).To(
+					Equal(weles.ErrArtifactInUse))
+				Expect(string(ai.Path)).To(BeAnExistingFile())
+			})
+
+			It("should not delete uploaded artifact used by active job", func() {
+				ai := upload(0)
+				_, err := silverKangaroo.UseArtifact(ai.ID, activeJob)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(silverKangaroo.DeleteArtifact(ai.ID)).To(Equal(weles.ErrArtifactInUse))
+				Expect(string(ai.Path)).To(BeAnExistingFile())
+			})
+
+			It("should delete artifacts used only by finished jobs", func() {
+				ai := upload(0)
+				_, err := silverKangaroo.UseArtifact(ai.ID, job)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(silverKangaroo.DeleteArtifact(ai.ID)).To(Succeed())
+				Expect(string(ai.Path)).NotTo(BeAnExistingFile())
+			})
+		})
+
+		It("should pin and unpin artifact", func() {
+			ai := upload(job)
+
+			Expect(silverKangaroo.SetArtifactPinned(ai.ID, true)).To(Succeed())
+			info, err := silverKangaroo.GetArtifactInfoByID(ai.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Pinned).To(BeTrue())
+
+			Expect(silverKangaroo.SetArtifactPinned(ai.ID, false)).To(Succeed())
+			info, err = silverKangaroo.GetArtifactInfoByID(ai.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Pinned).To(BeFalse())
+
+			Expect(silverKangaroo.SetArtifactPinned(1234, true)).To(
+				Equal(weles.ErrArtifactNotFound))
+		})
+	})
+
+	Describe("UploadArtifact", func() {
+		uploaded := weles.ArtifactDescription{
+			Alias: "uploaded",
+			Type:  weles.ArtifactTypeTEST,
+		}
+
+		It("should store content as a ready artifact", func() {
+			ai, err := silverKangaroo.UploadArtifact(uploaded, strings.NewReader(poem))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ai.ID).NotTo(BeZero())
+			Expect(ai.ArtifactDescription).To(Equal(uploaded))
+			Expect(ai.Status).To(Equal(weles.ArtifactStatusREADY))
+
+			content, err := ioutil.ReadFile(string(ai.Path))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(BeIdenticalTo(poem))
+
+			byID, err := silverKangaroo.GetArtifactInfoByID(ai.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(byID.Path).To(Equal(ai.Path))
+			Expect(byID.Status).To(Equal(weles.ArtifactStatusREADY))
+			Expect(byID.Size).To(BeEquivalentTo(len(poem)))
+			Expect(byID.SHA256).To(Equal(poemSHA256))
+			Expect(byID.MimeType).To(Equal("text/plain; charset=utf-8"))
+		})
+
+		It("should not leave a file when content cannot be read", func() {
+			dir := filepath.Join(testDir, "0", string(uploaded.Type))
+
+			_, err := silverKangaroo.UploadArtifact(uploaded, errReader{})
+			Expect(err).To(HaveOccurred())
+
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(BeEmpty())
+		})
+
+		It("should not leave a file when artifact cannot be stored in database", func() {
+			dir := filepath.Join(testDir, "0", string(uploaded.Type))
+			db, err := sql.Open("sqlite3", dbPath)
+			Expect(err).ToNot(HaveOccurred())
+			defer db.Close()
+			_, err = db.Exec(`create trigger fail before insert on artifacts
+				begin select raise(abort, 'database is full'); end`)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = silverKangaroo.UploadArtifact(uploaded, strings.NewReader(poem))
+			Expect(err).To(HaveOccurred())
+
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(BeEmpty())
+		})
+
+		It("should return ErrArtifactNotFound for unknown ID", func() {
+			_, err := silverKangaroo.GetArtifactInfoByID(1234)
+			Expect(err).To(Equal(weles.ErrArtifactNotFound))
+		})
 	})
 })
+
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error) {
+	return 0, errors.New("reader error")
+}
@@ -0,0 +1,167 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// Package checksum is responsible for verification of artifacts against checksum files.
+package checksum
+
+import (
+	"bufio"
+	"crypto/md5"  // nolint:gosec
+	"crypto/sha1" // nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrUnknownType is returned when checksum type is not supported.
+	ErrUnknownType = errors.New("unknown checksum type")
+	// ErrMalformed is returned when checksum file cannot be parsed.
+	ErrMalformed = errors.New("malformed checksum file")
+	// ErrNotFound is returned when checksum file lists no entry for the verified file.
+	ErrNotFound = errors.New("no checksum for the file")
+)
+
+// hashes maps supported checksum types to hash constructors.
+var hashes = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// typeByLength maps length of hex encoded hash to checksum type.
+var typeByLength = map[int]string{
+	2 * md5.Size:    "md5",
+	2 * sha1.Size:   "sha1",
+	2 * sha256.Size: "sha256",
+	2 * sha512.Size: "sha512",
+}
+
+// Supported returns true if checksum type t is supported. Empty type is
+// supported and means that type is detected from the length of a checksum.
+func Supported(t string) bool {
+	if t == "" {
+		return true
+	}
+	_, ok := hashes[strings.ToLower(t)]
+	return ok
+}
+
+// Verify checks if file at filePath matches checksum stored in a file at
+// checksumPath. Checksum file may contain bare hash or output of *sum tools
+// (e.g. sha256sum). In the latter case, entry matching name is used if there
+// are many. If checksumType is empty, it is detected from the hash length.
+func Verify(filePath, checksumPath, checksumType, name string) error {
+	expected, err := parseFile(checksumPath, name)
+	if err != nil {
+		return err
+	}
+
+	if checksumType == "" {
+		checksumType = typeByLength[len(expected)]
+	}
+	newHash, ok := hashes[strings.ToLower(checksumType)]
+	if !ok {
+		return ErrUnknownType
+	}
+	if len(expected) != 2*newHash().Size() {
+		return fmt.Errorf("%v: %s hash expected, got %q", ErrMalformed, checksumType, expected)
+	}
+
+	actual, err := sum(filePath, newHash())
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("%s checksum mismatch: expected %s, got %s", checksumType, expected,
+			actual)
+	}
+	return nil
+}
+
+// parseFile returns hex encoded hash stored in checksum file for name.
+func parseFile(checksumPath, name string) (string, error) {
+	f, err := os.Open(checksumPath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if erro := f.Close(); erro != nil {
+			log.Println("failed to close checksum file: " + erro.Error())
+		}
+	}()
+	return parse(f, path.Base(name))
+}
+
+// parse reads entries from checksum file. A single entry is returned regardless
+// of its file name. If there are many entries, the one matching name is returned.
+func parse(r io.Reader, name string) (string, error) {
+	var entries [][]string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if _, err := hex.DecodeString(fields[0]); err != nil {
+			return "", ErrMalformed
+		}
+		entries = append(entries, fields)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	switch len(entries) {
+	case 0:
+		return "", ErrMalformed
+	case 1:
+		return strings.ToLower(entries[0][0]), nil
+	}
+	for _, e := range entries {
+		// Binary mode entries of *sum tools are prefixed with asterisk.
+		if len(e) > 1 && path.Base(strings.TrimPrefix(e[1], "*")) == name {
+			return strings.ToLower(e[0]), nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// sum returns hex encoded hash of the file content.
+func sum(filePath string, h hash.Hash) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if erro := f.Close(); erro != nil {
+			log.Println("failed to close file: " + erro.Error())
+		}
+	}()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
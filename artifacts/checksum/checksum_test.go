@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package checksum
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checksum", func() {
+	const (
+		content = "hello\n"
+		md5sum  = "b1946ac92492d2347c6235b4d2611184"
+		sha1sum = "f572d396fae9206628714fb2ce00f72e94f2258f"
+		sha256  = "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03"
+		sha512  = "e7c22b994c59d9cf2b48e549b1e24666636045930d3da7c1acb299d1c3b7f931" +
+			"f94aae41edda2c2b207a36e10f8bcb8d45223e54878f5b316e7ce3b6bc019629"
+	)
+
+	var (
+		tmpDir   string
+		filePath string
+	)
+
+	writeChecksum := func(data string) string {
+		p := filepath.Join(tmpDir, "checksum")
+		Expect(ioutil.WriteFile(p, []byte(data), 0644)).To(Succeed())
+		return p
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "weles-")
+		Expect(err).ToNot(HaveOccurred())
+		filePath = filepath.Join(tmpDir, "image")
+		Expect(ioutil.WriteFile(filePath, []byte(content), 0644)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	DescribeTable("should accept matching checksums",
+		func(checksumType, data string) {
+			err := Verify(filePath, writeChecksum(data), checksumType,
+				"http://example.com/images/image.img")
+			Expect(err).ToNot(HaveOccurred())
+		},
+		Entry("bare md5", "md5", md5sum+"\n"),
+		Entry("bare sha1", "sha1", sha1sum),
+		Entry("sha256sum format", "sha256", sha256+"  image.img\n"),
+		Entry("sha512sum binary format", "sha512", sha512+" *image.img\n"),
+		Entry("upper case type and hash", "MD5", "B1946AC92492D2347C6235B4D2611184"),
+		Entry("detected type", "", sha256),
+		Entry("matching entry of many", "md5",
+			"00000000000000000000000000000000  other.img\n"+md5sum+"  image.img\n"),
+	)
+
+	DescribeTable("should reject invalid checksums",
+		func(checksumType, data string, expected string) {
+			err := Verify(filePath, writeChecksum(data), checksumType, "image.img")
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("mismatch", "md5", "00000000000000000000000000000000", "md5 checksum mismatch: "+
+			"expected 00000000000000000000000000000000, got "+md5sum),
+		Entry("unknown type", "crc32", md5sum, ErrUnknownType.Error()),
+		Entry("wrong hash length", "sha256", md5sum,
+			`malformed checksum file: sha256 hash expected, got "`+md5sum+`"`),
+		Entry("not a hash", "md5", "<html>Not Found</html>", ErrMalformed.Error()),
+		Entry("empty file", "md5", "", ErrMalformed.Error()),
+		Entry("no matching entry", "md5",
+			md5sum+"  other.img\n"+md5sum+"  another.img\n", ErrNotFound.Error()),
+	)
+
+	It("should report supported checksum types", func() {
+		for _, t := range []string{"", "md5", "SHA1", "sha256", "sha512"} {
+			Expect(Supported(t)).To(BeTrue(), t)
+		}
+		Expect(Supported("crc32")).To(BeFalse())
+	})
+})
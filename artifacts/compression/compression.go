@@ -0,0 +1,176 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// Package compression is responsible for decompression of downloaded artifacts.
+package compression
+
+import (
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// Supported compression formats.
+const (
+	Gzip  = "gz"
+	Xz    = "xz"
+	Bzip2 = "bz2"
+	Zstd  = "zstd"
+	Zip   = "zip"
+)
+
+// TempSuffix is suffix of temporary files holding decompressed content of artifacts
+// before they replace the compressed ones.
+const TempSuffix = ".decompressed"
+
+var (
+	// ErrUnknownCompression is returned when compression format is not supported.
+	ErrUnknownCompression = errors.New("unknown compression format")
+	// ErrZipContent is returned when zip archive does not contain exactly one file.
+	ErrZipContent = errors.New("zip archive must contain exactly one file")
+)
+
+// readers maps stream compression formats to functions creating decompressing readers.
+var readers = map[string]func(io.Reader) (io.ReadCloser, error){
+	Gzip: func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	},
+	Xz: func(r io.Reader) (io.ReadCloser, error) {
+		xr, err := xz.NewReader(r)
+		return ioutil.NopCloser(xr), err
+	},
+	Bzip2: func(r io.Reader) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bzip2.NewReader(r)), nil
+	},
+	Zstd: func(r io.Reader) (io.ReadCloser, error) {
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	},
+}
+
+// Supported returns true if compression format c is supported. Empty format
+// means that file is not compressed.
+func Supported(c string) bool {
+	if c == "" || c == Zip {
+		return true
+	}
+	_, ok := readers[c]
+	return ok
+}
+
+// Decompress decompresses file src compressed with format c and writes
+// the result to dst.
+func Decompress(src, dst, c string) (err error) {
+	in, err := open(src, c)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if erro := in.Close(); erro != nil {
+			log.Println("failed to close decompressed file: " + erro.Error())
+		}
+	}()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if erro := out.Close(); err == nil {
+			err = erro
+		}
+	}()
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// open returns reader of decompressed content of file src.
+func open(src, c string) (io.ReadCloser, error) {
+	if c == Zip {
+		return openZip(src)
+	}
+	newReader, ok := readers[c]
+	if !ok {
+		return nil, ErrUnknownCompression
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	r, err := newReader(f)
+	if err != nil {
+		if erro := f.Close(); erro != nil {
+			log.Println("failed to close compressed file: " + erro.Error())
+		}
+		return nil, err
+	}
+	return &readCloser{Reader: r, closers: []io.Closer{r, f}}, nil
+}
+
+// openZip returns reader of the only file stored in zip archive src.
+func openZip(src string) (io.ReadCloser, error) {
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, err
+	}
+	var files []*zip.File
+	for _, f := range zr.File {
+		if f.Mode().IsRegular() {
+			files = append(files, f)
+		}
+	}
+	if len(files) != 1 {
+		if erro := zr.Close(); erro != nil {
+			log.Println("failed to close zip archive: " + erro.Error())
+		}
+		return nil, ErrZipContent
+	}
+	r, err := files[0].Open()
+	if err != nil {
+		if erro := zr.Close(); erro != nil {
+			log.Println("failed to close zip archive: " + erro.Error())
+		}
+		return nil, err
+	}
+	return &readCloser{Reader: r, closers: []io.Closer{r, zr}}, nil
+}
+
+// readCloser closes all underlying closers in order.
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close is part of implementation of io.Closer interface.
+func (rc *readCloser) Close() (err error) {
+	for _, c := range rc.closers {
+		if erro := c.Close(); err == nil {
+			err = erro
+		}
+	}
+	return err
+}
@@ -0,0 +1,140 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package compression
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/ulikunitz/xz"
+)
+
+var _ = Describe("Decompress", func() {
+
+	const content = "Oh, the places you'll go!"
+
+	// bzip2Content is content compressed with bzip2 as there is no bzip2 writer
+	// in the standard library.
+	bzip2Content := []byte{
+		0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x64, 0xf9, 0xe8, 0x68,
+		0x00, 0x00, 0x03, 0x95, 0x80, 0x60, 0x84, 0x00, 0x00, 0xaa, 0xc4, 0xce, 0x20, 0x20,
+		0x00, 0x31, 0x43, 0x4d, 0x30, 0x00, 0x53, 0x40, 0xc9, 0xa7, 0xa3, 0x48, 0xed, 0xb8,
+		0x06, 0x19, 0x7d, 0x90, 0xe0, 0xf0, 0xc9, 0xa5, 0xa4, 0x94, 0xf8, 0xbb, 0x92, 0x29,
+		0xc2, 0x84, 0x83, 0x27, 0xcf, 0x43, 0x40,
+	}
+
+	var (
+		tmpDir string
+		src    string
+		dst    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "weles-")
+		Expect(err).ToNot(HaveOccurred())
+		src = filepath.Join(tmpDir, "src")
+		dst = filepath.Join(tmpDir, "dst")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	compress := func(newWriter func(io.Writer) (io.WriteCloser, error)) []byte {
+		var buf bytes.Buffer
+		w, err := newWriter(&buf)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = io.WriteString(w, content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(w.Close()).To(Succeed())
+		return buf.Bytes()
+	}
+
+	zipped := func(names ...string) []byte {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for _, n := range names {
+			f, err := w.Create(n)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = io.WriteString(f, content)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(w.Close()).To(Succeed())
+		return buf.Bytes()
+	}
+
+	DescribeTable("should decompress supported formats",
+		func(c string, data func() []byte) {
+			Expect(ioutil.WriteFile(src, data(), 0644)).To(Succeed())
+
+			Expect(Decompress(src, dst, c)).To(Succeed())
+			out, err := ioutil.ReadFile(dst)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal(content))
+		},
+		Entry("gzip", Gzip, func() []byte {
+			return compress(func(w io.Writer) (io.WriteCloser, error) {
+				return gzip.NewWriter(w), nil
+			})
+		}),
+		Entry("xz", Xz, func() []byte {
+			return compress(func(w io.Writer) (io.WriteCloser, error) {
+				return xz.NewWriter(w)
+			})
+		}),
+		Entry("bzip2", Bzip2, func() []byte { return bzip2Content }),
+		Entry("zstd", Zstd, func() []byte {
+			return compress(func(w io.Writer) (io.WriteCloser, error) {
+				return zstd.NewWriter(w)
+			})
+		}),
+		Entry("zip", Zip, func() []byte { return zipped("image.img") }),
+	)
+
+	It("should fail for unknown format", func() {
+		Expect(ioutil.WriteFile(src, []byte(content), 0644)).To(Succeed())
+		Expect(Decompress(src, dst, "rar")).To(Equal(ErrUnknownCompression))
+		Expect(dst).NotTo(BeAnExistingFile())
+	})
+
+	It("should fail for corrupted file", func() {
+		Expect(ioutil.WriteFile(src, []byte(content), 0644)).To(Succeed())
+		Expect(Decompress(src, dst, Gzip)).To(HaveOccurred())
+	})
+
+	It("should fail for zip archive with many files", func() {
+		Expect(ioutil.WriteFile(src, zipped("a", "b"), 0644)).To(Succeed())
+		Expect(Decompress(src, dst, Zip)).To(Equal(ErrZipContent))
+	})
+
+	It("should recognize supported formats", func() {
+		for _, c := range []string{"", Gzip, Xz, Bzip2, Zstd, Zip} {
+			Expect(Supported(c)).To(BeTrue())
+		}
+		Expect(Supported("rar")).To(BeFalse())
+	})
+})
@@ -0,0 +1,249 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package artifacts
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/artifacts/compression"
+)
+
+// ConsistencyPolicy defines how consistency of ArtifactDB and artifact files is checked
+// and repaired.
+type ConsistencyPolicy struct {
+	// VerifyDigests enables comparing SHA256 digests of ready artifacts with stored ones.
+	// Only sizes are compared otherwise, as hashing all artifacts takes time.
+	VerifyDigests bool
+	// AdoptOrphans makes files without records to be added to ArtifactDB as ready
+	// artifacts instead of being removed. Temporary files are always removed.
+	AdoptOrphans bool
+	// DryRun disables repairs, so that inconsistencies are only reported.
+	DryRun bool
+}
+
+// ConsistencyFix describes inconsistency found by CheckConsistency and its repair.
+type ConsistencyFix struct {
+	Path weles.ArtifactPath
+	// Problem describes the inconsistency.
+	Problem string
+	// Action describes the repair. It is not made in dry run.
+	Action string
+}
+
+// String implements fmt.Stringer interface.
+func (f ConsistencyFix) String() string {
+	return string(f.Path) + ": " + f.Problem + ", " + f.Action
+}
+
+// Problems and actions reported by CheckConsistency.
+const (
+	problemStuck      = "download was interrupted"
+	problemMissing    = "file is missing"
+	problemNoMetadata = "metadata is missing"
+	problemSize       = "size of file differs from stored one"
+	problemDigest     = "digest of file differs from stored one"
+	problemOrphan     = "file has no record"
+	problemTemporary  = "temporary file was left"
+
+	actionFail    = "marked as FAILED"
+	actionDiscard = "file removed and marked as FAILED"
+	actionRefresh = "metadata computed"
+	actionRemove  = "file removed"
+	actionAdopt   = "added as READY artifact"
+)
+
+// CheckConsistency finds records of artifacts which do not match their files and files
+// in artifact directory without records. Inconsistencies are repaired according to
+// policy and reported. Records left by interrupted downloads are marked as FAILED,
+// so it must not be called while artifacts are downloaded.
+func (s *Storage) CheckConsistency(policy ConsistencyPolicy) ([]ConsistencyFix, error) {
+	artifacts, err := s.db.SelectAll()
+	if err != nil {
+		return nil, err
+	}
+	var fixes []ConsistencyFix
+	known := make(map[string]bool, len(artifacts))
+	for _, ai := range artifacts {
+		known[filepath.Clean(string(ai.Path))] = true
+		fix, err := s.checkArtifact(ai, policy)
+		if err != nil {
+			return fixes, err
+		}
+		if fix.Problem != "" {
+			fixes = append(fixes, fix)
+		}
+	}
+
+	orphans, err := s.findOrphans(known)
+	if err != nil {
+		return fixes, err
+	}
+	for _, orphan := range orphans {
+		fix, err := s.fixOrphan(orphan, policy)
+		if err != nil {
+			return fixes, err
+		}
+		fixes = append(fixes, fix)
+	}
+	return fixes, nil
+}
+
+// checkArtifact verifies the artifact and repairs it according to policy. Returned fix
+// has empty Problem if artifact is consistent.
+func (s *Storage) checkArtifact(ai weles.ArtifactInfo, policy ConsistencyPolicy,
+) (ConsistencyFix, error) {
+	fix := ConsistencyFix{Path: ai.Path}
+	switch {
+	case isBusy(ai.Status):
+		fix.Problem, fix.Action = problemStuck, actionDiscard
+	case ai.Status == weles.ArtifactStatusREADY:
+		fi, err := os.Stat(string(ai.Path))
+		if os.IsNotExist(err) {
+			fix.Problem, fix.Action = problemMissing, actionFail
+			break
+		}
+		if err != nil {
+			return fix, err
+		}
+		current := weles.ArtifactInfo{
+			Path:         ai.Path,
+			ETag:         ai.ETag,
+			LastModified: ai.LastModified,
+		}
+		switch {
+		case ai.SHA256 == "":
+			fix.Problem, fix.Action = problemNoMetadata, actionRefresh
+		case fi.Size() != ai.Size:
+			fix.Problem, fix.Action = problemSize, actionDiscard
+		case policy.VerifyDigests:
+			if err = fillMetadata(&current); err != nil {
+				return fix, err
+			}
+			if current.SHA256 != ai.SHA256 {
+				fix.Problem, fix.Action = problemDigest, actionDiscard
+			}
+		}
+		if fix.Action == actionRefresh && !policy.DryRun {
+			if err = fillMetadata(&current); err != nil {
+				return fix, err
+			}
+			return fix, s.db.SetMetadata(current)
+		}
+	}
+	if fix.Problem == "" || policy.DryRun {
+		return fix, nil
+	}
+	if fix.Action == actionDiscard {
+		if err := os.Remove(string(ai.Path)); err != nil && !os.IsNotExist(err) {
+			return fix, err
+		}
+	}
+	return fix, s.db.SetStatus(weles.ArtifactStatusChange{
+		Path:      ai.Path,
+		NewStatus: weles.ArtifactStatusFAILED,
+	})
+}
+
+// findOrphans returns paths of files in directories of artifacts which are not known.
+// Artifacts are stored in directories named after their job and type.
+func (s *Storage) findOrphans(known map[string]bool) ([]string, error) {
+	var orphans []string
+	jobs, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if _, err = strconv.ParseUint(job.Name(), 10, 64); err != nil || !job.IsDir() {
+			continue
+		}
+		jobDir := filepath.Join(s.dir, job.Name())
+		types, err := ioutil.ReadDir(jobDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, typ := range types {
+			if weles.ArtifactType(typ.Name()).Validate(nil) != nil || !typ.IsDir() {
+				continue
+			}
+			typeDir := filepath.Join(jobDir, typ.Name())
+			files, err := ioutil.ReadDir(typeDir)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range files {
+				path := filepath.Join(typeDir, f.Name())
+				if f.Mode().IsRegular() && !known[path] {
+					orphans = append(orphans, path)
+				}
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// fixOrphan removes or adopts file without record according to policy. Adopted file
+// is assigned to job and type of its directory and its name is used as alias.
+func (s *Storage) fixOrphan(path string, policy ConsistencyPolicy) (ConsistencyFix, error) {
+	fix := ConsistencyFix{
+		Path:    weles.ArtifactPath(path),
+		Problem: problemOrphan,
+		Action:  actionRemove,
+	}
+	temporary := strings.HasSuffix(path, compression.TempSuffix)
+	if temporary {
+		fix.Problem = problemTemporary
+	} else if policy.AdoptOrphans {
+		fix.Action = actionAdopt
+	}
+	if policy.DryRun {
+		return fix, nil
+	}
+	if fix.Action == actionRemove {
+		return fix, os.Remove(path)
+	}
+
+	typeDir := filepath.Dir(path)
+	job, err := strconv.ParseUint(filepath.Base(filepath.Dir(typeDir)), 10, 64)
+	if err != nil {
+		return fix, err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fix, err
+	}
+	ai := weles.ArtifactInfo{
+		ArtifactDescription: weles.ArtifactDescription{
+			JobID: weles.JobID(job),
+			Type:  weles.ArtifactType(filepath.Base(typeDir)),
+			Alias: weles.ArtifactAlias(filepath.Base(path)),
+		},
+		Path:      fix.Path,
+		Status:    weles.ArtifactStatusREADY,
+		Timestamp: strfmt.DateTime(fi.ModTime().UTC()),
+	}
+	if err = fillMetadata(&ai); err != nil {
+		return fix, err
+	}
+	return fix, s.db.InsertArtifactInfo(&ai)
+}
@@ -0,0 +1,209 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package artifacts
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/artifacts/compression"
+	"github.com/SamsungSLAV/weles/artifacts/downloader"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Consistency", func() {
+	const content = "Twas brillig, and the slithy toves"
+
+	var (
+		leadMarmot *Storage
+		testDir    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		testDir, err = ioutil.TempDir("", "test-weles-")
+		Expect(err).ToNot(HaveOccurred())
+		leadMarmot, err = newArtifactManager(filepath.Join(testDir, "test.db"), testDir, 100, 1,
+			100, 0, downloader.DefaultRetryPolicy, downloader.LimitPolicy{}, nil, "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(leadMarmot.Close()).To(Succeed())
+		Expect(os.RemoveAll(testDir)).To(Succeed())
+	})
+
+	upload := func() weles.ArtifactInfo {
+		ai, err := leadMarmot.UploadArtifact(weles.ArtifactDescription{
+			Alias: "jabberwocky",
+			JobID: 1,
+			Type:  weles.ArtifactTypeTEST,
+		}, strings.NewReader(content))
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		return ai
+	}
+
+	status := func(path weles.ArtifactPath) weles.ArtifactStatus {
+		ai, err := leadMarmot.GetArtifactInfo(path)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		return ai.Status
+	}
+
+	write := func(path weles.ArtifactPath, data string) {
+		ExpectWithOffset(1, ioutil.WriteFile(string(path), []byte(data), 0644)).To(Succeed())
+	}
+
+	check := func(policy ConsistencyPolicy) []ConsistencyFix {
+		fixes, err := leadMarmot.CheckConsistency(policy)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		return fixes
+	}
+
+	It("should report nothing if artifacts are consistent", func() {
+		upload()
+		Expect(check(ConsistencyPolicy{VerifyDigests: true})).To(BeEmpty())
+	})
+
+	It("should fail artifacts left by interrupted downloads", func() {
+		path, err := leadMarmot.CreateArtifact(weles.ArtifactDescription{
+			Alias: "partial",
+			JobID: 1,
+			Type:  weles.ArtifactTypeIMAGE,
+			URI:   "http://example.com/image",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(leadMarmot.SetArtifactStatus(weles.ArtifactStatusChange{
+			Path: path, NewStatus: weles.ArtifactStatusDOWNLOADING})).To(Succeed())
+		write(path, content[:5])
+
+		Expect(check(ConsistencyPolicy{})).To(ConsistOf(ConsistencyFix{
+			Path: path, Problem: problemStuck, Action: actionDiscard}))
+		Expect(status(path)).To(Equal(weles.ArtifactStatusFAILED))
+		Expect(string(path)).NotTo(BeAnExistingFile())
+	})
+
+	It("should fail ready artifacts with missing files", func() {
+		ai := upload()
+		Expect(os.Remove(string(ai.Path))).To(Succeed())
+
+		Expect(check(ConsistencyPolicy{})).To(ConsistOf(ConsistencyFix{
+			Path: ai.Path, Problem: problemMissing, Action: actionFail}))
+		Expect(status(ai.Path)).To(Equal(weles.ArtifactStatusFAILED))
+	})
+
+	It("should compute missing metadata", func() {
+		ai := upload()
+		Expect(leadMarmot.db.SetMetadata(weles.ArtifactInfo{Path: ai.Path})).To(Succeed())
+
+		Expect(check(ConsistencyPolicy{})).To(ConsistOf(ConsistencyFix{
+			Path: ai.Path, Problem: problemNoMetadata, Action: actionRefresh}))
+		info, err := leadMarmot.GetArtifactInfo(ai.Path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.SHA256).To(Equal(ai.SHA256))
+		Expect(info.Size).To(Equal(ai.Size))
+		Expect(info.Status).To(Equal(weles.ArtifactStatusREADY))
+	})
+
+	It("should discard ready artifacts of different size", func() {
+		ai := upload()
+		write(ai.Path, content+content)
+
+		Expect(check(ConsistencyPolicy{})).To(ConsistOf(ConsistencyFix{
+			Path: ai.Path, Problem: problemSize, Action: actionDiscard}))
+		Expect(status(ai.Path)).To(Equal(weles.ArtifactStatusFAILED))
+		Expect(string(ai.Path)).NotTo(BeAnExistingFile())
+	})
+
+	It("should verify digests only if enabled", func() {
+		ai := upload()
+		write(ai.Path, strings.ToUpper(content))
+
+		Expect(check(ConsistencyPolicy{})).To(BeEmpty())
+		Expect(check(ConsistencyPolicy{VerifyDigests: true})).To(ConsistOf(ConsistencyFix{
+			Path: ai.Path, Problem: problemDigest, Action: actionDiscard}))
+		Expect(status(ai.Path)).To(Equal(weles.ArtifactStatusFAILED))
+	})
+
+	Describe("orphans", func() {
+		var orphan, temporary, unrelated string
+
+		BeforeEach(func() {
+			dir := filepath.Join(testDir, "3", string(weles.ArtifactTypeRESULT))
+			Expect(os.MkdirAll(dir, os.ModePerm)).To(Succeed())
+			orphan = filepath.Join(dir, "vorpal123")
+			temporary = filepath.Join(dir, "sword"+compression.TempSuffix)
+			unrelated = filepath.Join(testDir, "3", "notes")
+			for _, f := range []string{orphan, temporary, unrelated} {
+				write(weles.ArtifactPath(f), content)
+			}
+			upload()
+		})
+
+		It("should remove files without records", func() {
+			Expect(check(ConsistencyPolicy{})).To(ConsistOf(
+				ConsistencyFix{Path: weles.ArtifactPath(orphan), Problem: problemOrphan,
+					Action: actionRemove},
+				ConsistencyFix{Path: weles.ArtifactPath(temporary), Problem: problemTemporary,
+					Action: actionRemove},
+			))
+			Expect(orphan).NotTo(BeAnExistingFile())
+			Expect(temporary).NotTo(BeAnExistingFile())
+			Expect(unrelated).To(BeAnExistingFile())
+		})
+
+		It("should adopt files without records", func() {
+			Expect(check(ConsistencyPolicy{AdoptOrphans: true})).To(ConsistOf(
+				ConsistencyFix{Path: weles.ArtifactPath(orphan), Problem: problemOrphan,
+					Action: actionAdopt},
+				ConsistencyFix{Path: weles.ArtifactPath(temporary), Problem: problemTemporary,
+					Action: actionRemove},
+			))
+			ai, err := leadMarmot.GetArtifactInfo(weles.ArtifactPath(orphan))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ai.JobID).To(BeEquivalentTo(3))
+			Expect(ai.Type).To(Equal(weles.ArtifactTypeRESULT))
+			Expect(ai.Alias).To(BeEquivalentTo("vorpal123"))
+			Expect(ai.Status).To(Equal(weles.ArtifactStatusREADY))
+			Expect(ai.Size).To(BeEquivalentTo(len(content)))
+			Expect(ai.SHA256).NotTo(BeEmpty())
+			Expect(temporary).NotTo(BeAnExistingFile())
+
+			By("Adopted artifact should be consistent")
+			Expect(check(ConsistencyPolicy{VerifyDigests: true})).To(BeEmpty())
+		})
+
+		It("should only report inconsistencies in dry run", func() {
+			ai := upload()
+			Expect(os.Remove(string(ai.Path))).To(Succeed())
+
+			Expect(check(ConsistencyPolicy{DryRun: true, AdoptOrphans: true})).To(ConsistOf(
+				ConsistencyFix{Path: ai.Path, Problem: problemMissing, Action: actionFail},
+				ConsistencyFix{Path: weles.ArtifactPath(orphan), Problem: problemOrphan,
+					Action: actionAdopt},
+				ConsistencyFix{Path: weles.ArtifactPath(temporary), Problem: problemTemporary,
+					Action: actionRemove},
+			))
+			Expect(status(ai.Path)).To(Equal(weles.ArtifactStatusREADY))
+			Expect(orphan).To(BeAnExistingFile())
+			Expect(temporary).To(BeAnExistingFile())
+		})
+	})
+})
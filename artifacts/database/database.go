@@ -20,21 +20,22 @@ package database
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/SamsungSLAV/weles"
 
 	"github.com/go-gorp/gorp"
-	// sqlite3 is imported for side-effects and will be used
-	// with the standard library sql interface.
-	_ "github.com/mattn/go-sqlite3"
 )
 
 // ArtifactDB is responsible for database connection and queries.
 type ArtifactDB struct {
 	handler *sql.DB
 	dbmap   *gorp.DbMap
+	// path of the database file.
+	path string
 }
 
 const (
@@ -45,7 +46,8 @@ const (
 // Open opens database connection.
 func (aDB *ArtifactDB) Open(dbPath string) error {
 	var err error
-	aDB.handler, err = sql.Open("sqlite3", dbPath+sqlite3BusyTimeout)
+	aDB.path = dbPath
+	aDB.handler, err = sql.Open(sqlite3Driver, dbPath+sqlite3BusyTimeout)
 	if err != nil {
 		return errors.New(dbOpenFail + err.Error())
 	}
@@ -55,12 +57,14 @@ func (aDB *ArtifactDB) Open(dbPath string) error {
 	return aDB.initDB()
 }
 
-// initDB initializes tables.
+// initDB maps tables and migrates database schema to the current version.
 func (aDB *ArtifactDB) initDB() error {
 	// Add tables.
 	aDB.dbmap.AddTableWithName(weles.ArtifactInfo{}, "artifacts").SetKeys(true, "ID")
+	aDB.dbmap.AddTableWithName(weles.ArtifactAttempt{}, "attempts").SetKeys(true, "ID")
+	aDB.dbmap.AddTableWithName(schemaVersion{}, "schema_version")
 
-	return aDB.dbmap.CreateTablesIfNotExists()
+	return aDB.migrate()
 }
 
 // Close closes the database.
@@ -88,10 +92,68 @@ func (aDB *ArtifactDB) SelectPath(path weles.ArtifactPath) (weles.ArtifactInfo,
 	return ai, nil
 }
 
+// SelectID selects artifact from database based on its ID.
+func (aDB *ArtifactDB) SelectID(id int64) (weles.ArtifactInfo, error) {
+	ai := weles.ArtifactInfo{}
+	err := aDB.dbmap.SelectOne(&ai, "select * from artifacts where ID=?", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return weles.ArtifactInfo{}, weles.ErrArtifactNotFound
+		}
+		return weles.ArtifactInfo{}, err
+	}
+	return ai, nil
+}
+
+// InsertAttempt inserts information about download attempt to database.
+func (aDB *ArtifactDB) InsertAttempt(a *weles.ArtifactAttempt) (err error) {
+	err = aDB.dbmap.Insert(a)
+	if err != nil {
+		log.Println("Failed to insert ArtifactAttempt: ", err)
+	}
+	return
+}
+
+// SelectAttempts selects download attempts of artifact with given path ordered
+// by their number.
+func (aDB *ArtifactDB) SelectAttempts(path weles.ArtifactPath) ([]weles.ArtifactAttempt, error) {
+	attempts := []weles.ArtifactAttempt{}
+	_, err := aDB.dbmap.Select(&attempts,
+		"select * from attempts where Path=? order by Number, ID", path)
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// sortColumns maps sort keys to columns of artifacts table.
+var sortColumns = map[weles.ArtifactSortBy]string{
+	weles.ArtifactSortByID:        "ID",
+	weles.ArtifactSortByTimestamp: "Timestamp",
+	weles.ArtifactSortByJobID:     "JobID",
+	weles.ArtifactSortByType:      "Type",
+	weles.ArtifactSortByStatus:    "Status",
+	weles.ArtifactSortByAlias:     "Alias",
+	weles.ArtifactSortBySize:      "Size",
+}
+
+// sortColumn returns column used for sorting artifacts. ID is used if sort key is unknown.
+func sortColumn(sortBy weles.ArtifactSortBy) string {
+	if column, ok := sortColumns[sortBy]; ok {
+		return column
+	}
+	return "ID"
+}
+
 // prepareQuery prepares query based on given filter.
+// Pages are selected with keyset (row value) pagination: records are compared with
+// the one identified by paginator's ID on sort key and ID, as described in:
+// https://www.sqlite.org/rowvalue.html#scrolling_window_queries
+// Page preceding the paginator's ID is selected in reversed order.
+// Record identified by paginator's ID must exist, unless records are sorted by ID.
 // TODO code duplication
 func prepareQuery(filter weles.ArtifactFilter, sorter weles.ArtifactSorter,
-	paginator weles.ArtifactPagination, totalRecords, remainingRecords bool, offset int64,
+	paginator weles.ArtifactPagination, totalRecords, remainingRecords bool,
 ) (query string, args []interface{}) {
 
 	if !totalRecords && !remainingRecords {
@@ -103,42 +165,54 @@ func prepareQuery(filter weles.ArtifactFilter, sorter weles.ArtifactSorter,
 	var conditions []string
 	conditions, args = prepareQueryFilter(filter)
 
+	column := sortColumn(sorter.SortBy)
 	if !totalRecords && paginator.ID != 0 {
+		operator := ">"
 		if (paginator.Forward && sorter.SortOrder == weles.SortOrderDescending) ||
 			(!paginator.Forward && sorter.SortOrder == weles.SortOrderAscending) {
-			conditions = append(conditions, " ID < ? ")
-			args = append(args, paginator.ID)
+			operator = "<"
+		}
+		if column == "ID" {
+			conditions = append(conditions, " ID "+operator+" ? ")
 		} else {
-			conditions = append(conditions, " ID > ? ")
-			args = append(args, paginator.ID)
+			conditions = append(conditions, " ("+column+", ID) "+operator+
+				" (select "+column+", ID from artifacts where ID = ?) ")
 		}
+		args = append(args, paginator.ID)
 	}
 
 	if len(conditions) > 0 {
 		query += " where " + strings.Join(conditions, " AND ")
 	}
 
-	query += prepareQuerySorter(sorter)
+	if totalRecords || remainingRecords {
+		return
+	}
+
+	descending := sorter.SortOrder == weles.SortOrderDescending
+	if paginator.Limit != 0 && !paginator.Forward {
+		descending = !descending
+	}
+	query += prepareQuerySorter(column, descending)
 
 	if paginator.Limit != 0 {
-		if offset == 0 {
-			query += " LIMIT ? "
-			args = append(args, paginator.Limit)
-		} else {
-			query += " LIMIT ? OFFSET ?"
-			args = append(args, paginator.Limit, offset)
-		}
+		query += " LIMIT ? "
+		args = append(args, paginator.Limit)
 	}
 	return
 }
 
-func prepareQuerySorter(sorter weles.ArtifactSorter) string {
-	//TODO: make timestamp also db key, add to where clause and order by as described in:
-	// https://www.sqlite.org/rowvalue.html#scrolling_window_queries
-	if sorter.SortOrder == weles.SortOrderDescending {
-		return " ORDER BY ID DESC "
+// prepareQuerySorter returns order by clause sorting by column. Ties are broken by ID
+// in the same direction, so that order of records is stable.
+func prepareQuerySorter(column string, descending bool) string {
+	order := " ASC"
+	if descending {
+		order = " DESC"
+	}
+	if column == "ID" {
+		return " ORDER BY ID" + order + " "
 	}
-	return " ORDER BY ID ASC "
+	return " ORDER BY " + column + order + ", ID" + order + " "
 }
 
 func prepareQueryFilter(filter weles.ArtifactFilter) (conditions []string, args []interface{}) {
@@ -166,6 +240,9 @@ func prepareQueryFilter(filter weles.ArtifactFilter) (conditions []string, args
 		}
 		conditions = append(conditions, " Status in ("+strings.Join(q, ",")+")")
 	}
+	conditions, args = appendInCondition(conditions, args, "SHA256", filter.SHA256)
+	conditions, args = appendInCondition(conditions, args, "MimeType", filter.MimeType)
+	conditions, args = appendInCondition(conditions, args, "ETag", filter.ETag)
 	if len(filter.Alias) > 0 {
 		q := make([]string, len(filter.Alias))
 		for i, alias := range filter.Alias {
@@ -174,14 +251,72 @@ func prepareQueryFilter(filter weles.ArtifactFilter) (conditions []string, args
 		}
 		conditions = append(conditions, " Alias in ("+strings.Join(q, ",")+")")
 	}
+	// Timestamps are compared with julianday so that they do not depend on time zone
+	// used in the stored string.
+	if !time.Time(filter.CreatedAfter).IsZero() {
+		conditions = append(conditions, " julianday(Timestamp) > julianday(?)")
+		args = append(args, filter.CreatedAfter.String())
+	}
+	if !time.Time(filter.CreatedBefore).IsZero() {
+		conditions = append(conditions, " julianday(Timestamp) < julianday(?)")
+		args = append(args, filter.CreatedBefore.String())
+	}
+	conditions, args = appendAnyCondition(conditions, args, "instr(coalesce(Alias, ''), ?) > 0",
+		filter.AliasContains)
+	conditions, args = appendAnyCondition(conditions, args, "coalesce(Alias, '') regexp ?",
+		filter.AliasRegexp)
+	conditions, args = appendAnyCondition(conditions, args, "instr(coalesce(URI, ''), ?) > 0",
+		filter.URIContains)
+	conditions, args = appendAnyCondition(conditions, args, "coalesce(URI, '') regexp ?",
+		filter.URIRegexp)
+	if filter.MinSize > 0 {
+		conditions = append(conditions, " Size >= ?")
+		args = append(args, filter.MinSize)
+	}
+	if filter.MaxSize > 0 {
+		conditions = append(conditions, " Size <= ?")
+		args = append(args, filter.MaxSize)
+	}
 
 	return
 }
 
+// appendAnyCondition adds condition which is met if condition cond is met for any of
+// values. Each value is passed as an argument to cond.
+func appendAnyCondition(conditions []string, args []interface{}, cond string, values []string,
+) ([]string, []interface{}) {
+	if len(values) == 0 {
+		return conditions, args
+	}
+	q := make([]string, len(values))
+	for i, v := range values {
+		q[i] = cond
+		args = append(args, v)
+	}
+	return append(conditions, " ("+strings.Join(q, " OR ")+")"), args
+}
+
+// appendInCondition adds condition matching column to any of values if there are any.
+func appendInCondition(conditions []string, args []interface{}, column string, values []string,
+) ([]string, []interface{}) {
+	if len(values) == 0 {
+		return conditions, args
+	}
+	q := make([]string, len(values))
+	for i, v := range values {
+		q[i] = "?"
+		args = append(args, v)
+	}
+	return append(conditions, " "+column+" in ("+strings.Join(q, ",")+")"), args
+}
+
 // Filter fetches elements matching ArtifactFilter from database.
 func (aDB *ArtifactDB) Filter(filter weles.ArtifactFilter, sorter weles.ArtifactSorter,
 	paginator weles.ArtifactPagination) ([]weles.ArtifactInfo, weles.ListInfo, error) {
 
+	if err := validateRegexps(filter); err != nil {
+		return nil, weles.ListInfo{}, err
+	}
 	results := []weles.ArtifactInfo{}
 	var tr, rr int64
 	// TODO gorp doesn't support passing list of arguments to where in(...) clause yet.
@@ -199,9 +334,22 @@ func (aDB *ArtifactDB) Filter(filter weles.ArtifactFilter, sorter weles.Artifact
 			}
 		}
 	}()
-	queryForTotal, argsForTotal := prepareQuery(filter, sorter, paginator, true, false, 0)
-	queryForRemaining, argsForRemaining := prepareQuery(filter, sorter, paginator, false, true, 0)
-	var offset int64
+	if paginator.ID != 0 && sortColumn(sorter.SortBy) != "ID" {
+		// Records are compared with the cursor's one, so no record would match
+		// if it was deleted, e.g. by retention policy.
+		var n int64
+		n, err = trans.SelectInt("select count(*) from artifacts where ID = ?", paginator.ID)
+		if err != nil {
+			return nil, weles.ListInfo{}, errors.New(whileFilter + dbCursorFail + err.Error())
+		}
+		if n == 0 {
+			err = weles.ErrInvalidArgument(fmt.Sprintf(
+				"artifact %d used as pagination cursor does not exist", paginator.ID))
+			return nil, weles.ListInfo{}, err
+		}
+	}
+	queryForTotal, argsForTotal := prepareQuery(filter, sorter, paginator, true, false)
+	queryForRemaining, argsForRemaining := prepareQuery(filter, sorter, paginator, false, true)
 
 	rr, err = trans.SelectInt(queryForRemaining, argsForRemaining...)
 	if err != nil {
@@ -220,15 +368,17 @@ func (aDB *ArtifactDB) Filter(filter weles.ArtifactFilter, sorter weles.Artifact
 		return []weles.ArtifactInfo{}, weles.ListInfo{}, err
 	}
 
-	if !paginator.Forward {
-		offset = rr - int64(paginator.Limit)
-	}
-
-	queryForData, argsForData := prepareQuery(filter, sorter, paginator, false, false, offset)
+	queryForData, argsForData := prepareQuery(filter, sorter, paginator, false, false)
 	_, err = trans.Select(&results, queryForData, argsForData...)
 	if err != nil {
 		return nil, weles.ListInfo{}, errors.New(whileFilter + dbArtifactInfoFail + err.Error())
 	}
+	if paginator.Limit != 0 && !paginator.Forward {
+		// Page preceding paginator's ID was selected in reversed order.
+		for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+			results[i], results[j] = results[j], results[i]
+		}
+	}
 	if err := trans.Commit(); err != nil {
 		return nil, weles.ListInfo{}, errors.New(whileFilter + dbTransCommitFail + err.Error())
 
@@ -241,18 +391,121 @@ func (aDB *ArtifactDB) Filter(filter weles.ArtifactFilter, sorter weles.Artifact
 		nil
 }
 
-// SetStatus changes artifact's status in ArtifactDB.
+// SetStatus changes artifact's status in ArtifactDB. Only status column is updated,
+// so metadata of the artifact stored concurrently is not overwritten.
 func (aDB *ArtifactDB) SetStatus(change weles.ArtifactStatusChange) error {
-	ai, err := aDB.SelectPath(change.Path)
+	return aDB.updatePath(change.Path, "Status=?", change.NewStatus)
+}
+
+// SetMetadata stores size, digest, MIME type and source headers of the artifact
+// identified by ai.Path.
+func (aDB *ArtifactDB) SetMetadata(ai weles.ArtifactInfo) error {
+	return aDB.updatePath(ai.Path, "Size=?, SHA256=?, MimeType=?, ETag=?, LastModified=?",
+		ai.Size, ai.SHA256, ai.MimeType, ai.ETag, ai.LastModified)
+}
+
+// SelectJob selects all artifacts of the job.
+func (aDB *ArtifactDB) SelectJob(job weles.JobID) ([]weles.ArtifactInfo, error) {
+	artifacts := []weles.ArtifactInfo{}
+	_, err := aDB.dbmap.Select(&artifacts, "select * from artifacts where JobID=? order by ID",
+		job)
 	if err != nil {
-		log.Println("failed to retrieve artifact based on its path: " + err.Error())
-		return err //TODO: aalexanderr - log  error and continue
+		return nil, err
 	}
+	return artifacts, nil
+}
 
-	ai.Status = change.NewStatus
-	if _, err = aDB.dbmap.Update(&ai); err != nil {
+// SelectAll selects all artifacts ordered by ID.
+func (aDB *ArtifactDB) SelectAll() ([]weles.ArtifactInfo, error) {
+	artifacts := []weles.ArtifactInfo{}
+	_, err := aDB.dbmap.Select(&artifacts, "select * from artifacts order by ID")
+	if err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// Delete removes artifact and history of its download attempts from database.
+func (aDB *ArtifactDB) Delete(path weles.ArtifactPath) (err error) {
+	trans, err := aDB.dbmap.Begin()
+	if err != nil {
+		return errors.New(dbTransOpenFail + err.Error())
+	}
+	defer func() {
+		if err != nil {
+			if err2 := trans.Rollback(); err2 != nil {
+				log.Printf("%v occurred when deleting, trying to rollback transaction failed: %v",
+					err, err2)
+			}
+		}
+	}()
+	res, err := trans.Exec("delete from artifacts where Path=?", path)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		err = sql.ErrNoRows
+		return err
+	}
+	_, err = trans.Exec("delete from attempts where Path=?", path)
+	if err != nil {
+		return err
+	}
+	if err = trans.Commit(); err != nil {
+		return errors.New(dbTransCommitFail + err.Error())
+	}
+	return nil
+}
+
+// SetPinned sets whether artifact is protected from being purged by retention policy.
+func (aDB *ArtifactDB) SetPinned(path weles.ArtifactPath, pinned bool) error {
+	return aDB.updatePath(path, "Pinned=?", pinned)
+}
+
+// SelectCollectable selects artifacts which may be purged by retention policy, i.e.
+// ready, failed or canceled ones and results which were never pulled, which are not
+// pinned. Oldest artifacts are returned first.
+func (aDB *ArtifactDB) SelectCollectable() ([]weles.ArtifactInfo, error) {
+	artifacts := []weles.ArtifactInfo{}
+	_, err := aDB.dbmap.Select(&artifacts, `select * from artifacts
+		where (Status in (?, ?, ?) or (Status = ? and Type = ?)) and Pinned = 0
+		order by Timestamp, ID`,
+		weles.ArtifactStatusREADY, weles.ArtifactStatusFAILED, weles.ArtifactStatusCANCELED,
+		weles.ArtifactStatus(""), weles.ArtifactTypeRESULT)
+	if err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// TotalSize returns total size of artifacts which are not purged.
+func (aDB *ArtifactDB) TotalSize() (int64, error) {
+	return aDB.dbmap.SelectInt("select coalesce(sum(Size), 0) from artifacts where Status != ?",
+		weles.ArtifactStatusPURGED)
+}
+
+// updatePath sets columns of artifact identified by path. It returns sql.ErrNoRows
+// if there is no such artifact.
+func (aDB *ArtifactDB) updatePath(path weles.ArtifactPath, set string, args ...interface{},
+) error {
+	res, err := aDB.dbmap.Exec("update artifacts set "+set+" where Path=?",
+		append(args, path)...)
+	if err != nil {
 		log.Println("failed to update database" + err.Error())
 		// TODO: aalexanderr - log critical, stop weles gracefully
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		log.Println("failed to retrieve artifact based on its path: " + string(path))
+		return sql.ErrNoRows
 	}
-	return err
+	return nil
 }
@@ -19,6 +19,7 @@ package database
 
 import (
 	"database/sql"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -285,6 +286,135 @@ var _ = Describe("ArtifactDB", func() {
 			)
 		})
 
+		Describe("SetMetadata", func() {
+			metadata := weles.ArtifactInfo{
+				Path:         artifact.Path,
+				Size:         42,
+				SHA256:       "d2a84f4b8b650937ec8f73cd8be2c74add5a911ba64df27458ed8229da804a26",
+				MimeType:     "text/plain; charset=utf-8",
+				ETag:         `"5d41402a"`,
+				LastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+			}
+			BeforeEach(func() {
+				trans, err := goldenUnicorn.dbmap.Begin()
+				Expect(err).ToNot(HaveOccurred())
+				defer trans.Commit()
+				for _, a := range testArtifacts {
+					err := trans.Insert(&a)
+					Expect(err).ToNot(HaveOccurred())
+				}
+			})
+			It("should store metadata of artifact", func() {
+				Expect(goldenUnicorn.SetMetadata(metadata)).To(Succeed())
+
+				a, err := goldenUnicorn.SelectPath(artifact.Path)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(a.Size).To(Equal(metadata.Size))
+				Expect(a.SHA256).To(Equal(metadata.SHA256))
+				Expect(a.MimeType).To(Equal(metadata.MimeType))
+				Expect(a.ETag).To(Equal(metadata.ETag))
+				Expect(a.LastModified).To(Equal(metadata.LastModified))
+				Expect(a.Status).To(Equal(artifact.Status))
+			})
+			It("should not lose metadata when status changes", func() {
+				Expect(goldenUnicorn.SetMetadata(metadata)).To(Succeed())
+				Expect(goldenUnicorn.SetStatus(weles.ArtifactStatusChange{
+					Path:      artifact.Path,
+					NewStatus: weles.ArtifactStatusREADY,
+				})).To(Succeed())
+
+				a, err := goldenUnicorn.SelectPath(artifact.Path)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(a.SHA256).To(Equal(metadata.SHA256))
+				Expect(a.Status).To(Equal(weles.ArtifactStatusREADY))
+			})
+			It("should fail for artifact not present in ArtifactDB", func() {
+				m := metadata
+				m.Path = invalidPath
+				Expect(goldenUnicorn.SetMetadata(m)).To(Equal(sql.ErrNoRows))
+			})
+			DescribeTable("list artifacts matching metadata filter",
+				func(filter weles.ArtifactFilter) {
+					Expect(goldenUnicorn.SetMetadata(metadata)).To(Succeed())
+
+					results, _, err := goldenUnicorn.Filter(filter, defaultSorter, emptyPaginator)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(results).To(HaveLen(1))
+					Expect(results[0].Path).To(Equal(artifact.Path))
+				},
+				Entry("filter SHA256", weles.ArtifactFilter{
+					SHA256: []string{metadata.SHA256, "invalidSHA256"}}),
+				Entry("filter MimeType", weles.ArtifactFilter{
+					MimeType: []string{metadata.MimeType}}),
+				Entry("filter ETag", weles.ArtifactFilter{ETag: []string{metadata.ETag}}),
+			)
+		})
+
+		It("should add metadata columns to database created by previous version", func() {
+			dbPath := filepath.Join(tmpDir, "old.db")
+			old, err := sql.Open("sqlite3", dbPath)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = old.Exec(`create table artifacts (ID integer not null primary key
+				autoincrement, JobID integer, Type varchar(255), Alias varchar(255),
+				Path varchar(255), Status varchar(255), Timestamp datetime, URI varchar(255))`)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = old.Exec(`insert into artifacts (JobID, Type, Alias, Path, Status,
+				Timestamp, URI) values (1, 'IMAGE', 'old', 'oldPath', 'READY', ?,
+				'http://example.com')`, strfmt.DateTime(time.Now().UTC()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(old.Close()).To(Succeed())
+
+			var silverWombat ArtifactDB
+			Expect(silverWombat.Open(dbPath)).To(Succeed())
+			defer silverWombat.Close()
+
+			a, err := silverWombat.SelectPath("oldPath")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(a.Alias).To(BeEquivalentTo("old"))
+			Expect(a.SHA256).To(BeEmpty())
+			Expect(silverWombat.SetMetadata(weles.ArtifactInfo{Path: "oldPath", Size: 1})).To(
+				Succeed())
+		})
+
+		Describe("Delete", func() {
+			BeforeEach(func() {
+				for _, a := range testArtifacts {
+					a := a
+					Expect(goldenUnicorn.InsertArtifactInfo(&a)).To(Succeed())
+				}
+				Expect(goldenUnicorn.InsertAttempt(&weles.ArtifactAttempt{
+					Path: artifact.Path, Number: 1})).To(Succeed())
+			})
+			It("should delete artifact and its attempts", func() {
+				Expect(goldenUnicorn.Delete(artifact.Path)).To(Succeed())
+
+				_, err := goldenUnicorn.SelectPath(artifact.Path)
+				Expect(err).To(Equal(sql.ErrNoRows))
+				attempts, err := goldenUnicorn.SelectAttempts(artifact.Path)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(attempts).To(BeEmpty())
+				Expect(jobInDB(artifact.JobID, goldenUnicorn)).To(BeFalse())
+			})
+			It("should fail for artifact not present in ArtifactDB", func() {
+				Expect(goldenUnicorn.Delete(invalidPath)).To(Equal(sql.ErrNoRows))
+			})
+			It("should select all artifacts of job", func() {
+				artifacts, err := goldenUnicorn.SelectJob(aImageReady.JobID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(artifacts).To(HaveLen(2))
+				Expect(artifacts[0].Path).To(Equal(aImageReady.Path))
+				Expect(artifacts[1].Path).To(Equal(aYamlFailed.Path))
+			})
+			It("should select all artifacts", func() {
+				artifacts, err := goldenUnicorn.SelectAll()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(artifacts).To(HaveLen(len(testArtifacts)))
+				for i, a := range testArtifacts {
+					Expect(artifacts[i].Path).To(Equal(a.Path))
+				}
+			})
+		})
+
 		Describe("SelectPath", func() {
 
 			BeforeEach(func() {
@@ -313,6 +443,31 @@ var _ = Describe("ArtifactDB", func() {
 			)
 		})
 
+		Describe("Attempts", func() {
+			It("should return attempts of artifact in order", func() {
+				for _, a := range []weles.ArtifactAttempt{
+					{Path: artifact.Path, Number: 2, Offset: 10},
+					{Path: invalidPath, Number: 1},
+					{Path: artifact.Path, Number: 1, Error: "connection reset"},
+				} {
+					Expect(goldenUnicorn.InsertAttempt(&a)).To(Succeed())
+				}
+
+				attempts, err := goldenUnicorn.SelectAttempts(artifact.Path)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(attempts).To(HaveLen(2))
+				Expect(attempts[0].Number).To(Equal(1))
+				Expect(attempts[0].Error).To(Equal("connection reset"))
+				Expect(attempts[1].Number).To(Equal(2))
+				Expect(attempts[1].Offset).To(BeEquivalentTo(10))
+			})
+			It("should return empty list for artifact without attempts", func() {
+				attempts, err := goldenUnicorn.SelectAttempts(invalidPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(attempts).To(BeEmpty())
+			})
+		})
+
 		Describe("List", func() {
 			BeforeEach(func() {
 				trans, err := goldenUnicorn.dbmap.Begin()
@@ -353,8 +508,34 @@ var _ = Describe("ArtifactDB", func() {
 				Entry("filter is completly set up", fullFilter, aYamlFailed),
 				Entry("filter is empty", emptyFilter, artifact, aImageReady, aYamlFailed,
 					aTestFailed),
+				Entry("filter CreatedBefore", weles.ArtifactFilter{
+					CreatedBefore: strfmt.DateTime(time.Unix(4000, 0))}, aTestFailed),
+				Entry("filter CreatedAfter", weles.ArtifactFilter{
+					CreatedAfter: strfmt.DateTime(time.Unix(4000, 0))},
+					artifact, aImageReady, aYamlFailed),
+				Entry("filter time range", weles.ArtifactFilter{
+					CreatedAfter:  strfmt.DateTime(time.Unix(2000, 0)),
+					CreatedBefore: strfmt.DateTime(time.Unix(4000, 0))}, aTestFailed),
+				Entry("filter AliasContains", weles.ArtifactFilter{
+					AliasContains: []string{"other"}}, aImageReady, aYamlFailed),
+				Entry("filter more than one AliasContains", weles.ArtifactFilter{
+					AliasContains: []string{"some", "silverKangaroo"}}, artifact),
+				Entry("filter AliasRegexp", weles.ArtifactFilter{
+					AliasRegexp: []string{"^alias$"}}, aTestFailed),
+				Entry("filter URIContains", weles.ArtifactFilter{
+					URIContains: []string{"/2", "/3"}}, aYamlFailed, aTestFailed),
+				Entry("filter URIRegexp", weles.ArtifactFilter{
+					URIRegexp: []string{`\.com/[12]$`}}, aImageReady, aYamlFailed),
+				Entry("filter MaxSize", weles.ArtifactFilter{MaxSize: 10}, artifact, aImageReady,
+					aYamlFailed, aTestFailed),
 			)
 
+			It("should fail on invalid regular expression", func() {
+				_, _, err := goldenUnicorn.Filter(weles.ArtifactFilter{URIRegexp: []string{"("}},
+					defaultSorter, emptyPaginator)
+				Expect(err).To(BeAssignableToTypeOf(weles.ErrInvalidArgument("")))
+			})
+
 			DescribeTable("return artifact not found error",
 				func(filter weles.ArtifactFilter, expected ...weles.ArtifactInfo) {
 					_, _, err := goldenUnicorn.Filter(filter, defaultSorter, emptyPaginator)
@@ -365,6 +546,11 @@ var _ = Describe("ArtifactDB", func() {
 				Entry("filter Status not in db", noStatusFilter),
 				Entry("filter Alias not in db", noAliasFilter),
 				Entry("no artifact in db matches filter", noMatchFilter),
+				Entry("filter MinSize not in db", weles.ArtifactFilter{MinSize: 1}),
+				Entry("filter URIContains with LIKE wildcard", weles.ArtifactFilter{
+					URIContains: []string{"%"}}),
+				Entry("filter AliasRegexp not in db", weles.ArtifactFilter{
+					AliasRegexp: []string{"^bronzeYak"}}),
 			)
 		})
 		Describe("Sorting", func() {
@@ -406,6 +592,84 @@ var _ = Describe("ArtifactDB", func() {
 				Entry("By ID, Descending", descendingSorter),
 			)
 
+			DescribeTable("Should sort artifacts by key and ID",
+				func(sortBy weles.ArtifactSortBy, key func(weles.ArtifactInfo) string) {
+					for _, order := range []weles.SortOrder{weles.SortOrderAscending,
+						weles.SortOrderDescending} {
+						sorter := weles.ArtifactSorter{SortBy: sortBy, SortOrder: order}
+						result, _, err := goldenUnicorn.Filter(emptyFilter, sorter, emptyPaginator)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(result).To(HaveLen(len(testArtifacts)))
+						for i := 1; i < len(result); i++ {
+							prev, curr := result[i-1], result[i]
+							if order == weles.SortOrderDescending {
+								prev, curr = curr, prev
+							}
+							Expect(key(prev) <= key(curr)).To(BeTrue())
+							if key(prev) == key(curr) {
+								Expect(prev.ID).To(BeNumerically("<", curr.ID))
+							}
+						}
+					}
+				},
+				Entry("By Timestamp", weles.ArtifactSortByTimestamp,
+					func(a weles.ArtifactInfo) string { return a.Timestamp.String() }),
+				Entry("By JobID", weles.ArtifactSortByJobID,
+					func(a weles.ArtifactInfo) string { return fmt.Sprintf("%020d", a.JobID) }),
+				Entry("By Type", weles.ArtifactSortByType,
+					func(a weles.ArtifactInfo) string { return string(a.Type) }),
+				Entry("By Status", weles.ArtifactSortByStatus,
+					func(a weles.ArtifactInfo) string { return string(a.Status) }),
+				Entry("By Alias", weles.ArtifactSortByAlias,
+					func(a weles.ArtifactInfo) string { return string(a.Alias) }),
+				Entry("By Size", weles.ArtifactSortBySize,
+					func(a weles.ArtifactInfo) string { return fmt.Sprintf("%020d", a.Size) }),
+			)
+
+			It("should paginate by sort key using ID of record as a cursor", func() {
+				sorter := weles.ArtifactSorter{
+					SortBy:    weles.ArtifactSortByAlias,
+					SortOrder: weles.SortOrderAscending,
+				}
+				all, _, err := goldenUnicorn.Filter(emptyFilter, sorter, emptyPaginator)
+				Expect(err).ToNot(HaveOccurred())
+
+				first, list, err := goldenUnicorn.Filter(emptyFilter, sorter,
+					weles.ArtifactPagination{Limit: 2, Forward: true})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(first).To(Equal(all[:2]))
+				Expect(list.RemainingRecords).To(BeEquivalentTo(2))
+
+				second, list, err := goldenUnicorn.Filter(emptyFilter, sorter,
+					weles.ArtifactPagination{ID: first[1].ID, Limit: 2, Forward: true})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(second).To(Equal(all[2:]))
+				Expect(list.RemainingRecords).To(BeZero())
+
+				back, list, err := goldenUnicorn.Filter(emptyFilter, sorter,
+					weles.ArtifactPagination{ID: second[0].ID, Limit: 2, Forward: false})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(back).To(Equal(first))
+				Expect(list.RemainingRecords).To(BeZero())
+			})
+
+			It("should refuse cursor of deleted record unless sorting by ID", func() {
+				all, _, err := goldenUnicorn.Filter(emptyFilter, ascendingSorter, emptyPaginator)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(goldenUnicorn.Delete(all[1].Path)).To(Succeed())
+				paginator := weles.ArtifactPagination{ID: all[1].ID, Limit: 2, Forward: true}
+
+				_, _, err = goldenUnicorn.Filter(emptyFilter, weles.ArtifactSorter{
+					SortBy:    weles.ArtifactSortByAlias,
+					SortOrder: weles.SortOrderAscending,
+				}, paginator)
+				Expect(err).To(Equal(weles.ErrInvalidArgument(fmt.Sprintf(
+					"artifact %d used as pagination cursor does not exist", all[1].ID))))
+
+				page, _, err := goldenUnicorn.Filter(emptyFilter, ascendingSorter, paginator)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(page).To(Equal(all[2:4]))
+			})
 		})
 	})
 	Describe("Pagination", func() {
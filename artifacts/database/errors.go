@@ -26,4 +26,8 @@ const (
 	dbRemainingFail    = "failed to get remaining records count: "
 	dbTotalFail        = "failed to get total records count: "
 	dbArtifactInfoFail = "failed to get ArtifactInfo records: "
+	dbCursorFail       = "failed to get pagination cursor record: "
+	dbVersionUnknown   = "artifacts database schema is newer than supported: "
+	dbBackupFail       = "failed to back up artifacts database before migration: "
+	dbMigrationFail    = "failed to migrate artifacts database to version "
 )
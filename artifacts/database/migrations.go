@@ -0,0 +1,222 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File migrations.go provides versioned migrations of ArtifactDB schema.
+
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+)
+
+// migration is a single step of ArtifactDB schema evolution. Steps are applied in order
+// and version of the schema is the number of applied steps.
+type migration struct {
+	description string
+	// destructive is set if the step may lose data. Database file is backed up
+	// before such step is applied.
+	destructive bool
+	apply       func(tx *gorp.Transaction) error
+}
+
+// migrations lists all steps of ArtifactDB schema evolution. New steps must be
+// appended at the end and existing ones must never be changed.
+var migrations = []migration{
+	{
+		description: "create artifacts table",
+		apply: execAll(`create table if not exists "artifacts" ("Alias" varchar(255),
+			"JobID" integer, "Type" varchar(255), "URI" varchar(255),
+			"ID" integer not null primary key autoincrement, "Path" varchar(255),
+			"Status" varchar(255), "Timestamp" varchar(255))`),
+	},
+	{
+		description: "create attempts table",
+		apply: execAll(`create table if not exists "attempts" (
+			"ID" integer not null primary key autoincrement, "Path" varchar(255),
+			"Number" integer, "Timestamp" varchar(255), "Offset" integer,
+			"Error" varchar(255))`),
+	},
+	{
+		description: "add metadata columns to artifacts table",
+		apply: addColumns("artifacts", [][2]string{
+			{"Size", "integer not null default 0"},
+			{"SHA256", "varchar(255) not null default ''"},
+			{"MimeType", "varchar(255) not null default ''"},
+			{"ETag", "varchar(255) not null default ''"},
+			{"LastModified", "varchar(255) not null default ''"},
+		}),
+	},
+	{
+		description: "add pinned column to artifacts table",
+		apply: addColumns("artifacts", [][2]string{
+			{"Pinned", "integer not null default 0"},
+		}),
+	},
+}
+
+// schemaVersion is a row of schema_version table.
+type schemaVersion struct {
+	Version int
+}
+
+// SchemaVersion returns version of ArtifactDB schema, i.e. number of applied migrations.
+func (aDB *ArtifactDB) SchemaVersion() (int, error) {
+	v, err := aDB.dbmap.SelectNullInt("select max(Version) from schema_version")
+	if err != nil {
+		return 0, err
+	}
+	return int(v.Int64), nil
+}
+
+// migrate applies migrations missing in the database. Each of them is applied in its own
+// transaction together with update of schema version.
+func (aDB *ArtifactDB) migrate() error {
+	_, err := aDB.dbmap.Exec(`create table if not exists schema_version (Version integer)`)
+	if err != nil {
+		return err
+	}
+	version, err := aDB.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf(dbVersionUnknown+"%d > %d", version, len(migrations))
+	}
+	for i := version; i < len(migrations); i++ {
+		m := migrations[i]
+		if m.destructive {
+			if err = aDB.backup(i); err != nil {
+				return errors.New(dbBackupFail + err.Error())
+			}
+		}
+		log.Printf("Migrating ArtifactDB to version %d: %s", i+1, m.description)
+		if err = aDB.applyMigration(i+1, m); err != nil {
+			return fmt.Errorf(dbMigrationFail+"%d: %s", i+1, err.Error())
+		}
+	}
+	return nil
+}
+
+// applyMigration applies m and sets schema version to version.
+func (aDB *ArtifactDB) applyMigration(version int, m migration) (err error) {
+	tx, err := aDB.dbmap.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if err2 := tx.Rollback(); err2 != nil {
+				log.Printf("%v occurred when migrating, trying to rollback transaction failed: %v",
+					err, err2)
+			}
+		}
+	}()
+	if err = m.apply(tx); err != nil {
+		return err
+	}
+	if _, err = tx.Exec("delete from schema_version"); err != nil {
+		return err
+	}
+	if err = tx.Insert(&schemaVersion{Version: version}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// backup copies database file before migration to version+1 is applied. Copy is named
+// after the database file and the version it contains.
+func (aDB *ArtifactDB) backup(version int) (err error) {
+	if aDB.path == "" || aDB.path == ":memory:" {
+		return nil
+	}
+	src, err := os.Open(aDB.path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err2 := src.Close(); err == nil {
+			err = err2
+		}
+	}()
+	backupPath := fmt.Sprintf("%s.v%d.bak", aDB.path, version)
+	dst, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err2 := dst.Close(); err == nil {
+			err = err2
+		}
+	}()
+	_, err = io.Copy(dst, src)
+	if err == nil {
+		log.Println("ArtifactDB backed up to " + backupPath)
+	}
+	return err
+}
+
+// execAll returns migration step executing all queries.
+func execAll(queries ...string) func(tx *gorp.Transaction) error {
+	return func(tx *gorp.Transaction) error {
+		for _, q := range queries {
+			if _, err := tx.Exec(q); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// addColumns returns migration step adding columns to table. Columns already present
+// in the table are skipped.
+func addColumns(table string, columns [][2]string) func(tx *gorp.Transaction) error {
+	return func(tx *gorp.Transaction) error {
+		var existing []struct {
+			CID        int            `db:"cid"`
+			Name       string         `db:"name"`
+			Type       string         `db:"type"`
+			NotNull    bool           `db:"notnull"`
+			Default    sql.NullString `db:"dflt_value"`
+			PrimaryKey int            `db:"pk"`
+		}
+		_, err := tx.Select(&existing, "pragma table_info("+table+")")
+		if err != nil {
+			return err
+		}
+		names := make(map[string]bool, len(existing))
+		for _, c := range existing {
+			names[strings.ToLower(c.Name)] = true
+		}
+		for _, c := range columns {
+			if names[strings.ToLower(c[0])] {
+				continue
+			}
+			_, err = tx.Exec("alter table " + table + " add column " + c[0] + " " + c[1])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/go-gorp/gorp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Migrations", func() {
+	var (
+		brassOtter ArtifactDB
+		tmpDir     string
+		dbPath     string
+		original   []migration
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", tmpDirPrefix)
+		Expect(err).ToNot(HaveOccurred())
+		dbPath = filepath.Join(tmpDir, "test.db")
+		original = migrations
+	})
+
+	AfterEach(func() {
+		migrations = original
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	reopen := func() error {
+		ExpectWithOffset(1, brassOtter.Close()).To(Succeed())
+		return brassOtter.Open(dbPath)
+	}
+
+	tableExists := func(name string) bool {
+		n, err := brassOtter.dbmap.SelectInt(
+			"select count(*) from sqlite_master where type = 'table' and name = ?", name)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		return n > 0
+	}
+
+	It("should apply all migrations to new database", func() {
+		Expect(brassOtter.Open(dbPath)).To(Succeed())
+		defer brassOtter.Close()
+
+		v, err := brassOtter.SchemaVersion()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(v).To(Equal(len(migrations)))
+		Expect(tableExists("artifacts")).To(BeTrue())
+		Expect(tableExists("attempts")).To(BeTrue())
+	})
+
+	It("should apply only missing migrations", func() {
+		applied := 0
+		migrations = append(migrations[:len(migrations):len(migrations)], migration{
+			description: "count",
+			apply: func(tx *gorp.Transaction) error {
+				applied++
+				return nil
+			},
+		})
+		Expect(brassOtter.Open(dbPath)).To(Succeed())
+		defer brassOtter.Close()
+		Expect(reopen()).To(Succeed())
+
+		Expect(applied).To(Equal(1))
+		v, err := brassOtter.SchemaVersion()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(v).To(Equal(len(original) + 1))
+	})
+
+	It("should roll back failed migration", func() {
+		Expect(brassOtter.Open(dbPath)).To(Succeed())
+		defer brassOtter.Close()
+		migrations = append(migrations[:len(migrations):len(migrations)], migration{
+			description: "fail",
+			apply: func(tx *gorp.Transaction) error {
+				_, err := tx.Exec("create table leftover (ID integer)")
+				Expect(err).ToNot(HaveOccurred())
+				return errors.New("test error")
+			},
+		})
+
+		Expect(reopen()).To(MatchError(ContainSubstring("test error")))
+		Expect(tableExists("leftover")).To(BeFalse())
+		v, err := brassOtter.SchemaVersion()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(v).To(Equal(len(original)))
+	})
+
+	It("should back up database before destructive migration", func() {
+		Expect(brassOtter.Open(dbPath)).To(Succeed())
+		defer brassOtter.Close()
+		migrations = append(migrations[:len(migrations):len(migrations)], migration{
+			description: "drop attempts",
+			destructive: true,
+			apply:       execAll("drop table attempts"),
+		})
+
+		Expect(reopen()).To(Succeed())
+		Expect(tableExists("attempts")).To(BeFalse())
+
+		var backup ArtifactDB
+		migrations = original
+		backupPath := fmt.Sprintf("%s.v%d.bak", dbPath, len(original))
+		Expect(backupPath).To(BeAnExistingFile())
+		Expect(backup.Open(backupPath)).To(Succeed())
+		defer backup.Close()
+		n, err := backup.dbmap.SelectInt(
+			"select count(*) from sqlite_master where type = 'table' and name = 'attempts'")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(BeEquivalentTo(1))
+	})
+
+	It("should refuse database with newer schema", func() {
+		Expect(brassOtter.Open(dbPath)).To(Succeed())
+		defer brassOtter.Close()
+		migrations = migrations[:len(migrations)-1]
+
+		Expect(reopen()).To(MatchError(ContainSubstring(dbVersionUnknown)))
+	})
+})
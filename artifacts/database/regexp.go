@@ -0,0 +1,137 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package database
+
+import (
+	"container/list"
+	"database/sql"
+	"regexp"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+// sqlite3Driver is the name of sqlite3 driver providing REGEXP operator.
+const sqlite3Driver = "sqlite3_weles"
+
+// maxRegexps is the number of compiled regular expressions kept in regexps.
+const maxRegexps = 256
+
+// regexpCache holds compiled regular expressions, evicting the least recently used
+// ones, so expressions coming from user queries do not accumulate forever.
+type regexpCache struct {
+	// lru holds *regexpEntry values, most recently used at the front.
+	lru     *list.List
+	entries map[string]*list.Element
+	mutex   sync.Mutex
+}
+
+// regexpEntry is a compiled regular expression stored in regexpCache.
+type regexpEntry struct {
+	expr string
+	re   *regexp.Regexp
+}
+
+// regexps caches compiled regular expressions used in queries.
+var regexps = &regexpCache{
+	lru:     list.New(),
+	entries: make(map[string]*list.Element),
+}
+
+// load returns cached regular expression expr.
+func (c *regexpCache) load(expr string) (*regexp.Regexp, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	el, ok := c.entries[expr]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return el.Value.(*regexpEntry).re, true
+}
+
+// store adds regular expression expr to the cache and evicts the least recently
+// used entries exceeding maxRegexps.
+func (c *regexpCache) store(expr string, re *regexp.Regexp) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if el, ok := c.entries[expr]; ok {
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[expr] = c.lru.PushFront(&regexpEntry{expr: expr, re: re})
+	for c.lru.Len() > maxRegexps {
+		el := c.lru.Back()
+		c.lru.Remove(el)
+		delete(c.entries, el.Value.(*regexpEntry).expr)
+	}
+}
+
+// len returns number of cached regular expressions.
+func (c *regexpCache) len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.lru.Len()
+}
+
+func init() {
+	sql.Register(sqlite3Driver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("regexp", matchRegexp, true)
+		},
+	})
+}
+
+// compileRegexp returns compiled regular expression expr.
+func compileRegexp(expr string) (*regexp.Regexp, error) {
+	if re, ok := regexps.load(expr); ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	regexps.store(expr, re)
+	return re, nil
+}
+
+// matchRegexp implements sqlite3 REGEXP operator: "s REGEXP expr" calls matchRegexp(expr, s).
+func matchRegexp(expr, s string) (bool, error) {
+	re, err := compileRegexp(expr)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(s), nil
+}
+
+// validateRegexps checks if all regular expressions used in filter are valid.
+func validateRegexps(filter weles.ArtifactFilter) error {
+	for name, exprs := range map[string][]string{
+		"AliasRegexp": filter.AliasRegexp,
+		"URIRegexp":   filter.URIRegexp,
+	} {
+		for _, expr := range exprs {
+			if _, err := compileRegexp(expr); err != nil {
+				return weles.ErrInvalidArgument("cannot compile regex from " + name + ": " +
+					err.Error())
+			}
+		}
+	}
+	return nil
+}
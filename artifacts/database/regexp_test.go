@@ -0,0 +1,52 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package database
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("regexpCache", func() {
+	It("should keep at most maxRegexps recently used expressions", func() {
+		first, err := compileRegexp("^first$")
+		Expect(err).ToNot(HaveOccurred())
+		for i := 0; i < 2*maxRegexps; i++ {
+			_, err = compileRegexp(fmt.Sprintf("^expr%d$", i))
+			Expect(err).ToNot(HaveOccurred())
+			// Keep the first expression recently used.
+			_, err = compileRegexp("^first$")
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(regexps.len()).To(Equal(maxRegexps))
+
+		re, ok := regexps.load("^first$")
+		Expect(ok).To(BeTrue())
+		Expect(re).To(BeIdenticalTo(first))
+		_, ok = regexps.load("^expr0$")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should not cache invalid expressions", func() {
+		_, err := compileRegexp("(")
+		Expect(err).To(HaveOccurred())
+		_, ok := regexps.load("(")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,279 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File cache.go provides cache of downloaded artifacts.
+
+package downloader
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+const metaSuffix = ".json"
+
+// cacheEntry describes single file stored in Cache.
+type cacheEntry struct {
+	URI          weles.ArtifactURI `json:"uri"`
+	ETag         string            `json:"etag,omitempty"`
+	LastModified string            `json:"last_modified,omitempty"`
+	Size         int64             `json:"size"`
+	Used         time.Time         `json:"used"`
+}
+
+// Cache stores downloaded artifacts, so they can be reused by following jobs.
+// Entries are identified by URI and validated with ETag and Last-Modified values
+// returned by the server. Least recently used entries are evicted when size
+// of the cache exceeds its limit.
+type Cache struct {
+	dir     string
+	maxSize int64
+	size    int64
+	// lru holds *cacheEntry values, most recently used at the front.
+	lru     *list.List
+	entries map[weles.ArtifactURI]*list.Element
+	mutex   sync.Mutex
+}
+
+// NewCache returns Cache storing files in dir and limited to maxSize bytes.
+// Entries stored in dir by previous instances are loaded.
+func NewCache(dir string, maxSize int64) (*Cache, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: make(map[weles.ArtifactURI]*list.Element),
+	}
+	err = c.load()
+	if err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.evict()
+	return c, nil
+}
+
+// load reads metadata of entries stored in cache directory.
+func (c *Cache) load() error {
+	metas, err := filepath.Glob(filepath.Join(c.dir, "*"+metaSuffix))
+	if err != nil {
+		return err
+	}
+	loaded := make([]*cacheEntry, 0, len(metas))
+	for _, m := range metas {
+		data, err := ioutil.ReadFile(m)
+		if err != nil {
+			return err
+		}
+		e := new(cacheEntry)
+		if err = json.Unmarshal(data, e); err != nil {
+			log.Println("removing malformed cache entry: " + m)
+			c.remove(strings.TrimSuffix(m, metaSuffix))
+			continue
+		}
+		if _, err = os.Stat(c.path(e.URI)); err != nil {
+			c.remove(c.path(e.URI))
+			continue
+		}
+		loaded = append(loaded, e)
+	}
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].Used.Before(loaded[j].Used) })
+	for _, e := range loaded {
+		c.entries[e.URI] = c.lru.PushFront(e)
+		c.size += e.Size
+	}
+	return nil
+}
+
+// path returns location of the cached file identified by URI.
+func (c *Cache) path(URI weles.ArtifactURI) string {
+	sum := sha256.Sum256([]byte(URI))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// lookup returns copy of the entry identified by URI.
+func (c *Cache) lookup(URI weles.ArtifactURI) (cacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	el, ok := c.entries[URI]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	return *el.Value.(*cacheEntry), true
+}
+
+// Info returns information about cached file identified by URI.
+func (c *Cache) Info(URI weles.ArtifactURI) (weles.ArtifactInfo, error) {
+	e, ok := c.lookup(URI)
+	if !ok {
+		return weles.ArtifactInfo{}, ErrNotInCache
+	}
+	return weles.ArtifactInfo{
+		ArtifactDescription: weles.ArtifactDescription{URI: URI},
+		Path:                weles.ArtifactPath(c.path(URI)),
+		Status:              weles.ArtifactStatusREADY,
+		Timestamp:           strfmt.DateTime(e.Used),
+	}, nil
+}
+
+// restore places cached file identified by URI in path. Hard link is used
+// if possible, otherwise file is copied.
+func (c *Cache) restore(URI weles.ArtifactURI, path weles.ArtifactPath) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	el, ok := c.entries[URI]
+	if !ok {
+		return ErrNotInCache
+	}
+	err := linkOrCopy(c.path(URI), string(path))
+	if err != nil {
+		return err
+	}
+	e := el.Value.(*cacheEntry)
+	e.Used = time.Now().UTC()
+	c.lru.MoveToFront(el)
+	return c.saveMeta(e)
+}
+
+// store adds file from path to cache as an entry identified by URI.
+// Files larger than the cache limit are not stored.
+func (c *Cache) store(URI weles.ArtifactURI, path weles.ArtifactPath, etag, lastModified string,
+) error {
+	fi, err := os.Stat(string(path))
+	if err != nil {
+		return err
+	}
+	if fi.Size() > c.maxSize {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.drop(URI)
+	err = linkOrCopy(string(path), c.path(URI))
+	if err != nil {
+		return err
+	}
+	e := &cacheEntry{
+		URI:          URI,
+		ETag:         etag,
+		LastModified: lastModified,
+		Size:         fi.Size(),
+		Used:         time.Now().UTC(),
+	}
+	c.entries[URI] = c.lru.PushFront(e)
+	c.size += e.Size
+	c.evict()
+	return c.saveMeta(e)
+}
+
+// invalidate removes entry identified by URI from cache.
+func (c *Cache) invalidate(URI weles.ArtifactURI) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.drop(URI)
+}
+
+// saveMeta writes metadata of the entry to the cache directory.
+func (c *Cache) saveMeta(e *cacheEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path(e.URI)+metaSuffix, data, 0644)
+}
+
+// drop removes entry identified by URI from cache. It must be called with mutex locked.
+func (c *Cache) drop(URI weles.ArtifactURI) {
+	el, ok := c.entries[URI]
+	if !ok {
+		return
+	}
+	c.lru.Remove(el)
+	delete(c.entries, URI)
+	c.size -= el.Value.(*cacheEntry).Size
+	c.remove(c.path(URI))
+}
+
+// evict drops least recently used entries until size of the cache fits the limit.
+// It must be called with mutex locked.
+func (c *Cache) evict() {
+	for c.size > c.maxSize && c.lru.Len() > 0 {
+		c.drop(c.lru.Back().Value.(*cacheEntry).URI)
+	}
+}
+
+// remove deletes cached file and its metadata.
+func (c *Cache) remove(path string) {
+	for _, p := range []string{path, path + metaSuffix} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			log.Println("failed to remove cached file: " + err.Error())
+		}
+	}
+}
+
+// linkOrCopy creates hard link dst pointing to src. If it is not possible,
+// e.g. paths are located on different filesystems, content of src is copied.
+// dst is replaced if it exists.
+func linkOrCopy(src, dst string) (err error) {
+	if err = os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if os.Link(src, dst) == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if erro := in.Close(); erro != nil {
+			log.Println("failed to close file: " + src + " " + erro.Error())
+		}
+	}()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if erro := out.Close(); err == nil {
+			err = erro
+		}
+	}()
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,203 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package downloader
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/SamsungSLAV/weles"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+
+	const content = "Oh, the places you'll go!"
+	const etag = `"places"`
+
+	var (
+		tmpDir   string
+		cacheDir string
+		cache    *Cache
+		uri      weles.ArtifactURI = "http://example.com/places"
+	)
+
+	writeFile := func(name, data string) weles.ArtifactPath {
+		path := filepath.Join(tmpDir, name)
+		Expect(ioutil.WriteFile(path, []byte(data), 0644)).To(Succeed())
+		return weles.ArtifactPath(path)
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "weles-")
+		Expect(err).ToNot(HaveOccurred())
+		cacheDir = filepath.Join(tmpDir, "cache")
+		cache, err = NewCache(cacheDir, 100)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	It("should store and restore files", func() {
+		Expect(cache.store(uri, writeFile("src", content), etag, "")).To(Succeed())
+
+		e, ok := cache.lookup(uri)
+		Expect(ok).To(BeTrue())
+		Expect(e.ETag).To(Equal(etag))
+		Expect(e.Size).To(BeEquivalentTo(len(content)))
+
+		dst := writeFile("dst", "")
+		Expect(cache.restore(uri, dst)).To(Succeed())
+		data, err := ioutil.ReadFile(string(dst))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(content))
+
+		info, err := cache.Info(uri)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.URI).To(Equal(uri))
+		Expect(info.Status).To(Equal(weles.ArtifactStatusREADY))
+		Expect(string(info.Path)).To(BeAnExistingFile())
+	})
+
+	It("should return ErrNotInCache for unknown URI", func() {
+		_, err := cache.Info(uri)
+		Expect(err).To(Equal(ErrNotInCache))
+		Expect(cache.restore(uri, writeFile("dst", ""))).To(Equal(ErrNotInCache))
+	})
+
+	It("should evict least recently used entries", func() {
+		uris := []weles.ArtifactURI{"uri_0", "uri_1", "uri_2"}
+		for i, u := range uris {
+			Expect(cache.store(u, writeFile(fmt.Sprint(i), strings.Repeat("x", 40)), etag,
+				"")).To(Succeed())
+			if i == 1 {
+				// Use the first entry, so the second one becomes least recently used.
+				Expect(cache.restore(uris[0], writeFile("dst", ""))).To(Succeed())
+			}
+		}
+
+		_, ok := cache.lookup(uris[1])
+		Expect(ok).To(BeFalse())
+		Expect(cache.path(uris[1])).NotTo(BeAnExistingFile())
+		for _, u := range []weles.ArtifactURI{uris[0], uris[2]} {
+			_, ok = cache.lookup(u)
+			Expect(ok).To(BeTrue())
+		}
+		Expect(cache.size).To(BeEquivalentTo(80))
+	})
+
+	It("should invalidate entries", func() {
+		Expect(cache.store(uri, writeFile("src", content), etag, "")).To(Succeed())
+
+		cache.invalidate(uri)
+		_, ok := cache.lookup(uri)
+		Expect(ok).To(BeFalse())
+		Expect(cache.path(uri)).NotTo(BeAnExistingFile())
+		Expect(cache.size).To(BeZero())
+	})
+
+	It("should not store files larger than the limit", func() {
+		Expect(cache.store(uri, writeFile("big", strings.Repeat("x", 101)), etag,
+			"")).To(Succeed())
+		_, ok := cache.lookup(uri)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should load entries stored by previous instance", func() {
+		Expect(cache.store(uri, writeFile("src", content), etag, "")).To(Succeed())
+
+		reloaded, err := NewCache(cacheDir, 100)
+		Expect(err).ToNot(HaveOccurred())
+		e, ok := reloaded.lookup(uri)
+		Expect(ok).To(BeTrue())
+		Expect(e.ETag).To(Equal(etag))
+		Expect(reloaded.size).To(BeEquivalentTo(len(content)))
+	})
+
+	Describe("Downloader with cache", func() {
+		var (
+			ts           *httptest.Server
+			requests     int32
+			notification chan weles.ArtifactStatusChange
+			goldenTiger  *Downloader
+		)
+
+		BeforeEach(func() {
+			atomic.StoreInt32(&requests, 0)
+			ts = httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					atomic.AddInt32(&requests, 1)
+					if r.Header.Get("If-None-Match") == etag {
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+					w.Header().Set("ETag", etag)
+					fmt.Fprint(w, content)
+				}))
+			notification = make(chan weles.ArtifactStatusChange, 10)
+			goldenTiger = NewDownloader(notification, nil, 1, 10, cache, RetryPolicy{},
+				LimitPolicy{}, nil, "")
+		})
+
+		AfterEach(func() {
+			goldenTiger.Close()
+			ts.Close()
+		})
+
+		It("should reuse cached file if it is still valid", func() {
+			URI := weles.ArtifactURI(ts.URL)
+			first := writeFile("first", "")
+			second := writeFile("second", "")
+
+			cached, err := goldenTiger.getData(URI, first, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cached).To(BeFalse())
+
+			info, err := goldenTiger.CheckInCache(URI)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.URI).To(Equal(URI))
+
+			goldenTiger.download(downloadJob{uri: URI, path: second,
+				ch: make(chan weles.ArtifactStatusChange, 10)})
+			Eventually(notification).Should(Receive(Equal(weles.ArtifactStatusChange{
+				Path:      second,
+				NewStatus: weles.ArtifactStatusDOWNLOADING,
+			})))
+			Eventually(notification).Should(Receive(Equal(weles.ArtifactStatusChange{
+				Path:      second,
+				NewStatus: weles.ArtifactStatusREADY,
+				Cached:    true,
+				ETag:      etag,
+			})))
+
+			data, err := ioutil.ReadFile(string(second))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(content))
+			Expect(atomic.LoadInt32(&requests)).To(BeEquivalentTo(2))
+		})
+	})
+})
@@ -0,0 +1,377 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File credentials.go provides configuration of HTTP client used for downloads:
+// credentials and TLS settings of hosts, trusted CAs and proxy.
+
+package downloader
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SamsungSLAV/weles"
+	"golang.org/x/net/http/httpproxy"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// redacted replaces secrets in printed values.
+const redacted = "xxxxx"
+
+// HostCredentials defines authentication and TLS settings used for downloads from a host.
+// Token takes precedence over Username and Password.
+type HostCredentials struct {
+	// Username and Password are sent with basic authentication.
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// Token is sent as bearer token.
+	Token string `yaml:"token"`
+	// CertFile and KeyFile are paths of PEM encoded client TLS certificate and its key.
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// CAFile is path of PEM encoded bundle of CA certificates trusted for the host.
+	CAFile string `yaml:"ca_file"`
+}
+
+// String implements fmt.Stringer interface, so that secrets are not printed.
+func (c HostCredentials) String() string {
+	password, token := c.Password, c.Token
+	if password != "" {
+		password = redacted
+	}
+	if token != "" {
+		token = redacted
+	}
+	return fmt.Sprintf("{Username:%s Password:%s Token:%s CertFile:%s KeyFile:%s CAFile:%s}",
+		c.Username, password, token, c.CertFile, c.KeyFile, c.CAFile)
+}
+
+// GoString implements fmt.GoStringer interface, so that secrets are not printed.
+func (c HostCredentials) GoString() string {
+	return "downloader.HostCredentials" + c.String()
+}
+
+// TransportConfig defines how artifacts are downloaded over HTTP(S).
+type TransportConfig struct {
+	// Hosts maps hosts to their credentials. Host may contain port, in which case it
+	// takes precedence over entry without port.
+	Hosts map[string]HostCredentials `yaml:"hosts"`
+	// Netrc is path of netrc file with credentials of hosts which are not listed in
+	// Hosts. It is not used if empty.
+	Netrc string `yaml:"netrc"`
+	// CAFile is path of PEM encoded bundle of CA certificates trusted for all hosts
+	// in addition to system ones.
+	CAFile string `yaml:"ca_file"`
+	// HTTPProxy, HTTPSProxy and NoProxy have the same meaning as HTTP_PROXY, HTTPS_PROXY
+	// and NO_PROXY environment variables, which are used if all of them are empty.
+	HTTPProxy  string `yaml:"http_proxy"`
+	HTTPSProxy string `yaml:"https_proxy"`
+	NoProxy    string `yaml:"no_proxy"`
+}
+
+// LoadTransportConfig reads TransportConfig from YAML file.
+func LoadTransportConfig(path string) (TransportConfig, error) {
+	var cfg TransportConfig
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	err = yaml.UnmarshalStrict(data, &cfg)
+	return cfg, err
+}
+
+// NewHTTPClient returns HTTP client configured according to cfg.
+func NewHTTPClient(cfg TransportConfig) (*http.Client, error) {
+	proxy := http.ProxyFromEnvironment
+	if cfg.HTTPProxy != "" || cfg.HTTPSProxy != "" || cfg.NoProxy != "" {
+		pf := (&httpproxy.Config{
+			HTTPProxy:  cfg.HTTPProxy,
+			HTTPSProxy: cfg.HTTPSProxy,
+			NoProxy:    cfg.NoProxy,
+		}).ProxyFunc()
+		proxy = func(req *http.Request) (*url.URL, error) {
+			return pf(req.URL)
+		}
+	}
+
+	roots, err := certPool(cfg.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	t := &hostTransport{
+		base:  newTransport(proxy, &tls.Config{RootCAs: roots}),
+		hosts: make(map[string]hostEntry, len(cfg.Hosts)),
+	}
+	for host, creds := range cfg.Hosts {
+		e := hostEntry{creds: creds}
+		if creds.CAFile != "" || creds.CertFile != "" || creds.KeyFile != "" {
+			e.transport, err = hostTLSTransport(proxy, roots, cfg.CAFile, creds)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TLS settings of %s: %s", host, err.Error())
+			}
+		}
+		t.hosts[host] = e
+	}
+	if cfg.Netrc != "" {
+		data, err := ioutil.ReadFile(cfg.Netrc)
+		if err != nil {
+			return nil, err
+		}
+		t.netrc = parseNetrc(string(data))
+	}
+	return &http.Client{Transport: t}, nil
+}
+
+// newTransport returns transport with the same settings as http.DefaultTransport
+// except proxy and TLS configuration.
+func newTransport(proxy func(*http.Request) (*url.URL, error), tlsConfig *tls.Config,
+) *http.Transport {
+	return &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+}
+
+// hostTLSTransport returns transport using TLS settings of a host.
+func hostTLSTransport(proxy func(*http.Request) (*url.URL, error), roots *x509.CertPool,
+	commonCAFile string, creds HostCredentials) (*http.Transport, error) {
+	tlsConfig := &tls.Config{RootCAs: roots}
+	if creds.CAFile != "" {
+		var err error
+		if tlsConfig.RootCAs, err = certPool(commonCAFile, creds.CAFile); err != nil {
+			return nil, err
+		}
+	}
+	if creds.CertFile != "" || creds.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(creds.CertFile, creds.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return newTransport(proxy, tlsConfig), nil
+}
+
+// certPool returns pool of system CA certificates extended with certificates from
+// files. Nil is returned if there are no files, so that system pool is used.
+func certPool(files ...string) (*x509.CertPool, error) {
+	var pool *x509.CertPool
+	for _, f := range files {
+		if f == "" {
+			continue
+		}
+		if pool == nil {
+			var err error
+			if pool, err = x509.SystemCertPool(); err != nil {
+				pool = x509.NewCertPool()
+			}
+		}
+		pem, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in " + f)
+		}
+	}
+	return pool, nil
+}
+
+// hostEntry contains settings of a host used by hostTransport.
+type hostEntry struct {
+	creds HostCredentials
+	// transport is used for requests to the host instead of the base one unless it is nil.
+	transport *http.Transport
+}
+
+// hostTransport implements http.RoundTripper interface. It authenticates requests
+// and selects TLS settings according to the requested host.
+type hostTransport struct {
+	base  *http.Transport
+	hosts map[string]hostEntry
+	netrc map[string]netrcEntry
+}
+
+// entry returns settings of the host. Entries containing port are preferred.
+func (t *hostTransport) entry(u *url.URL) (hostEntry, bool) {
+	if e, ok := t.hosts[u.Host]; ok {
+		return e, true
+	}
+	e, ok := t.hosts[u.Hostname()]
+	return e, ok
+}
+
+// RoundTrip is part of implementation of http.RoundTripper interface. Authorization
+// header which is already set (e.g. by S3 signing) is not replaced.
+func (t *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.base
+	e, ok := t.entry(req.URL)
+	if ok && e.transport != nil {
+		transport = e.transport
+	}
+	if req.Header.Get("Authorization") != "" {
+		return transport.RoundTrip(req)
+	}
+
+	var auth string
+	switch {
+	case e.creds.Token != "":
+		auth = "Bearer " + e.creds.Token
+	case e.creds.Username != "" || e.creds.Password != "":
+		auth = basicAuth(e.creds.Username, e.creds.Password)
+	case !ok && t.netrc != nil:
+		n, found := t.netrc[req.URL.Hostname()]
+		if !found {
+			n, found = t.netrc[""]
+		}
+		if found {
+			auth = basicAuth(n.login, n.password)
+		}
+	}
+	if auth == "" {
+		return transport.RoundTrip(req)
+	}
+	// RoundTripper must not modify the request.
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		r.Header[k] = v
+	}
+	r.Header.Set("Authorization", auth)
+	return transport.RoundTrip(r)
+}
+
+// basicAuth returns value of Authorization header for basic authentication.
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+// netrcEntry contains credentials of a machine from netrc file.
+type netrcEntry struct {
+	login    string
+	password string
+}
+
+// parseNetrc returns credentials of machines defined in netrc file content. Default
+// entry is stored under empty name. Macro definitions are skipped.
+func parseNetrc(data string) map[string]netrcEntry {
+	entries := make(map[string]netrcEntry)
+	var (
+		machine string
+		current *netrcEntry
+	)
+	store := func() {
+		if current != nil {
+			if _, ok := entries[machine]; !ok {
+				entries[machine] = *current
+			}
+		}
+		current = nil
+	}
+
+	lines := bufio.NewScanner(strings.NewReader(data))
+	inMacro := false
+	for lines.Scan() {
+		line := lines.Text()
+		if inMacro {
+			// Macro definition ends with an empty line.
+			inMacro = strings.TrimSpace(line) != ""
+			continue
+		}
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields); i++ {
+			if strings.HasPrefix(fields[i], "#") {
+				break
+			}
+			value := ""
+			if i+1 < len(fields) {
+				value = fields[i+1]
+			}
+			switch fields[i] {
+			case "machine":
+				store()
+				machine, current = value, &netrcEntry{}
+				i++
+			case "default":
+				store()
+				machine, current = "", &netrcEntry{}
+			case "login":
+				if current != nil {
+					current.login = value
+				}
+				i++
+			case "password":
+				if current != nil {
+					current.password = value
+				}
+				i++
+			case "account":
+				i++
+			case "macdef":
+				store()
+				inMacro = true
+				i = len(fields)
+			}
+		}
+	}
+	store()
+	return entries
+}
+
+// RedactURI returns URI with password removed, so that it can be logged or shown to users.
+// User information of URI which cannot be parsed is assumed to end at the last "@",
+// as unescaped password may contain any character.
+func RedactURI(URI weles.ArtifactURI) string {
+	u, err := url.Parse(string(URI))
+	if err != nil {
+		s := string(URI)
+		at := strings.LastIndex(s, "@")
+		if at < 0 {
+			return s
+		}
+		start := strings.Index(s, "://")
+		if start < 0 || start > at {
+			return redacted + s[at:]
+		}
+		return s[:start+len("://")] + redacted + s[at:]
+	}
+	if u.User == nil {
+		return string(URI)
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	return u.String()
+}
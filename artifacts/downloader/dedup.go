@@ -0,0 +1,272 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File dedup.go provides sharing of a single download between concurrent requests
+// of the same URI.
+
+package downloader
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+// flight is a download of a URI which is queued or in progress. It is shared by all
+// jobs requesting the URI in the meantime.
+type flight struct {
+	// ctx of the transfer is canceled when contexts of all members are canceled.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// members are jobs sharing the download. The first one downloads the file to its
+	// path, others get a copy of it.
+	members []*member
+	// started is set when a worker starts the download.
+	started bool
+	// finished is set when all members are notified about result of the download.
+	finished bool
+	// done is closed when flight is finished.
+	done chan struct{}
+	// notifyMutex serializes notifications of members, so that they receive status
+	// changes in order. It must be locked before flightsMutex.
+	notifyMutex sync.Mutex
+}
+
+// member is a job sharing a flight.
+type member struct {
+	job downloadJob
+	// informed is set when member is notified that download started.
+	informed bool
+	// canceled is set when context of the job is canceled.
+	canceled bool
+	// finished is set when member is notified about its final status.
+	finished bool
+}
+
+// newFlight returns flight of job's URI with job as its only member.
+func newFlight(job downloadJob) *flight {
+	f := &flight{
+		members: []*member{{job: job}},
+		done:    make(chan struct{}),
+	}
+	f.ctx, f.cancel = context.WithCancel(context.Background())
+	return f
+}
+
+// channels returns channels notified about status changes of the job.
+func (d *Downloader) channels(job downloadJob) []chan weles.ArtifactStatusChange {
+	return []chan weles.ArtifactStatusChange{job.ch, d.notification}
+}
+
+// join adds job to the flight of its URI which is not started yet or is in progress.
+// New flight is created if there is no such flight. It returns flight and true if it
+// was created, in which case job must be put on the queue.
+func (d *Downloader) join(job downloadJob) (*flight, bool) {
+	d.flightsMutex.Lock()
+	defer d.flightsMutex.Unlock()
+	f, ok := d.flights[job.uri]
+	// Download being aborted is not joined.
+	ok = ok && f.ctx.Err() == nil
+	if ok {
+		f.members = append(f.members, &member{job: job})
+	} else {
+		f = newFlight(job)
+		d.flights[job.uri] = f
+	}
+	if job.ctx != nil && job.ctx.Done() != nil {
+		go d.watch(f, f.members[len(f.members)-1])
+	}
+	return f, !ok
+}
+
+// raise makes download of the flight waiting for a worker at least as urgent as job
+// which joined it, so that urgent job does not wait behind less urgent ones.
+func (d *Downloader) raise(f *flight, job downloadJob) {
+	d.queue.raise(f, job.priority)
+	d.limiter.raise(hostOf(job.uri), f, job.priority)
+}
+
+// watch waits until context of the member is canceled or flight is finished.
+// Canceled member is notified immediately unless it owns the file being downloaded.
+// Transfer is canceled when all members of the flight are canceled.
+func (d *Downloader) watch(f *flight, m *member) {
+	select {
+	case <-f.done:
+		return
+	case <-m.job.ctx.Done():
+	}
+
+	f.notifyMutex.Lock()
+	defer f.notifyMutex.Unlock()
+	d.flightsMutex.Lock()
+	if f.finished {
+		d.flightsMutex.Unlock()
+		return
+	}
+	m.canceled = true
+	m.finished = !f.started || m != f.members[0]
+	all := true
+	for _, other := range f.members {
+		all = all && other.canceled
+	}
+	if all {
+		f.cancel()
+		if !f.started {
+			d.finish(f)
+		}
+	}
+	d.flightsMutex.Unlock()
+
+	if m.finished {
+		notify(weles.ArtifactStatusChange{
+			Path:      m.job.path,
+			NewStatus: weles.ArtifactStatusCANCELED,
+		}, d.channels(m.job))
+	}
+}
+
+// finish marks flight as finished and removes it from flights, so that new requests
+// of its URI start a new download. It must be called with flightsMutex locked.
+func (d *Downloader) finish(f *flight) {
+	f.finished = true
+	close(f.done)
+	if d.flights[f.members[0].job.uri] == f {
+		delete(d.flights, f.members[0].job.uri)
+	}
+}
+
+// start marks flight as started and notifies its members. It returns false if all
+// members of the flight were canceled before it started.
+func (d *Downloader) start(f *flight) bool {
+	f.notifyMutex.Lock()
+	defer f.notifyMutex.Unlock()
+	d.flightsMutex.Lock()
+	if f.finished {
+		d.flightsMutex.Unlock()
+		return false
+	}
+	f.started = true
+	d.flightsMutex.Unlock()
+	d.inform(f, weles.ArtifactStatusChange{NewStatus: weles.ArtifactStatusDOWNLOADING})
+	return true
+}
+
+// progress notifies members of the flight about progress of the download.
+func (d *Downloader) progress(f *flight, change weles.ArtifactStatusChange) {
+	f.notifyMutex.Lock()
+	defer f.notifyMutex.Unlock()
+	d.inform(f, change)
+}
+
+// inform sends change to members of the flight which are not canceled. Members which
+// joined after download started are notified about it first. It must be called with
+// notifyMutex locked.
+func (d *Downloader) inform(f *flight, change weles.ArtifactStatusChange) {
+	d.flightsMutex.Lock()
+	var uninformed, active []downloadJob
+	for _, m := range f.members {
+		if m.canceled {
+			continue
+		}
+		if !m.informed {
+			m.informed = true
+			uninformed = append(uninformed, m.job)
+		}
+		active = append(active, m.job)
+	}
+	d.flightsMutex.Unlock()
+
+	if change.Progress != nil {
+		d.notifyJobs(uninformed, weles.ArtifactStatusChange{
+			NewStatus: weles.ArtifactStatusDOWNLOADING,
+		})
+		d.notifyJobs(active, change)
+		return
+	}
+	d.notifyJobs(uninformed, change)
+}
+
+// land finishes the flight and notifies its members about result of the download.
+// Downloaded file is shared with other members the same way as cached files. Canceled
+// members get CANCELED status and owner's file is removed if owner was canceled.
+func (d *Downloader) land(f *flight, result weles.ArtifactStatusChange) {
+	f.notifyMutex.Lock()
+	defer f.notifyMutex.Unlock()
+	d.flightsMutex.Lock()
+	d.finish(f)
+	var uninformed []downloadJob
+	for _, m := range f.members {
+		if !m.informed && !m.canceled {
+			m.informed = true
+			uninformed = append(uninformed, m.job)
+		}
+	}
+	d.flightsMutex.Unlock()
+	d.notifyJobs(uninformed, weles.ArtifactStatusChange{
+		NewStatus: weles.ArtifactStatusDOWNLOADING,
+	})
+
+	// Owner is completed last as its file is shared with others.
+	owner := f.members[0]
+	order := append(append(make([]*member, 0, len(f.members)), f.members[1:]...), owner)
+	for _, m := range order {
+		if m.finished {
+			continue
+		}
+		change := result
+		change.Path = m.job.path
+		switch {
+		case m.canceled:
+			change = weles.ArtifactStatusChange{
+				Path:      m.job.path,
+				NewStatus: weles.ArtifactStatusCANCELED,
+			}
+			if m == owner && result.NewStatus == weles.ArtifactStatusREADY {
+				removeArtifact(m.job.path)
+			}
+		case m != owner && change.NewStatus == weles.ArtifactStatusREADY:
+			if err := linkOrCopy(string(result.Path), string(m.job.path)); err != nil {
+				log.Println("failed to share downloaded artifact: ", m.job.path,
+					" due to: "+err.Error())
+				removeArtifact(m.job.path)
+				change = weles.ArtifactStatusChange{
+					Path:      m.job.path,
+					NewStatus: weles.ArtifactStatusFAILED,
+				}
+			}
+		}
+		notify(change, d.channels(m.job))
+	}
+	f.cancel()
+}
+
+// notifyJobs sends change to channels of all jobs with their paths set.
+func (d *Downloader) notifyJobs(jobs []downloadJob, change weles.ArtifactStatusChange) {
+	for _, job := range jobs {
+		change.Path = job.path
+		notify(change, d.channels(job))
+	}
+}
+
+// removeArtifact removes file of an artifact logging failure.
+func removeArtifact(path weles.ArtifactPath) {
+	if err := os.Remove(string(path)); err != nil && !os.IsNotExist(err) {
+		log.Println("failed to remove an artifact: ", path, " due to: "+err.Error())
+	}
+}
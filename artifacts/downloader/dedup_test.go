@@ -0,0 +1,243 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/SamsungSLAV/weles"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deduplication", func() {
+
+	const content = "Would you, could you, in a box?"
+
+	var (
+		copperLynx *Downloader
+		tmpDir     string
+		ts         *httptest.Server
+		uri        weles.ArtifactURI
+		release    chan struct{}
+		requests   int32
+		status     int
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "weles-")
+		Expect(err).ToNot(HaveOccurred())
+		release = make(chan struct{})
+		atomic.StoreInt32(&requests, 0)
+		status = http.StatusOK
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			<-release
+			w.WriteHeader(status)
+			fmt.Fprint(w, content)
+		}))
+		uri = weles.ArtifactURI(ts.URL + "/box")
+		copperLynx = NewDownloader(make(chan weles.ArtifactStatusChange, 100), nil, 4, 10,
+			nil, RetryPolicy{}, LimitPolicy{}, nil, "")
+	})
+
+	AfterEach(func() {
+		copperLynx.Close()
+		ts.Close()
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	requested := func() int32 {
+		return atomic.LoadInt32(&requests)
+	}
+
+	downloadWithContext := func(ctx context.Context, name string,
+	) (weles.ArtifactPath, chan weles.ArtifactStatusChange) {
+		path := weles.ArtifactPath(filepath.Join(tmpDir, name))
+		ch := make(chan weles.ArtifactStatusChange, 100)
+		ExpectWithOffset(1, copperLynx.Download(ctx, uri, path, weles.MEDIUM, ch)).
+			To(Succeed())
+		return path, ch
+	}
+
+	download := func(name string) (weles.ArtifactPath, chan weles.ArtifactStatusChange) {
+		return downloadWithContext(context.Background(), name)
+	}
+
+	// statuses returns statuses received from ch until download is finished.
+	// Progress notifications are skipped.
+	statuses := func(path weles.ArtifactPath, ch chan weles.ArtifactStatusChange,
+	) []weles.ArtifactStatus {
+		var ret []weles.ArtifactStatus
+		for {
+			var change weles.ArtifactStatusChange
+			EventuallyWithOffset(1, ch).Should(Receive(&change))
+			ExpectWithOffset(1, change.Path).To(Equal(path))
+			if change.Progress == nil {
+				ret = append(ret, change.NewStatus)
+			}
+			if change.NewStatus == weles.ArtifactStatusREADY ||
+				change.NewStatus == weles.ArtifactStatusFAILED ||
+				change.NewStatus == weles.ArtifactStatusCANCELED {
+				return ret
+			}
+		}
+	}
+
+	It("should share single download between concurrent requests", func() {
+		first, firstCh := download("first")
+		Eventually(requested).Should(BeEquivalentTo(1))
+		second, secondCh := download("second")
+		third, thirdCh := download("third")
+		close(release)
+
+		expected := []weles.ArtifactStatus{weles.ArtifactStatusPENDING,
+			weles.ArtifactStatusDOWNLOADING, weles.ArtifactStatusREADY}
+		for path, ch := range map[weles.ArtifactPath]chan weles.ArtifactStatusChange{
+			first: firstCh, second: secondCh, third: thirdCh} {
+			Expect(statuses(path, ch)).To(Equal(expected))
+			data, err := ioutil.ReadFile(string(path))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(content))
+		}
+		Expect(requested()).To(BeEquivalentTo(1))
+
+		By("Later request should start new download")
+		fourth, fourthCh := download("fourth")
+		Expect(statuses(fourth, fourthCh)).To(Equal(expected))
+		Expect(requested()).To(BeEquivalentTo(2))
+	})
+
+	It("should fail all requests sharing failed download", func() {
+		status = http.StatusNotFound
+		first, firstCh := download("first")
+		Eventually(requested).Should(BeEquivalentTo(1))
+		second, secondCh := download("second")
+		close(release)
+
+		expected := []weles.ArtifactStatus{weles.ArtifactStatusPENDING,
+			weles.ArtifactStatusDOWNLOADING, weles.ArtifactStatusFAILED}
+		Expect(statuses(first, firstCh)).To(Equal(expected))
+		Expect(statuses(second, secondCh)).To(Equal(expected))
+		Expect(string(first)).NotTo(BeAnExistingFile())
+		Expect(string(second)).NotTo(BeAnExistingFile())
+		Expect(requested()).To(BeEquivalentTo(1))
+	})
+
+	It("should notify Downloader's channel about every request", func() {
+		first, _ := download("first")
+		second, _ := download("second")
+		close(release)
+
+		ready := map[weles.ArtifactPath]bool{}
+		for len(ready) < 2 {
+			var change weles.ArtifactStatusChange
+			Eventually(copperLynx.notification).Should(Receive(&change))
+			if change.NewStatus == weles.ArtifactStatusREADY {
+				ready[change.Path] = true
+			}
+		}
+		Expect(ready).To(HaveKey(first))
+		Expect(ready).To(HaveKey(second))
+	})
+
+	Describe("cancellation", func() {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+		})
+
+		It("should cancel queued download", func() {
+			copperLynx.Close()
+			copperLynx = NewDownloader(make(chan weles.ArtifactStatusChange, 100), nil, 4, 10,
+				nil, RetryPolicy{}, LimitPolicy{HostDownloads: 1}, nil, "")
+			uri = weles.ArtifactURI(ts.URL + "/busy")
+			busy, busyCh := download("busy")
+			Eventually(requested).Should(BeEquivalentTo(1))
+			uri = weles.ArtifactURI(ts.URL + "/queued")
+			queued, queuedCh := downloadWithContext(ctx, "queued")
+
+			cancel()
+			Expect(statuses(queued, queuedCh)).To(Equal([]weles.ArtifactStatus{
+				weles.ArtifactStatusPENDING, weles.ArtifactStatusCANCELED}))
+			close(release)
+
+			Expect(statuses(busy, busyCh)).To(Equal([]weles.ArtifactStatus{
+				weles.ArtifactStatusPENDING, weles.ArtifactStatusDOWNLOADING,
+				weles.ArtifactStatusREADY}))
+			Consistently(requested).Should(BeEquivalentTo(1))
+			Expect(string(queued)).NotTo(BeAnExistingFile())
+		})
+
+		It("should abort transfer when all requests are canceled", func() {
+			first, firstCh := downloadWithContext(ctx, "first")
+			Eventually(requested).Should(BeEquivalentTo(1))
+
+			cancel()
+			Expect(statuses(first, firstCh)).To(Equal([]weles.ArtifactStatus{
+				weles.ArtifactStatusPENDING, weles.ArtifactStatusDOWNLOADING,
+				weles.ArtifactStatusCANCELED}))
+			Expect(string(first)).NotTo(BeAnExistingFile())
+			close(release)
+		})
+
+		It("should continue shared download for requests which are not canceled", func() {
+			first, firstCh := downloadWithContext(ctx, "first")
+			Eventually(requested).Should(BeEquivalentTo(1))
+			second, secondCh := download("second")
+			otherCtx, otherCancel := context.WithCancel(context.Background())
+			third, thirdCh := downloadWithContext(otherCtx, "third")
+
+			By("Canceled request which does not own the file should be notified immediately")
+			otherCancel()
+			Expect(statuses(third, thirdCh)).To(Equal([]weles.ArtifactStatus{
+				weles.ArtifactStatusPENDING, weles.ArtifactStatusCANCELED}))
+
+			cancel()
+			Eventually(func() bool {
+				copperLynx.flightsMutex.Lock()
+				defer copperLynx.flightsMutex.Unlock()
+				return copperLynx.flights[uri].members[0].canceled
+			}).Should(BeTrue())
+			close(release)
+			Expect(statuses(first, firstCh)).To(Equal([]weles.ArtifactStatus{
+				weles.ArtifactStatusPENDING, weles.ArtifactStatusDOWNLOADING,
+				weles.ArtifactStatusCANCELED}))
+			Expect(statuses(second, secondCh)).To(Equal([]weles.ArtifactStatus{
+				weles.ArtifactStatusPENDING, weles.ArtifactStatusDOWNLOADING,
+				weles.ArtifactStatusREADY}))
+			data, err := ioutil.ReadFile(string(second))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(content))
+			Expect(string(first)).NotTo(BeAnExistingFile())
+			Expect(string(third)).NotTo(BeAnExistingFile())
+			Expect(requested()).To(BeEquivalentTo(1))
+		})
+	})
+})
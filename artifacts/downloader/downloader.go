@@ -18,12 +18,15 @@
 package downloader
 
 import (
-	"fmt"
+	"context"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
 
 	"github.com/SamsungSLAV/weles"
 )
@@ -31,23 +34,99 @@ import (
 // Downloader implements ArtifactDownloader interface.
 type Downloader struct {
 	notification chan weles.ArtifactStatusChange // can be used to monitor ArtifactStatusChanges.
-	queue        chan downloadJob
-	wg           sync.WaitGroup
+	// attempts receives history of download attempts. It is not used if nil.
+	attempts chan weles.ArtifactAttempt
+	queue    *queue
+	wg       sync.WaitGroup
+	// done is closed when Downloader is closed to stop waiting for retries.
+	done chan struct{}
+	// cache stores downloaded files for reuse. It is disabled if nil.
+	cache *Cache
+	retry RetryPolicy
+	// limiter enforces limits of concurrency and bandwidth of downloads.
+	limiter *limiter
+	// fetchers retrieve artifacts from sources identified by URI scheme.
+	fetchers map[string]Fetcher
+	// flights maps URIs to downloads which are queued or in progress, so that
+	// concurrent requests of the same URI share a single download.
+	flights      map[weles.ArtifactURI]*flight
+	flightsMutex sync.Mutex
 }
 
 // downloadJob provides necessary info for download to be done.
 type downloadJob struct {
-	path weles.ArtifactPath
+	path     weles.ArtifactPath
+	uri      weles.ArtifactURI
+	ch       chan weles.ArtifactStatusChange
+	priority weles.Priority
+	// seq is set by queue to order jobs of the same priority.
+	seq uint64
+	// ctx cancels the job. It may be nil if job is never canceled.
+	ctx context.Context
+	// flight is the download shared by the job.
+	flight *flight
+}
+
+// transfer holds state of a download shared between its attempts.
+type transfer struct {
+	// ctx aborts the transfer when it is canceled.
+	ctx  context.Context
 	uri  weles.ArtifactURI
-	ch   chan weles.ArtifactStatusChange
+	path weles.ArtifactPath
+	// offset is number of bytes already saved in path.
+	offset int64
+	// validator identifies version of the partially downloaded file.
+	// It is empty if fetcher does not support resuming.
+	validator string
+	// total is size of the file or -1 if it is unknown.
+	total int64
+	// etag and lastModified are returned by fetcher of the file.
+	etag         string
+	lastModified string
+	// progress is called periodically while data is received. It may be nil.
+	progress func(weles.ArtifactProgress)
+	// reported and reportedOffset describe the last progress notification.
+	reported       time.Time
+	reportedOffset int64
+}
+
+// progressInterval is minimal time between progress notifications of a transfer.
+var progressInterval = time.Second
+
+// reportProgress notifies about progress of the transfer if enough time passed
+// since the previous notification.
+func (t *transfer) reportProgress() {
+	if t.progress == nil {
+		return
+	}
+	now := time.Now()
+	elapsed := now.Sub(t.reported)
+	if elapsed < progressInterval {
+		return
+	}
+	p := weles.ArtifactProgress{Received: t.offset, Total: t.total}
+	if elapsed > 0 {
+		p.Rate = int64(float64(t.offset-t.reportedOffset) / elapsed.Seconds())
+	}
+	t.progress(p)
+	t.reported = now
+	t.reportedOffset = t.offset
 }
 
 // newDownloader returns initilized Downloader.
-func newDownloader(notification chan weles.ArtifactStatusChange, workers, queueSize int,
-) *Downloader {
+func newDownloader(notification chan weles.ArtifactStatusChange,
+	attempts chan weles.ArtifactAttempt, workers, queueSize int, cache *Cache, retry RetryPolicy,
+	limits LimitPolicy, client *http.Client, localRoot string) *Downloader {
 	d := &Downloader{
 		notification: notification,
-		queue:        make(chan downloadJob, queueSize),
+		attempts:     attempts,
+		queue:        newQueue(queueSize),
+		done:         make(chan struct{}),
+		cache:        cache,
+		retry:        retry,
+		limiter:      newLimiter(limits),
+		fetchers:     defaultFetchers(client, localRoot),
+		flights:      make(map[weles.ArtifactURI]*flight),
 	}
 
 	// Start all workers.
@@ -58,114 +137,347 @@ func newDownloader(notification chan weles.ArtifactStatusChange, workers, queueS
 	return d
 }
 
-// NewDownloader returns Downloader initialized  with default queue length
-func NewDownloader(notification chan weles.ArtifactStatusChange, workerCount, queueCap int,
-) *Downloader {
-	return newDownloader(notification, workerCount, queueCap)
+// NewDownloader returns Downloader initialized with initial queue capacity queueCap.
+// Downloaded files are reused from cache unless it is nil. Failed downloads are
+// retried according to retry policy and every attempt is sent to attempts channel
+// unless it is nil. Concurrency and bandwidth of downloads are limited according
+// to limits. HTTP(S) requests are sent with client or default HTTP client if it is nil.
+// Local files and git repositories are copied only from localRoot directory and
+// only if it is set.
+func NewDownloader(notification chan weles.ArtifactStatusChange,
+	attempts chan weles.ArtifactAttempt, workerCount, queueCap int, cache *Cache,
+	retry RetryPolicy, limits LimitPolicy, client *http.Client, localRoot string) *Downloader {
+	return newDownloader(notification, attempts, workerCount, queueCap, cache, retry, limits,
+		client, localRoot)
 }
 
 // Close is part of implementation of ArtifactDownloader interface.
 // It waits for running download jobs to stop and closes used channels.
 func (d *Downloader) Close() {
-	close(d.queue)
+	close(d.done)
+	d.queue.close()
 	d.wg.Wait()
 }
 
 // getData downloads file from provided location and saves it in a prepared path.
-func (d *Downloader) getData(URI weles.ArtifactURI, path weles.ArtifactPath) error {
-	resp, err := http.Get(string(URI))
+// If cache contains a valid copy of the file, it is used instead and cached is set.
+// Failed attempts are retried according to retry policy. Transfer is resumed
+// if server supports it. Progress of the transfer is reported with progress
+// function unless it is nil.
+func (d *Downloader) getData(URI weles.ArtifactURI, path weles.ArtifactPath,
+	progress func(weles.ArtifactProgress)) (cached bool, err error) {
+	return d.retrieve(&transfer{ctx: context.Background(), uri: URI, path: path,
+		progress: progress})
+}
+
+// retrieve runs attempts of the transfer until it succeeds, retry policy allows
+// no more attempts or the transfer is canceled.
+func (d *Downloader) retrieve(t *transfer) (cached bool, err error) {
+	for attempt := 1; ; attempt++ {
+		if err = t.ctx.Err(); err != nil {
+			return false, err
+		}
+		if t.validator == "" {
+			// Fetcher does not support resuming. Start from the beginning.
+			t.offset = 0
+		}
+		a := weles.ArtifactAttempt{
+			Path:      t.path,
+			Number:    attempt,
+			Timestamp: strfmt.DateTime(time.Now().UTC()),
+			Offset:    t.offset,
+		}
+		cached, err = d.try(t)
+		if err != nil {
+			a.Error = err.Error()
+		}
+		d.record(a)
+		if t.ctx.Err() != nil {
+			// Reading content fails when transfer is canceled.
+			return false, t.ctx.Err()
+		}
+		if err == nil || !isTemporary(err) || attempt >= d.retry.Attempts {
+			return cached, err
+		}
+		if !d.wait(t.ctx, d.retry.delay(attempt)) {
+			return false, err
+		}
+	}
+}
+
+// try makes single attempt of downloading file.
+func (d *Downloader) try(t *transfer) (cached bool, err error) {
+	// Cached file is useless if part of the file is already downloaded.
+	cached, err = d.fetch(t, d.cache != nil && t.offset == 0)
+	if err == ErrNotInCache {
+		// Entry was evicted after validation. Fall back to regular download.
+		return d.fetch(t, false)
+	}
+	return cached, err
+}
+
+// record sends attempt to attempts channel if it is set.
+func (d *Downloader) record(a weles.ArtifactAttempt) {
+	if d.attempts != nil {
+		d.attempts <- a
+	}
+}
+
+// wait sleeps for delay. It returns false if Downloader was closed or ctx was
+// canceled in the meantime.
+func (d *Downloader) wait(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-d.done:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// fetch downloads file from provided location using Fetcher selected by URI scheme.
+// If useCache is set, request is conditional and cached file is restored if fetcher
+// confirms it is up to date. If part of the file was downloaded by previous attempts,
+// only the remaining part is requested.
+func (d *Downloader) fetch(t *transfer, useCache bool) (cached bool, err error) {
+	f, err := d.fetcher(t.uri)
 	if err != nil {
-		return err
+		return false, err
+	}
+	req := FetchRequest{URI: t.uri, Context: t.ctx}
+	if useCache {
+		entry, _ := d.cache.lookup(t.uri)
+		req.ETag = entry.ETag
+		req.LastModified = entry.LastModified
+	}
+	if t.offset > 0 {
+		req.Offset = t.offset
+		req.Validator = t.validator
+	}
+
+	resp, err := f.Fetch(req)
+	if err != nil {
+		return false, err
+	}
+	t.etag, t.lastModified = resp.ETag, resp.LastModified
+	if resp.NotModified {
+		if t.etag == "" && t.lastModified == "" {
+			t.etag, t.lastModified = req.ETag, req.LastModified
+		}
+		return true, d.cache.restore(t.uri, t.path)
 	}
 
 	defer func() {
 		if erro := resp.Body.Close(); erro != nil {
-			log.Println("failed to close response body after downloading file from: "+string(URI),
-				erro.Error())
+			log.Println("failed to close response body after downloading file from: "+
+				RedactURI(t.uri), erro.Error())
 		}
 	}()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("while downloading: %v server returned %v status code, expected 200 ",
-			URI, resp.Status)
+	t.offset = resp.Offset
+	t.validator = resp.Validator
+	t.total = resp.Size
+	t.reported = time.Now()
+	t.reportedOffset = t.offset
+	err = saveData(&contextReader{ctx: t.ctx,
+		r: d.limiter.reader(t.ctx, hostOf(t.uri), resp.Body)}, t)
+	if err != nil {
+		return false, err
 	}
+	d.storeInCache(t.uri, t.path, resp.ETag, resp.LastModified)
+	return false, nil
+}
 
-	file, err := os.Create(string(path))
+// storeInCache adds downloaded file to cache if fetcher provided validators for it.
+func (d *Downloader) storeInCache(URI weles.ArtifactURI, path weles.ArtifactPath,
+	etag, lastModified string) {
+	if d.cache == nil {
+		return
+	}
+	if etag == "" && lastModified == "" {
+		return
+	}
+	if err := d.cache.store(URI, path, etag, lastModified); err != nil {
+		log.Println("failed to store file in cache: " + err.Error())
+	}
+}
+
+// saveData writes content to a file in prepared path starting at transfer's offset.
+// The offset is updated with every written chunk, so next attempt can resume the
+// transfer if reading content fails.
+func saveData(content io.Reader, t *transfer) error {
+	file, err := os.OpenFile(string(t.path), os.O_WRONLY|os.O_CREATE, 0644)
 	if err != nil {
 		return err
 	}
 
 	defer func() {
 		if erro := file.Close(); erro != nil {
-			log.Println("failed to close file: " + string(path) + " " + erro.Error())
+			log.Println("failed to close file: " + string(t.path) + " " + erro.Error())
 		}
 	}()
 
-	_, err = io.Copy(file, resp.Body)
+	if err = file.Truncate(t.offset); err != nil {
+		return err
+	}
+	if _, err = file.Seek(t.offset, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = io.Copy(&offsetWriter{w: file, t: t}, content)
+	if pe, ok := err.(*os.PathError); err != nil && !(ok && pe.Op == "write") {
+		// Reading content from the source failed.
+		return temporaryError{err}
+	}
 	return err
 }
 
-// download downloads artifact from provided URI and saves it to specified path.
-// It sends notification about status changes to two channels - Downloader's notification
-// channel, and other one, that can be specified passed as an argument.
-func (d *Downloader) download(URI weles.ArtifactURI, path weles.ArtifactPath,
-	ch chan weles.ArtifactStatusChange) {
+// contextReader stops reading from the underlying reader when ctx is canceled,
+// so that transfers are aborted regardless of fetcher.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
 
-	if path == "" {
-		return
+// Read is part of implementation of io.Reader interface.
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
 	}
+	return c.r.Read(p)
+}
 
-	change := weles.ArtifactStatusChange{
-		Path:      path,
-		NewStatus: weles.ArtifactStatusDOWNLOADING,
+// offsetWriter counts bytes written to the underlying writer and reports
+// progress of the transfer.
+type offsetWriter struct {
+	w io.Writer
+	t *transfer
+}
+
+// Write is part of implementation of io.Writer interface.
+func (o *offsetWriter) Write(p []byte) (int, error) {
+	n, err := o.w.Write(p)
+	o.t.offset += int64(n)
+	o.t.reportProgress()
+	return n, err
+}
+
+// download downloads artifact of the job and saves it to its path. Other members
+// of the job's flight get a copy of the file. Notifications about status changes are
+// sent to two channels of every member - Downloader's notification channel, and other
+// one, specified by the member.
+func (d *Downloader) download(job downloadJob) {
+	if job.path == "" {
+		return
+	}
+	f := job.flight
+	if f == nil {
+		f = newFlight(job)
+	}
+	if !d.start(f) {
+		// All members were canceled before download started.
+		return
 	}
-	channels := []chan weles.ArtifactStatusChange{ch, d.notification}
-	notify(change, channels)
 
-	err := d.getData(URI, path)
-	if err != nil {
-		if err = os.Remove(string(path)); err != nil {
-			log.Println("failed to remove an artifact: ", path, " due to: "+err.Error())
-		}
+	t := &transfer{ctx: f.ctx, uri: job.uri, path: job.path,
+		progress: func(p weles.ArtifactProgress) {
+			d.progress(f, weles.ArtifactStatusChange{
+				NewStatus: weles.ArtifactStatusDOWNLOADING,
+				Progress:  &p,
+			})
+		}}
+	cached, err := d.retrieve(t)
+	change := weles.ArtifactStatusChange{Path: job.path}
+	switch {
+	case err != nil:
+		removeArtifact(job.path)
 		change.NewStatus = weles.ArtifactStatusFAILED
-	} else {
+		if f.ctx.Err() != nil {
+			change.NewStatus = weles.ArtifactStatusCANCELED
+		}
+	default:
 		change.NewStatus = weles.ArtifactStatusREADY
+		change.Cached = cached
+		change.ETag = t.etag
+		change.LastModified = t.lastModified
 	}
-	notify(change, channels)
+	d.land(f, change)
 }
 
 // Download is part of implementation of ArtifactDownloader interface.
-// It puts new downloadJob on the queue.
-func (d *Downloader) Download(URI weles.ArtifactURI, path weles.ArtifactPath,
-	ch chan weles.ArtifactStatusChange) error {
-
-	channels := []chan weles.ArtifactStatusChange{ch, d.notification}
-	notify(weles.ArtifactStatusChange{Path: path, NewStatus: weles.ArtifactStatusPENDING}, channels)
+// It puts new downloadJob on the queue. Jobs are downloaded in order of priority
+// and then in order of submission. If the same URI is already queued or being
+// downloaded, the job waits for that download and gets a copy of its result.
+// Download still waiting for a worker gets priority of the most urgent job waiting for it.
+// Job is canceled with ctx: it is removed from the download, which is aborted if no
+// other job waits for it, its file is removed and its status is set to CANCELED.
+func (d *Downloader) Download(ctx context.Context, URI weles.ArtifactURI,
+	path weles.ArtifactPath, priority weles.Priority, ch chan weles.ArtifactStatusChange,
+) error {
 
 	job := downloadJob{
-		path: path,
-		uri:  URI,
-		ch:   ch,
+		path:     path,
+		uri:      URI,
+		ch:       ch,
+		priority: priority,
+		ctx:      ctx,
 	}
+	notify(weles.ArtifactStatusChange{Path: path, NewStatus: weles.ArtifactStatusPENDING},
+		d.channels(job))
 
-	select {
-	case d.queue <- job:
-	default:
-		return ErrQueueFull
+	if path == "" {
+		return d.queue.push(job)
 	}
-	return nil
+	f, created := d.join(job)
+	if !created {
+		d.raise(f, job)
+		return nil
+	}
+	job.flight = f
+	err := d.queue.push(job)
+	if err != nil {
+		// Caller of Download is notified with the error instead.
+		d.flightsMutex.Lock()
+		f.members[0].informed, f.members[0].finished = true, true
+		d.flightsMutex.Unlock()
+		d.land(f, weles.ArtifactStatusChange{NewStatus: weles.ArtifactStatusFAILED})
+	}
+	return err
 }
 
 func (d *Downloader) work() {
 	defer d.wg.Done()
-	for job := range d.queue {
-		d.download(job.uri, job.path, job.ch)
+	for {
+		job, ok := d.queue.pop()
+		if !ok {
+			return
+		}
+		host := hostOf(job.uri)
+		if !d.limiter.acquire(host, job) {
+			continue
+		}
+		// Worker keeps the slot of the host as long as there are jobs deferred by limiter.
+		for next := true; next; job, next = d.limiter.release(host) {
+			d.download(job)
+		}
 	}
 }
 
 // CheckInCache is part of implementation of ArtifactDownloader interface.
-// TODO implement.
+// It does not verify if cached file is still up to date.
 func (d *Downloader) CheckInCache(URI weles.ArtifactURI) (weles.ArtifactInfo, error) {
-	return weles.ArtifactInfo{}, weles.ErrNotImplemented
+	if d.cache == nil {
+		return weles.ArtifactInfo{}, ErrNotInCache
+	}
+	return d.cache.Info(URI)
+}
+
+// Invalidate is part of implementation of ArtifactDownloader interface.
+func (d *Downloader) Invalidate(URI weles.ArtifactURI) {
+	if d.cache != nil {
+		d.cache.invalidate(URI)
+	}
 }
 
 // notify sends ArtifactStatusChange to all specified channels.
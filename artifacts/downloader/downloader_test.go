@@ -17,12 +17,14 @@
 package downloader
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/SamsungSLAV/weles"
 	. "github.com/onsi/ginkgo"
@@ -70,7 +72,8 @@ I call it stupid of the pig.
 		var err error
 		// prepare Downloader.
 		notification = make(chan weles.ArtifactStatusChange, notifyCap)
-		platinumKoala = NewDownloader(notification, workersCount, queueCap)
+		platinumKoala = NewDownloader(notification, nil, workersCount, queueCap, nil,
+			RetryPolicy{}, LimitPolicy{}, nil, "")
 
 		// prepare temporary directories.
 		tmpDir, err = ioutil.TempDir("", "weles-")
@@ -115,7 +118,9 @@ I call it stupid of the pig.
 			}
 			filename := weles.ArtifactPath(filepath.Join(dir, "test"))
 
-			err := platinumKoala.getData(weles.ArtifactURI(ts.URL), weles.ArtifactPath(filename))
+			cached, err := platinumKoala.getData(weles.ArtifactURI(ts.URL),
+				weles.ArtifactPath(filename), nil)
+			Expect(cached).To(BeFalse())
 
 			if valid && url != invalidURL {
 				Expect(err).ToNot(HaveOccurred())
@@ -151,7 +156,7 @@ I call it stupid of the pig.
 				NewStatus: weles.ArtifactStatusDOWNLOADING,
 			}
 
-			platinumKoala.download(weles.ArtifactURI(ts.URL), weles.ArtifactPath(filename), ch)
+			platinumKoala.download(downloadJob{uri: weles.ArtifactURI(ts.URL), path: filename, ch: ch})
 
 			status.NewStatus = weles.ArtifactStatusDOWNLOADING
 			checkChannels(ch, platinumKoala.notification, status)
@@ -185,7 +190,8 @@ I call it stupid of the pig.
 			}
 			path := weles.ArtifactPath(filepath.Join(dir, "animal"))
 
-			err := platinumKoala.Download(weles.ArtifactURI(ts.URL), path, ch)
+			err := platinumKoala.Download(context.Background(), weles.ArtifactURI(ts.URL), path,
+				weles.MEDIUM, ch)
 			Expect(err).ToNot(HaveOccurred())
 
 			status := weles.ArtifactStatusChange{
@@ -213,7 +219,8 @@ I call it stupid of the pig.
 
 			path := weles.ArtifactPath(filepath.Join(validDir, filename))
 
-			err := platinumKoala.Download(weles.ArtifactURI(ts.URL), path, ch)
+			err := platinumKoala.Download(context.Background(), weles.ArtifactURI(ts.URL), path,
+				weles.MEDIUM, ch)
 			Expect(err).ToNot(HaveOccurred())
 
 			Eventually(ch).Should(Receive(Equal(weles.ArtifactStatusChange{
@@ -248,18 +255,68 @@ I call it stupid of the pig.
 		Entry("fail when url is invalid", invalidURL, "cows", nil),
 	)
 
+	Describe("progress", func() {
+		var interval time.Duration
+		BeforeEach(func() {
+			interval = progressInterval
+			progressInterval = 0
+		})
+		AfterEach(func() {
+			progressInterval = interval
+		})
+		It("should report received bytes and size of the file", func() {
+			ts = prepareServer(validURL)
+			defer ts.Close()
+			path := weles.ArtifactPath(filepath.Join(validDir, "pigs"))
+
+			var reported []weles.ArtifactProgress
+			_, err := platinumKoala.getData(weles.ArtifactURI(ts.URL), path,
+				func(p weles.ArtifactProgress) {
+					reported = append(reported, p)
+				})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(reported).NotTo(BeEmpty())
+			last := reported[len(reported)-1]
+			Expect(last.Received).To(BeEquivalentTo(len(pigs)))
+			Expect(last.Total).To(BeEquivalentTo(len(pigs)))
+		})
+		It("should notify channels about progress", func() {
+			ts = prepareServer(validURL)
+			defer ts.Close()
+			path := weles.ArtifactPath(filepath.Join(validDir, "pigs"))
+
+			platinumKoala.download(downloadJob{uri: weles.ArtifactURI(ts.URL), path: path, ch: ch})
+
+			Eventually(ch).Should(Receive(Equal(weles.ArtifactStatusChange{
+				Path:      path,
+				NewStatus: weles.ArtifactStatusDOWNLOADING,
+			})))
+			var change weles.ArtifactStatusChange
+			Eventually(ch).Should(Receive(&change))
+			Expect(change.NewStatus).To(Equal(weles.ArtifactStatusDOWNLOADING))
+			Expect(change.Progress).NotTo(BeNil())
+			Expect(change.Progress.Total).To(BeEquivalentTo(len(pigs)))
+		})
+	})
+
 	Describe("DownloadJob queue capacity", func() {
-		It("should return error if queue if full.", func() {
+		It("should queue jobs exceeding initial capacity.", func() {
 			ts = prepareServer(validURL)
 
 			notification := make(chan weles.ArtifactStatusChange, notifyCap)
-			ironGopher := newDownloader(notification, 0, 0)
+			ironGopher := newDownloader(notification, nil, 0, 0, nil, RetryPolicy{},
+				LimitPolicy{}, nil, "")
 			defer ironGopher.Close()
 
 			path := weles.ArtifactPath(filepath.Join(validDir, "file"))
 
-			err := ironGopher.Download(weles.ArtifactURI(ts.URL), path, ch)
-			Expect(err).To(Equal(ErrQueueFull))
+			for i := 0; i < 3; i++ {
+				uri := weles.ArtifactURI(fmt.Sprintf("%s/%d", ts.URL, i))
+				err := ironGopher.Download(context.Background(), uri, path, weles.LOW, ch)
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(ironGopher.queue.jobs).To(HaveLen(3))
 		})
 	})
 })
@@ -21,6 +21,16 @@ package downloader
 import "errors"
 
 var (
-	//ErrQueueFull is returned when download queue is full.
-	ErrQueueFull = errors.New("downlad queue is full")
+	// ErrClosed is returned when download is requested from closed Downloader.
+	ErrClosed = errors.New("downloader is closed")
+	// ErrNotInCache is returned when requested artifact is not available in cache.
+	ErrNotInCache = errors.New("artifact not found in cache")
+	// ErrUnsupportedScheme is returned when there is no Fetcher for URI scheme.
+	ErrUnsupportedScheme = errors.New("unsupported URI scheme")
+	// ErrInvalidGitSource is returned when git repository or ref identified by URI
+	// could be interpreted by git as an option or is not a valid ref name.
+	ErrInvalidGitSource = errors.New("invalid git repository or ref")
+	// ErrForbiddenPath is returned when local file is requested from outside
+	// of the directory of local artifacts.
+	ErrForbiddenPath = errors.New("path outside of directory of local artifacts")
 )
@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File fetcher.go provides interface of artifact sources.
+
+package downloader
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+// FetchRequest describes content requested from a Fetcher.
+type FetchRequest struct {
+	URI weles.ArtifactURI
+	// Offset is number of bytes already downloaded by previous attempts.
+	// Fetcher should return content starting at Offset if it is able to.
+	Offset int64
+	// Validator identifies version of the partially downloaded content.
+	// It is set only if Offset is greater than 0.
+	Validator string
+	// ETag and LastModified identify version of the content stored in cache.
+	// Fetcher may set NotModified in response if the content did not change.
+	ETag         string
+	LastModified string
+	// Context cancels fetching. Background context is used if it is nil.
+	Context context.Context
+}
+
+// context returns context of the request.
+func (r FetchRequest) context() context.Context {
+	if r.Context == nil {
+		return context.Background()
+	}
+	return r.Context
+}
+
+// FetchResponse contains content returned by a Fetcher.
+type FetchResponse struct {
+	// Body should be closed by the caller. It is nil if NotModified is set.
+	Body io.ReadCloser
+	// Offset is position in the file where Body starts. It is either 0 or Offset
+	// of the request if transfer is resumed.
+	Offset int64
+	// Size is size of the whole file or -1 if it is unknown.
+	Size int64
+	// Validator identifies version of the content. Transfer is not resumed
+	// if it is empty.
+	Validator string
+	// NotModified is set if content did not change since it was cached.
+	NotModified bool
+	// ETag and LastModified are used for caching the content. It is not cached
+	// if both are empty.
+	ETag         string
+	LastModified string
+}
+
+// Fetcher retrieves artifacts from a source identified by URI scheme.
+// Errors after which fetching may succeed when repeated should be marked
+// with Temporary.
+type Fetcher interface {
+	Fetch(req FetchRequest) (*FetchResponse, error)
+}
+
+// Temporary marks err as a failure after which fetching may be retried.
+func Temporary(err error) error {
+	return temporaryError{err}
+}
+
+// defaultFetchers returns fetchers supported by Downloader mapped by URI schemes.
+// Local files and git repositories are available only if localRoot is set and
+// only from localRoot directory.
+func defaultFetchers(client *http.Client, localRoot string) map[string]Fetcher {
+	hf := newHTTPFetcher(client)
+	gf := newGitFetcher("")
+	fetchers := map[string]Fetcher{
+		"http":      hf,
+		"https":     hf,
+		"s3":        newS3FetcherFromEnv(hf),
+		"git":       gf,
+		"git+http":  gf,
+		"git+https": gf,
+		"git+ssh":   gf,
+	}
+	if localRoot != "" {
+		fetchers["file"] = newFileFetcher(localRoot)
+		fetchers["git+file"] = newGitFetcher(localRoot)
+	}
+	return fetchers
+}
+
+// RegisterFetcher sets fetcher used for URIs with given scheme. It must not be
+// called while downloads are in progress.
+func (d *Downloader) RegisterFetcher(scheme string, f Fetcher) {
+	d.fetchers[scheme] = f
+}
+
+// fetcher returns Fetcher able to retrieve artifact from URI.
+func (d *Downloader) fetcher(URI weles.ArtifactURI) (Fetcher, error) {
+	u, err := url.Parse(string(URI))
+	if err != nil {
+		return nil, err
+	}
+	f, ok := d.fetchers[u.Scheme]
+	if !ok {
+		return nil, ErrUnsupportedScheme
+	}
+	return f, nil
+}
@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File filefetcher.go provides Fetcher copying artifacts from local or mounted
+// filesystems.
+
+package downloader
+
+import (
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// fileFetcher implements Fetcher interface for file URIs, e.g. file:///mnt/nfs/image.img
+// Only files located in root directory are available.
+type fileFetcher struct {
+	root string
+}
+
+// newFileFetcher returns fileFetcher copying files from root directory.
+func newFileFetcher(root string) *fileFetcher {
+	return &fileFetcher{root: root}
+}
+
+// Fetch is part of implementation of Fetcher interface. Transfer is resumed
+// if the file was not modified since previous attempt.
+func (f *fileFetcher) Fetch(r FetchRequest) (*FetchResponse, error) {
+	u, err := url.Parse(string(r.URI))
+	if err != nil {
+		return nil, err
+	}
+	path, err := localPath(f.root, u.Path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		closeFile(file)
+		return nil, err
+	}
+	ret := &FetchResponse{
+		Body: file,
+		Size: fi.Size(),
+		// Files on network filesystems may be replaced during transfer.
+		Validator: strconv.FormatInt(fi.ModTime().UnixNano(), 10) + "-" +
+			strconv.FormatInt(fi.Size(), 10),
+	}
+	if r.Offset > 0 && r.Offset <= fi.Size() && r.Validator == ret.Validator {
+		if _, err = file.Seek(r.Offset, io.SeekStart); err != nil {
+			closeFile(file)
+			return nil, err
+		}
+		ret.Offset = r.Offset
+	}
+	return ret, nil
+}
+
+// localPath returns path with symbolic links resolved. ErrForbiddenPath is
+// returned if the resolved path is not located in root directory.
+func localPath(root, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", ErrForbiddenPath
+	}
+	r, err := filepath.EvalSymlinks(root)
+	if err == nil {
+		r, err = filepath.Abs(r)
+	}
+	if err != nil {
+		return "", err
+	}
+	p, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(r, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", ErrForbiddenPath
+	}
+	return p, nil
+}
+
+// closeFile closes file which is not going to be read.
+func closeFile(f *os.File) {
+	if err := f.Close(); err != nil {
+		log.Println("failed to close file: " + err.Error())
+	}
+}
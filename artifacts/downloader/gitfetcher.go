@@ -0,0 +1,170 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File gitfetcher.go provides Fetcher packing git repositories into tarballs.
+
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+// gitDefaultRef is used if URI does not specify ref.
+const gitDefaultRef = "HEAD"
+
+// gitFetcher implements Fetcher interface for git repositories. URI identifies
+// repository and ref (branch, tag or commit) in its fragment, e.g.
+// git+https://example.com/repo.git#v1.0. Scheme prefix "git+" is stripped before
+// passing URL to git. Content of the ref is returned as tar.gz archive.
+type gitFetcher struct {
+	// git is path to git executable.
+	git string
+	// root is set if fetcher is used for local repositories. Only repositories
+	// located in root directory are available then.
+	root string
+}
+
+// newGitFetcher returns gitFetcher using git executable found in PATH. If root is
+// set, fetcher accepts only file URIs of repositories located in root directory.
+func newGitFetcher(root string) *gitFetcher {
+	return &gitFetcher{git: "git", root: root}
+}
+
+// Fetch is part of implementation of Fetcher interface. Only the requested ref
+// is fetched. Transfer is never resumed.
+func (f *gitFetcher) Fetch(r FetchRequest) (*FetchResponse, error) {
+	repo, ref, err := gitSource(r.URI)
+	if err != nil {
+		return nil, err
+	}
+	if f.root != "" {
+		if repo, err = f.localRepo(repo); err != nil {
+			return nil, err
+		}
+	}
+	ctx := r.context()
+	dir, err := ioutil.TempDir("", "weles-git-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if erro := os.RemoveAll(dir); erro != nil {
+			log.Println("failed to remove git repository: " + erro.Error())
+		}
+	}()
+
+	if err = f.run(ctx, dir, "check-ref-format", "--allow-onelevel", ref); err != nil {
+		return nil, ErrInvalidGitSource
+	}
+	if err = f.run(ctx, dir, "init", "-q"); err != nil {
+		return nil, err
+	}
+	if err = f.run(ctx, dir, "fetch", "-q", "--depth", "1", "--", repo, ref); err != nil {
+		// Repository may be temporarily unavailable.
+		return nil, Temporary(err)
+	}
+	archive, err := ioutil.TempFile("", "weles-git-archive-")
+	if err != nil {
+		return nil, err
+	}
+	body := &tempFile{File: archive}
+	err = f.run(ctx, dir, "archive", "--format=tar.gz", "-o", archive.Name(), "FETCH_HEAD")
+	if err == nil {
+		_, err = archive.Seek(0, 0)
+	}
+	var fi os.FileInfo
+	if err == nil {
+		fi, err = archive.Stat()
+	}
+	if err != nil {
+		if erro := body.Close(); erro != nil {
+			log.Println("failed to remove git archive: " + erro.Error())
+		}
+		return nil, err
+	}
+	return &FetchResponse{Body: body, Size: fi.Size()}, nil
+}
+
+// localRepo returns file URL of repository with symbolic links in its path resolved.
+// ErrForbiddenPath is returned if repository is not located in root directory.
+func (f *gitFetcher) localRepo(repo string) (string, error) {
+	u, err := url.Parse(repo)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", ErrForbiddenPath
+	}
+	path, err := localPath(f.root, u.Path)
+	if err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String(), nil
+}
+
+// run executes git command in dir. Command is killed when ctx is canceled.
+func (f *gitFetcher) run(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, f.git, args...) // nolint:gosec
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s failed: %v: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// gitSource returns URL of git repository and ref identified by URI. Values
+// starting with "-" are rejected, so that they are never taken for git options.
+func gitSource(URI weles.ArtifactURI) (repo, ref string, err error) {
+	u, err := url.Parse(string(URI))
+	if err != nil {
+		return "", "", err
+	}
+	ref = u.Fragment
+	if ref == "" {
+		ref = gitDefaultRef
+	}
+	u.Fragment = ""
+	u.Scheme = strings.TrimPrefix(u.Scheme, "git+")
+	repo = u.String()
+	if strings.HasPrefix(repo, "-") || strings.HasPrefix(ref, "-") {
+		return "", "", ErrInvalidGitSource
+	}
+	return repo, ref, nil
+}
+
+// tempFile is removed when it is closed.
+type tempFile struct {
+	*os.File
+}
+
+// Close is part of implementation of io.Closer interface.
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	if erro := os.Remove(f.Name()); err == nil {
+		err = erro
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File httpfetcher.go provides Fetcher downloading artifacts over HTTP(S).
+
+package downloader
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+// httpFetcher implements Fetcher interface for http and https URIs. It is also
+// used by fetchers of other sources available over HTTP.
+type httpFetcher struct {
+	client *http.Client
+	// resolve returns URL of the artifact. URI is used as URL if it is nil.
+	resolve func(URI weles.ArtifactURI) (string, error)
+	// sign adds authentication data to the request. It is not used if nil.
+	sign func(req *http.Request) error
+}
+
+// newHTTPFetcher returns httpFetcher using client or default HTTP client if it is nil.
+func newHTTPFetcher(client *http.Client) *httpFetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpFetcher{client: client}
+}
+
+// Fetch is part of implementation of Fetcher interface. Requests are conditional
+// if cached content is available and transfer is resumed with Range header.
+func (f *httpFetcher) Fetch(r FetchRequest) (*FetchResponse, error) {
+	URL := string(r.URI)
+	if f.resolve != nil {
+		var err error
+		if URL, err = f.resolve(r.URI); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(r.context())
+	if r.ETag != "" {
+		req.Header.Set("If-None-Match", r.ETag)
+	}
+	if r.LastModified != "" {
+		req.Header.Set("If-Modified-Since", r.LastModified)
+	}
+	if r.Offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", r.Offset))
+		req.Header.Set("If-Range", r.Validator)
+	}
+	if f.sign != nil {
+		if err = f.sign(req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, Temporary(err)
+	}
+
+	ret := &FetchResponse{
+		Body:         resp.Body,
+		Size:         -1,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotModified && (r.ETag != "" || r.LastModified != ""):
+		closeBody(resp)
+		ret.Body = nil
+		ret.NotModified = true
+		return ret, nil
+	case resp.StatusCode == http.StatusPartialContent && r.Offset > 0 &&
+		strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", r.Offset)):
+		// Server resumes the transfer.
+		ret.Offset = r.Offset
+		ret.Validator = r.Validator
+	case resp.StatusCode == http.StatusOK:
+		// Server sends the whole file, e.g. it was changed or resuming is not supported.
+		if resp.Header.Get("Accept-Ranges") == "bytes" {
+			ret.Validator = ret.ETag
+			if ret.Validator == "" {
+				ret.Validator = ret.LastModified
+			}
+		}
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && r.Offset > 0:
+		// Partially downloaded content is not valid anymore. Start from the beginning.
+		closeBody(resp)
+		r.Offset, r.Validator = 0, ""
+		return f.Fetch(r)
+	default:
+		closeBody(resp)
+		err = fmt.Errorf(
+			"while downloading: %v server returned %v status code, expected 200 ", RedactURI(r.URI),
+			resp.Status)
+		if retryableStatus(resp.StatusCode) {
+			return nil, Temporary(err)
+		}
+		return nil, err
+	}
+	if resp.ContentLength >= 0 {
+		ret.Size = ret.Offset + resp.ContentLength
+	}
+	return ret, nil
+}
+
+// closeBody closes body of the response which is not going to be read.
+func closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		log.Println("failed to close response body: " + err.Error())
+	}
+}
@@ -0,0 +1,249 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File limits.go provides limits of concurrency and bandwidth of downloads.
+
+package downloader
+
+import (
+	"container/heap"
+	"context"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+// LimitPolicy defines limits applied to downloads. Hosts are identified by host
+// part of artifact's URI, including port if present. Limits are not applied to
+// URIs without host, e.g. local files. Zero values mean no limit.
+type LimitPolicy struct {
+	// HostDownloads is maximum number of concurrent downloads from a single host.
+	HostDownloads int
+	// HostDownloadsOverrides overrides HostDownloads for given hosts.
+	HostDownloadsOverrides map[string]int
+	// Bandwidth is maximum total download rate in bytes per second.
+	Bandwidth int64
+	// HostBandwidth is maximum download rate from a single host in bytes per second.
+	HostBandwidth int64
+	// HostBandwidthOverrides overrides HostBandwidth for given hosts.
+	HostBandwidthOverrides map[string]int64
+}
+
+// hostDownloads returns maximum number of concurrent downloads from host.
+func (p LimitPolicy) hostDownloads(host string) int {
+	if n, ok := p.HostDownloadsOverrides[host]; ok {
+		return n
+	}
+	return p.HostDownloads
+}
+
+// hostBandwidth returns maximum download rate from host.
+func (p LimitPolicy) hostBandwidth(host string) int64 {
+	if n, ok := p.HostBandwidthOverrides[host]; ok {
+		return n
+	}
+	return p.HostBandwidth
+}
+
+// hostOf returns host part of URI or empty string if it has none.
+func hostOf(URI weles.ArtifactURI) string {
+	u, err := url.Parse(string(URI))
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// limiter enforces LimitPolicy on downloads run by Downloader's workers.
+type limiter struct {
+	policy LimitPolicy
+	mutex  sync.Mutex
+	// active counts running downloads per host.
+	active map[string]int
+	// deferred holds jobs waiting for a download from their host to finish.
+	deferred map[string]*jobHeap
+	// throttles limit bandwidth. Global one is nil if it is not limited.
+	throttle      *throttle
+	hostThrottles map[string]*throttle
+}
+
+// newLimiter returns limiter enforcing policy.
+func newLimiter(policy LimitPolicy) *limiter {
+	return &limiter{
+		policy:        policy,
+		active:        make(map[string]int),
+		deferred:      make(map[string]*jobHeap),
+		throttle:      newThrottle(policy.Bandwidth),
+		hostThrottles: make(map[string]*throttle),
+	}
+}
+
+// acquire reserves download slot for job from host. If limit of the host is
+// reached, job is deferred until one of running downloads from the host finishes
+// and false is returned.
+func (l *limiter) acquire(host string, job downloadJob) bool {
+	limit := l.policy.hostDownloads(host)
+	if host == "" || limit <= 0 {
+		return true
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.active[host] >= limit {
+		jobs, ok := l.deferred[host]
+		if !ok {
+			jobs = new(jobHeap)
+			l.deferred[host] = jobs
+		}
+		heap.Push(jobs, job)
+		return false
+	}
+	l.active[host]++
+	return true
+}
+
+// release frees download slot of host. If there are deferred jobs from the host,
+// the slot is passed to the most urgent of them, which is returned.
+func (l *limiter) release(host string) (downloadJob, bool) {
+	limit := l.policy.hostDownloads(host)
+	if host == "" || limit <= 0 {
+		return downloadJob{}, false
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if jobs, ok := l.deferred[host]; ok {
+		job := heap.Pop(jobs).(downloadJob)
+		if jobs.Len() == 0 {
+			delete(l.deferred, host)
+		}
+		return job, true
+	}
+	l.active[host]--
+	if l.active[host] == 0 {
+		delete(l.active, host)
+	}
+	return downloadJob{}, false
+}
+
+// raise increases priority of deferred job of flight f from host to p if p is
+// more urgent.
+func (l *limiter) raise(host string, f *flight, p weles.Priority) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if jobs, ok := l.deferred[host]; ok {
+		jobs.raise(f, p)
+	}
+}
+
+// reader returns reader of data downloaded from host limited to allowed bandwidth.
+// Waiting for bandwidth is interrupted when ctx is done.
+func (l *limiter) reader(ctx context.Context, host string, r io.Reader) io.Reader {
+	var throttles []*throttle
+	if l.throttle != nil {
+		throttles = append(throttles, l.throttle)
+	}
+	if host != "" {
+		if t := l.hostThrottle(host); t != nil {
+			throttles = append(throttles, t)
+		}
+	}
+	if len(throttles) == 0 {
+		return r
+	}
+	return &throttledReader{ctx: ctx, r: r, throttles: throttles}
+}
+
+// hostThrottle returns throttle shared by downloads from host or nil if bandwidth
+// of the host is not limited.
+func (l *limiter) hostThrottle(host string) *throttle {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	t, ok := l.hostThrottles[host]
+	if !ok {
+		t = newThrottle(l.policy.hostBandwidth(host))
+		l.hostThrottles[host] = t
+	}
+	return t
+}
+
+// throttle schedules transfers of data, so that their total rate does not exceed limit.
+type throttle struct {
+	// rate is limit in bytes per second.
+	rate  int64
+	mutex sync.Mutex
+	// next is time when the next transfer may start.
+	next time.Time
+}
+
+// newThrottle returns throttle limiting rate to given bytes per second or nil if
+// rate is not positive.
+func newThrottle(rate int64) *throttle {
+	if rate <= 0 {
+		return nil
+	}
+	return &throttle{rate: rate}
+}
+
+// reserve schedules transfer of n bytes. It returns time to wait before the transfer.
+func (t *throttle) reserve(n int) time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	start := t.next
+	t.next = t.next.Add(time.Duration(int64(n) * int64(time.Second) / t.rate))
+	return start.Sub(now)
+}
+
+// throttledChunk is maximum number of bytes read at once by throttledReader, so that
+// single read does not hold throttles for long.
+const throttledChunk = 32 << 10
+
+// throttledReader limits rate of reading from the underlying reader.
+type throttledReader struct {
+	ctx       context.Context
+	r         io.Reader
+	throttles []*throttle
+}
+
+// Read is part of implementation of io.Reader interface.
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > throttledChunk {
+		p = p[:throttledChunk]
+	}
+	n, err := t.r.Read(p)
+	var wait time.Duration
+	for _, th := range t.throttles {
+		if d := th.reserve(n); d > wait {
+			wait = d
+		}
+	}
+	if wait <= 0 {
+		return n, err
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-t.ctx.Done():
+		return n, t.ctx.Err()
+	case <-timer.C:
+		return n, err
+	}
+}
@@ -0,0 +1,215 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SamsungSLAV/weles"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Limits", func() {
+
+	Describe("limiter", func() {
+		policy := LimitPolicy{
+			HostDownloads:          1,
+			HostDownloadsOverrides: map[string]int{"example.com:8080": 2},
+		}
+		job := func(n int) downloadJob {
+			return downloadJob{path: weles.ArtifactPath(fmt.Sprintf("path%d", n))}
+		}
+
+		It("should defer jobs exceeding limit of host", func() {
+			l := newLimiter(policy)
+			Expect(l.acquire("example.com", job(1))).To(BeTrue())
+			Expect(l.acquire("example.com", job(2))).To(BeFalse())
+			Expect(l.acquire("example.com", job(3))).To(BeFalse())
+			Expect(l.acquire("example.org", job(4))).To(BeTrue())
+
+			j, ok := l.release("example.com")
+			Expect(ok).To(BeTrue())
+			Expect(j).To(Equal(job(2)))
+			j, ok = l.release("example.com")
+			Expect(ok).To(BeTrue())
+			Expect(j).To(Equal(job(3)))
+			_, ok = l.release("example.com")
+			Expect(ok).To(BeFalse())
+			Expect(l.acquire("example.com", job(5))).To(BeTrue())
+		})
+
+		It("should use limit overriden for host", func() {
+			l := newLimiter(policy)
+			Expect(l.acquire("example.com:8080", job(1))).To(BeTrue())
+			Expect(l.acquire("example.com:8080", job(2))).To(BeTrue())
+			Expect(l.acquire("example.com:8080", job(3))).To(BeFalse())
+		})
+
+		It("should not limit downloads without host", func() {
+			l := newLimiter(policy)
+			for i := 0; i < 3; i++ {
+				Expect(l.acquire(hostOf("file:///tmp/file"), job(i))).To(BeTrue())
+			}
+		})
+	})
+
+	Describe("throttle", func() {
+		It("should schedule transfers according to rate", func() {
+			t := newThrottle(1000)
+			Expect(t.reserve(1000)).To(BeNumerically("<=", 0))
+			Expect(t.reserve(500)).To(BeNumerically("~", time.Second, 100*time.Millisecond))
+			Expect(t.reserve(1)).To(BeNumerically("~", 1500*time.Millisecond,
+				100*time.Millisecond))
+		})
+
+		It("should not limit if rate is not positive", func() {
+			Expect(newThrottle(0)).To(BeNil())
+			l := newLimiter(LimitPolicy{})
+			r := strings.NewReader("Oh, the thinks you can think!")
+			Expect(l.reader(context.Background(), "example.com", r)).To(BeIdenticalTo(r))
+		})
+
+		It("should limit rate of reading", func() {
+			l := newLimiter(LimitPolicy{HostBandwidth: 1 << 20})
+			data := bytes.Repeat([]byte("x"), 256<<10)
+			start := time.Now()
+			read, err := ioutil.ReadAll(l.reader(context.Background(), "example.com",
+				bytes.NewReader(data)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(read).To(Equal(data))
+			// First chunk is read immediately, the rest at 1 MiB/s.
+			Expect(time.Since(start)).To(BeNumerically(">=", 200*time.Millisecond))
+		})
+
+		It("should stop waiting for bandwidth when context is done", func() {
+			l := newLimiter(LimitPolicy{HostBandwidth: 1 << 10})
+			data := bytes.Repeat([]byte("x"), 64<<10)
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+			start := time.Now()
+			_, err := ioutil.ReadAll(l.reader(ctx, "example.com", bytes.NewReader(data)))
+			Expect(err).To(Equal(context.Canceled))
+			// Reading at 1 KiB/s would take about a minute.
+			Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+		})
+	})
+
+	Describe("Downloader", func() {
+		const content = "And will you succeed? Yes! You will, indeed!"
+
+		var (
+			tmpDir        string
+			ch            chan weles.ArtifactStatusChange
+			release       chan struct{}
+			mutex         sync.Mutex
+			active        map[string]int
+			maxActive     map[string]int
+			servers       []*httptest.Server
+			platinumMoose *Downloader
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "weles-")
+			Expect(err).ToNot(HaveOccurred())
+			ch = make(chan weles.ArtifactStatusChange, 100)
+			release = make(chan struct{})
+			active = make(map[string]int)
+			maxActive = make(map[string]int)
+			platinumMoose = NewDownloader(make(chan weles.ArtifactStatusChange, 100), nil, 4, 10,
+				nil, RetryPolicy{}, LimitPolicy{HostDownloads: 2}, nil, "")
+		})
+
+		AfterEach(func() {
+			platinumMoose.Close()
+			for _, ts := range servers {
+				ts.Close()
+			}
+			servers = nil
+			Expect(os.RemoveAll(tmpDir)).To(Succeed())
+		})
+
+		serve := func(name string) string {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				active[name]++
+				if active[name] > maxActive[name] {
+					maxActive[name] = active[name]
+				}
+				mutex.Unlock()
+				<-release
+				fmt.Fprint(w, content)
+				mutex.Lock()
+				active[name]--
+				mutex.Unlock()
+			}))
+			servers = append(servers, ts)
+			return ts.URL
+		}
+
+		activeOf := func(name string) func() int {
+			return func() int {
+				mutex.Lock()
+				defer mutex.Unlock()
+				return active[name]
+			}
+		}
+
+		It("should not exceed download limit of host", func() {
+			busy := serve("busy")
+			idle := serve("idle")
+			for i := 0; i < 4; i++ {
+				path := weles.ArtifactPath(filepath.Join(tmpDir, fmt.Sprintf("busy%d", i)))
+				uri := weles.ArtifactURI(fmt.Sprintf("%s/%d", busy, i))
+				Expect(platinumMoose.Download(context.Background(), uri, path, weles.MEDIUM, ch)).
+					To(Succeed())
+			}
+			Eventually(activeOf("busy")).Should(Equal(2))
+			By("Deferred downloads should not block downloads from other hosts")
+			path := weles.ArtifactPath(filepath.Join(tmpDir, "idle"))
+			Expect(platinumMoose.Download(context.Background(), weles.ArtifactURI(idle), path,
+				weles.MEDIUM, ch)).To(Succeed())
+			Eventually(activeOf("idle")).Should(Equal(1))
+			Consistently(activeOf("busy")).Should(Equal(2))
+
+			close(release)
+			ready := 0
+			for ready < 5 {
+				var change weles.ArtifactStatusChange
+				Eventually(ch).Should(Receive(&change))
+				Expect(change.NewStatus).ToNot(Equal(weles.ArtifactStatusFAILED))
+				if change.NewStatus == weles.ArtifactStatusREADY {
+					ready++
+				}
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			Expect(maxActive["busy"]).To(Equal(2))
+		})
+	})
+})
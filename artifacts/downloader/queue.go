@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File queue.go provides priority queue of download jobs.
+
+package downloader
+
+import (
+	"container/heap"
+	"sync"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+// rank returns position of priority in order of processing jobs. Jobs with unknown
+// priority are processed as medium priority ones.
+func rank(p weles.Priority) int {
+	switch p {
+	case weles.HIGH:
+		return 0
+	case weles.LOW:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// jobHeap orders download jobs by priority and then by order of submission.
+// It implements heap.Interface.
+type jobHeap []downloadJob
+
+// Len is part of implementation of sort.Interface.
+func (h jobHeap) Len() int { return len(h) }
+
+// Less is part of implementation of sort.Interface.
+func (h jobHeap) Less(i, j int) bool {
+	ri, rj := rank(h[i].priority), rank(h[j].priority)
+	if ri != rj {
+		return ri < rj
+	}
+	return h[i].seq < h[j].seq
+}
+
+// Swap is part of implementation of sort.Interface.
+func (h jobHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Push is part of implementation of heap.Interface.
+func (h *jobHeap) Push(x interface{}) { *h = append(*h, x.(downloadJob)) }
+
+// Pop is part of implementation of heap.Interface.
+func (h *jobHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	job := old[n-1]
+	*h = old[:n-1]
+	return job
+}
+
+// raise increases priority of job of flight f to p if p is more urgent.
+func (h *jobHeap) raise(f *flight, p weles.Priority) {
+	for i, job := range *h {
+		if job.flight != f {
+			continue
+		}
+		if rank(p) < rank(job.priority) {
+			(*h)[i].priority = p
+			heap.Fix(h, i)
+		}
+		return
+	}
+}
+
+// queue is unbounded priority queue of download jobs waiting for workers.
+type queue struct {
+	mutex sync.Mutex
+	// available is signalled when a job is pushed or queue is closed.
+	available *sync.Cond
+	jobs      jobHeap
+	// seq is number of jobs pushed so far. It orders jobs of the same priority.
+	seq    uint64
+	closed bool
+}
+
+// newQueue returns empty queue with room for capacity jobs. It grows when needed.
+func newQueue(capacity int) *queue {
+	q := &queue{jobs: make(jobHeap, 0, capacity)}
+	q.available = sync.NewCond(&q.mutex)
+	return q
+}
+
+// push adds job to the queue. It fails only if the queue is closed.
+func (q *queue) push(job downloadJob) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	q.seq++
+	job.seq = q.seq
+	heap.Push(&q.jobs, job)
+	q.available.Signal()
+	return nil
+}
+
+// pop removes the most urgent job from the queue. It blocks until a job is available.
+// Jobs left in closed queue are still returned. False is returned when the queue
+// is closed and empty.
+func (q *queue) pop() (downloadJob, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for len(q.jobs) == 0 && !q.closed {
+		q.available.Wait()
+	}
+	if len(q.jobs) == 0 {
+		return downloadJob{}, false
+	}
+	return heap.Pop(&q.jobs).(downloadJob), true
+}
+
+// raise increases priority of queued job of flight f to p if p is more urgent.
+func (q *queue) raise(f *flight, p weles.Priority) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.jobs.raise(f, p)
+}
+
+// close stops accepting new jobs and wakes up all workers waiting for jobs.
+func (q *queue) close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.closed = true
+	q.available.Broadcast()
+}
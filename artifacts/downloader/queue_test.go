@@ -0,0 +1,213 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/SamsungSLAV/weles"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Queue", func() {
+
+	job := func(p weles.Priority, path string) downloadJob {
+		return downloadJob{priority: p, path: weles.ArtifactPath(path)}
+	}
+
+	popPaths := func(q *queue, n int) []weles.ArtifactPath {
+		paths := make([]weles.ArtifactPath, n)
+		for i := range paths {
+			j, ok := q.pop()
+			ExpectWithOffset(1, ok).To(BeTrue())
+			paths[i] = j.path
+		}
+		return paths
+	}
+
+	It("should order jobs by priority and submission", func() {
+		q := newQueue(1)
+		for _, j := range []downloadJob{
+			job(weles.LOW, "low1"),
+			job(weles.MEDIUM, "medium1"),
+			job(weles.HIGH, "high1"),
+			job(weles.LOW, "low2"),
+			job("", "unknown"),
+			job(weles.HIGH, "high2"),
+			job(weles.MEDIUM, "medium2"),
+		} {
+			Expect(q.push(j)).To(Succeed())
+		}
+		Expect(popPaths(q, 7)).To(Equal([]weles.ArtifactPath{
+			"high1", "high2", "medium1", "unknown", "medium2", "low1", "low2",
+		}))
+	})
+
+	It("should raise priority of queued job of a flight", func() {
+		q := newQueue(0)
+		shared := newFlight(job(weles.LOW, "shared"))
+		low := job(weles.LOW, "shared")
+		low.flight = shared
+		for _, j := range []downloadJob{low, job(weles.MEDIUM, "medium"),
+			job(weles.HIGH, "high")} {
+			Expect(q.push(j)).To(Succeed())
+		}
+
+		q.raise(shared, weles.MEDIUM)
+		q.raise(shared, weles.LOW)
+		// Raised job keeps its order of submission.
+		Expect(popPaths(q, 3)).To(Equal([]weles.ArtifactPath{"high", "shared", "medium"}))
+	})
+
+	It("should block until job is available", func() {
+		q := newQueue(0)
+		popped := make(chan weles.ArtifactPath)
+		go func() {
+			defer GinkgoRecover()
+			j, ok := q.pop()
+			Expect(ok).To(BeTrue())
+			popped <- j.path
+		}()
+		Consistently(popped).ShouldNot(Receive())
+		Expect(q.push(job(weles.LOW, "late"))).To(Succeed())
+		Eventually(popped).Should(Receive(Equal(weles.ArtifactPath("late"))))
+	})
+
+	It("should return queued jobs after close and then stop", func() {
+		q := newQueue(0)
+		Expect(q.push(job(weles.LOW, "left"))).To(Succeed())
+		q.close()
+		Expect(q.push(job(weles.HIGH, "rejected"))).To(Equal(ErrClosed))
+		Expect(popPaths(q, 1)).To(Equal([]weles.ArtifactPath{"left"}))
+		_, ok := q.pop()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should wake up waiting workers on close", func() {
+		q := newQueue(0)
+		stopped := make(chan bool)
+		go func() {
+			_, ok := q.pop()
+			stopped <- ok
+		}()
+		q.close()
+		Eventually(stopped).Should(Receive(BeFalse()))
+	})
+
+	Describe("Downloader", func() {
+		const content = "Congratulations! Today is your day."
+
+		var (
+			tmpDir       string
+			ch           chan weles.ArtifactStatusChange
+			release      chan struct{}
+			ts           *httptest.Server
+			bronzeBeaver *Downloader
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "weles-")
+			Expect(err).ToNot(HaveOccurred())
+			ch = make(chan weles.ArtifactStatusChange, 100)
+			release = make(chan struct{})
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-release
+				fmt.Fprint(w, content)
+			}))
+			bronzeBeaver = NewDownloader(make(chan weles.ArtifactStatusChange, 100), nil, 1, 1,
+				nil, RetryPolicy{}, LimitPolicy{}, nil, "")
+		})
+
+		AfterEach(func() {
+			bronzeBeaver.Close()
+			ts.Close()
+			Expect(os.RemoveAll(tmpDir)).To(Succeed())
+		})
+
+		download := func(p weles.Priority, name string) weles.ArtifactPath {
+			path := weles.ArtifactPath(filepath.Join(tmpDir, name))
+			ExpectWithOffset(1, bronzeBeaver.Download(context.Background(),
+				weles.ArtifactURI(ts.URL+"/"+name), path, p, ch)).To(Succeed())
+			return path
+		}
+
+		It("should download urgent artifacts first without failing when busy", func() {
+			first := download(weles.LOW, "first")
+			Eventually(ch).Should(Receive(Equal(weles.ArtifactStatusChange{
+				Path: first, NewStatus: weles.ArtifactStatusPENDING})))
+			Eventually(ch).Should(Receive(Equal(weles.ArtifactStatusChange{
+				Path: first, NewStatus: weles.ArtifactStatusDOWNLOADING})))
+
+			expected := []weles.ArtifactPath{}
+			for _, p := range []weles.Priority{weles.LOW, weles.MEDIUM, weles.HIGH} {
+				expected = append([]weles.ArtifactPath{download(p, string(p))}, expected...)
+			}
+			close(release)
+
+			started := []weles.ArtifactPath{}
+			for len(started) < len(expected) {
+				var change weles.ArtifactStatusChange
+				Eventually(ch).Should(Receive(&change))
+				Expect(change.NewStatus).ToNot(Equal(weles.ArtifactStatusFAILED))
+				if change.NewStatus == weles.ArtifactStatusDOWNLOADING &&
+					change.Path != first && change.Progress == nil {
+					started = append(started, change.Path)
+				}
+			}
+			Expect(started).To(Equal(expected))
+		})
+
+		It("should download artifact joined by urgent job before less urgent ones", func() {
+			first := download(weles.LOW, "first")
+			Eventually(ch).Should(Receive(Equal(weles.ArtifactStatusChange{
+				Path: first, NewStatus: weles.ArtifactStatusPENDING})))
+			Eventually(ch).Should(Receive(Equal(weles.ArtifactStatusChange{
+				Path: first, NewStatus: weles.ArtifactStatusDOWNLOADING})))
+
+			download(weles.MEDIUM, "medium")
+			shared := download(weles.LOW, "shared")
+			urgent := weles.ArtifactPath(filepath.Join(tmpDir, "urgent"))
+			Expect(bronzeBeaver.Download(context.Background(),
+				weles.ArtifactURI(ts.URL+"/shared"), urgent, weles.HIGH, ch)).To(Succeed())
+			close(release)
+
+			var change weles.ArtifactStatusChange
+			for change.NewStatus != weles.ArtifactStatusDOWNLOADING || change.Path == first {
+				Eventually(ch).Should(Receive(&change))
+			}
+			Expect(change.Path).To(Equal(shared))
+		})
+
+		It("should fail after Downloader is closed", func() {
+			closed := NewDownloader(make(chan weles.ArtifactStatusChange, 100), nil, 1, 1,
+				nil, RetryPolicy{}, LimitPolicy{}, nil, "")
+			closed.Close()
+			path := weles.ArtifactPath(filepath.Join(tmpDir, "closed"))
+			Expect(closed.Download(context.Background(), weles.ArtifactURI(ts.URL), path, weles.HIGH,
+				ch)).
+				To(Equal(ErrClosed))
+		})
+	})
+})
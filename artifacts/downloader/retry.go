@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File retry.go provides policy of retrying failed downloads.
+
+package downloader
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy defines how failed downloads are retried. Delay between attempts
+// grows exponentially from InitialDelay up to MaxDelay and is randomly reduced
+// by up to Jitter fraction of it, so downloads failed at the same time are not
+// retried all at once.
+type RetryPolicy struct {
+	// Attempts is maximum number of download attempts. Values lower than 2
+	// disable retrying.
+	Attempts     int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	// Jitter should be in range [0, 1].
+	Jitter float64
+}
+
+// DefaultRetryPolicy is used by Weles unless configured otherwise.
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:     5,
+	InitialDelay: time.Second,
+	MaxDelay:     time.Minute,
+	Jitter:       0.5,
+}
+
+// delay returns time to wait after failed attempt (counted from 1).
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.InitialDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	// Jitter does not need cryptographically secure random numbers.
+	return d - time.Duration(rand.Float64()*p.Jitter*float64(d)) // nolint:gosec
+}
+
+// temporaryError marks failures after which download may be retried.
+type temporaryError struct {
+	error
+}
+
+// isTemporary returns true if download failed with err may be retried.
+func isTemporary(err error) bool {
+	_, ok := err.(temporaryError)
+	return ok
+}
+
+// retryableStatus returns true if request failed with status code may succeed
+// when repeated.
+func retryableStatus(code int) bool {
+	return code >= http.StatusInternalServerError || code == http.StatusRequestTimeout ||
+		code == http.StatusTooManyRequests
+}
@@ -0,0 +1,206 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package downloader
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/SamsungSLAV/weles"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retries", func() {
+
+	const content = "Today you are You, that is truer than true."
+	const etag = `"you"`
+
+	var (
+		tmpDir       string
+		path         weles.ArtifactPath
+		requests     int32
+		attempts     chan weles.ArtifactAttempt
+		silverWombat *Downloader
+		servers      []*httptest.Server
+		policy       = RetryPolicy{
+			Attempts:     3,
+			InitialDelay: time.Millisecond,
+			MaxDelay:     time.Millisecond,
+		}
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "weles-")
+		Expect(err).ToNot(HaveOccurred())
+		path = weles.ArtifactPath(filepath.Join(tmpDir, "file"))
+		atomic.StoreInt32(&requests, 0)
+		attempts = make(chan weles.ArtifactAttempt, 10)
+		silverWombat = NewDownloader(make(chan weles.ArtifactStatusChange, 10), attempts, 1, 10,
+			nil, policy, LimitPolicy{}, nil, "")
+	})
+
+	AfterEach(func() {
+		silverWombat.Close()
+		for _, ts := range servers {
+			ts.Close()
+		}
+		servers = nil
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	serve := func(handler func(n int32, w http.ResponseWriter, r *http.Request)) string {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler(atomic.AddInt32(&requests, 1), w, r)
+		}))
+		servers = append(servers, ts)
+		return ts.URL
+	}
+
+	receiveAttempts := func(n int) []weles.ArtifactAttempt {
+		ret := make([]weles.ArtifactAttempt, n)
+		for i := range ret {
+			EventuallyWithOffset(1, attempts).Should(Receive(&ret[i]))
+		}
+		ConsistentlyWithOffset(1, attempts).ShouldNot(Receive())
+		return ret
+	}
+
+	It("should retry after temporary server failure", func() {
+		URL := serve(func(n int32, w http.ResponseWriter, r *http.Request) {
+			if n == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, content)
+		})
+
+		_, err := silverWombat.getData(weles.ArtifactURI(URL), path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		data, err := ioutil.ReadFile(string(path))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(content))
+
+		history := receiveAttempts(2)
+		Expect(history[0].Number).To(Equal(1))
+		Expect(history[0].Path).To(Equal(path))
+		Expect(history[0].Error).To(ContainSubstring("503"))
+		Expect(history[1].Number).To(Equal(2))
+		Expect(history[1].Error).To(BeEmpty())
+	})
+
+	It("should not retry permanent failure", func() {
+		URL := serve(func(n int32, w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		_, err := silverWombat.getData(weles.ArtifactURI(URL), path, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(atomic.LoadInt32(&requests)).To(BeEquivalentTo(1))
+		Expect(receiveAttempts(1)[0].Error).To(ContainSubstring("404"))
+	})
+
+	It("should give up after maximum number of attempts", func() {
+		URL := serve(func(n int32, w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		_, err := silverWombat.getData(weles.ArtifactURI(URL), path, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(atomic.LoadInt32(&requests)).To(BeEquivalentTo(policy.Attempts))
+		receiveAttempts(policy.Attempts)
+	})
+
+	It("should resume interrupted transfer", func() {
+		half := len(content) / 2
+		URL := serve(func(n int32, w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Accept-Ranges", "bytes")
+			if n == 1 {
+				// Send only half of the file and break the connection.
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				fmt.Fprint(w, content[:half])
+				w.(http.Flusher).Flush()
+				return
+			}
+			Expect(r.Header.Get("Range")).To(Equal(fmt.Sprintf("bytes=%d-", half)))
+			Expect(r.Header.Get("If-Range")).To(Equal(etag))
+			w.Header().Set("Content-Range",
+				fmt.Sprintf("bytes %d-%d/%d", half, len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			fmt.Fprint(w, content[half:])
+		})
+
+		_, err := silverWombat.getData(weles.ArtifactURI(URL), path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		data, err := ioutil.ReadFile(string(path))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(content))
+
+		history := receiveAttempts(2)
+		Expect(history[0].Error).ToNot(BeEmpty())
+		Expect(history[1].Offset).To(BeEquivalentTo(half))
+	})
+
+	It("should start from the beginning if server sends the whole file", func() {
+		half := len(content) / 2
+		URL := serve(func(n int32, w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			if n == 1 {
+				fmt.Fprint(w, content[:half])
+				w.(http.Flusher).Flush()
+				return
+			}
+			fmt.Fprint(w, content)
+		})
+
+		_, err := silverWombat.getData(weles.ArtifactURI(URL), path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		data, err := ioutil.ReadFile(string(path))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(content))
+	})
+
+	Describe("RetryPolicy", func() {
+		It("should double delay up to the limit", func() {
+			p := RetryPolicy{InitialDelay: time.Second, MaxDelay: 5 * time.Second}
+			Expect(p.delay(1)).To(Equal(time.Second))
+			Expect(p.delay(2)).To(Equal(2 * time.Second))
+			Expect(p.delay(3)).To(Equal(4 * time.Second))
+			Expect(p.delay(4)).To(Equal(5 * time.Second))
+			Expect(p.delay(100)).To(Equal(5 * time.Second))
+		})
+		It("should reduce delay by jitter", func() {
+			p := RetryPolicy{InitialDelay: time.Second, MaxDelay: time.Second, Jitter: 0.5}
+			for i := 0; i < 10; i++ {
+				Expect(p.delay(1)).To(And(
+					BeNumerically(">=", 500*time.Millisecond),
+					BeNumerically("<=", time.Second)))
+			}
+		})
+	})
+})
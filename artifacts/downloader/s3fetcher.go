@@ -0,0 +1,190 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File s3fetcher.go provides Fetcher downloading artifacts from S3-compatible
+// object stores.
+
+package downloader
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+const (
+	s3DefaultRegion = "us-east-1"
+	// s3EmptyPayloadHash is SHA256 of empty body of GET requests.
+	s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	s3TimeFormat       = "20060102T150405Z"
+)
+
+// s3Fetcher retrieves objects identified by s3://bucket/key URIs. Objects are
+// addressed path-style, so any S3-compatible store can be used. Requests are
+// signed with AWS Signature Version 4 if credentials are set.
+type s3Fetcher struct {
+	endpoint     string
+	region       string
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	// now returns current time. It is replaced in tests.
+	now func() time.Time
+}
+
+// newS3FetcherFromEnv returns Fetcher configured with standard AWS environment
+// variables: AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL, AWS_REGION, AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN. Requests are sent with client
+// of hf.
+func newS3FetcherFromEnv(hf *httpFetcher) *httpFetcher {
+	s := &s3Fetcher{
+		endpoint:     os.Getenv("AWS_ENDPOINT_URL_S3"),
+		region:       os.Getenv("AWS_REGION"),
+		accessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		now:          time.Now,
+	}
+	if s.endpoint == "" {
+		s.endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+	return s.fetcher(hf.client)
+}
+
+// fetcher returns httpFetcher retrieving objects from the store with client.
+func (s *s3Fetcher) fetcher(client *http.Client) *httpFetcher {
+	if s.region == "" {
+		s.region = s3DefaultRegion
+	}
+	if s.endpoint == "" {
+		s.endpoint = "https://s3." + s.region + ".amazonaws.com"
+	}
+	f := &httpFetcher{client: client, resolve: s.url}
+	if s.accessKey != "" {
+		f.sign = s.sign
+	}
+	return f
+}
+
+// url returns URL of the object identified by URI.
+func (s *s3Fetcher) url(URI weles.ArtifactURI) (string, error) {
+	u, err := url.Parse(string(URI))
+	if err != nil {
+		return "", err
+	}
+	key := strings.TrimPrefix(u.Path, "/")
+	if u.Host == "" || key == "" {
+		return "", errors.New("malformed S3 URI, expected s3://bucket/key: " + string(URI))
+	}
+	return strings.TrimSuffix(s.endpoint, "/") + "/" + s3Escape(u.Host) + "/" +
+		s3Escape(key), nil
+}
+
+// sign adds AWS Signature Version 4 to the request.
+func (s *s3Fetcher) sign(req *http.Request) error {
+	now := s.now().UTC()
+	amzDate := now.Format(s3TimeFormat)
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3EmptyPayloadHash)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "range" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		s3EmptyPayloadHash,
+	}, "\n")
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" +
+		hex.EncodeToString(sha256Sum([]byte(canonicalRequest)))
+
+	key := []byte("AWS4" + s.secretKey)
+	for _, part := range []string{date, s.region, "s3", "aws4_request"} {
+		key = hmacSum(key, part)
+	}
+	signature := hex.EncodeToString(hmacSum(key, stringToSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+	return nil
+}
+
+// s3Escape encodes object path as required by AWS: all characters except
+// unreserved ones are percent-encoded, slashes separate path segments.
+func s3Escape(path string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			strings.IndexByte("-_.~/", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hexDigits[c>>4])
+		b.WriteByte(hexDigits[c&0xF])
+	}
+	return b.String()
+}
+
+// sha256Sum returns SHA256 checksum of data.
+func sha256Sum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// hmacSum returns HMAC-SHA256 of data signed with key.
+func hmacSum(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data)) // nolint:gosec
+	return h.Sum(nil)
+}
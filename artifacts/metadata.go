@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package artifacts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+// sniffLen is number of bytes used to detect MIME type of an artifact.
+const sniffLen = 512
+
+// fillMetadata sets size, SHA256 digest and MIME type of the file at ai.Path.
+func fillMetadata(ai *weles.ArtifactInfo) (err error) {
+	f, err := os.Open(string(ai.Path))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err2 := f.Close(); err == nil {
+			err = err2
+		}
+	}()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	head = head[:n]
+
+	h := sha256.New()
+	_, _ = h.Write(head) // hash.Hash never returns an error.
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return err
+	}
+
+	ai.Size = size + int64(n)
+	ai.SHA256 = hex.EncodeToString(h.Sum(nil))
+	ai.MimeType = http.DetectContentType(head)
+	return nil
+}
+
+// storeMetadata computes metadata of the artifact described by change and stores it
+// in db together with source headers.
+func (s *Storage) storeMetadata(change weles.ArtifactStatusChange) {
+	ai := weles.ArtifactInfo{
+		Path:         change.Path,
+		ETag:         change.ETag,
+		LastModified: change.LastModified,
+	}
+	s.metadataMutex.Lock()
+	defer s.metadataMutex.Unlock()
+	if err := fillMetadata(&ai); err != nil {
+		log.Println("Failed to compute metadata of artifact: " + err.Error())
+		return
+	}
+	if err := s.db.SetMetadata(ai); err != nil {
+		log.Println("Failed to store metadata of artifact.")
+	}
+}
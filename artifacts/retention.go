@@ -0,0 +1,241 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package artifacts
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+// RetentionPolicy defines which artifacts are purged by the collector. Artifacts
+// owned or used by active jobs and pinned artifacts are never purged. Zero value
+// disables collecting.
+type RetentionPolicy struct {
+	// Interval between runs of the collector. Collector is disabled if it is 0.
+	Interval time.Duration
+	// MaxAge is maximum age of artifacts. There is no limit if it is 0.
+	MaxAge time.Duration
+	// TypeMaxAge overrides MaxAge for artifacts of given types.
+	TypeMaxAge map[weles.ArtifactType]time.Duration
+	// JobStatusMaxAge overrides MaxAge for artifacts of jobs in given statuses.
+	// If both type and job status overrides apply, the longer one is used.
+	JobStatusMaxAge map[weles.JobStatus]time.Duration
+	// DiskBudget is maximum total size of artifacts in bytes. Oldest artifacts are
+	// purged when it is exceeded. There is no limit if it is 0.
+	DiskBudget int64
+}
+
+// limited returns true if policy limits age or total size of artifacts.
+func (p *RetentionPolicy) limited() bool {
+	return p.MaxAge != 0 || len(p.TypeMaxAge) != 0 || len(p.JobStatusMaxAge) != 0 ||
+		p.DiskBudget > 0
+}
+
+// maxAge returns maximum age of an artifact of given type belonging to job in given status.
+// Status is empty if the job is not known. 0 means there is no limit.
+func (p *RetentionPolicy) maxAge(typ weles.ArtifactType, status weles.JobStatus) time.Duration {
+	typeAge, typeSet := p.TypeMaxAge[typ]
+	statusAge, statusSet := p.JobStatusMaxAge[status]
+	switch {
+	case typeSet && statusSet:
+		if typeAge == 0 || statusAge == 0 {
+			return 0
+		}
+		if statusAge > typeAge {
+			return statusAge
+		}
+		return typeAge
+	case typeSet:
+		return typeAge
+	case statusSet:
+		return statusAge
+	}
+	return p.MaxAge
+}
+
+// isActive returns true if job in given status may still use its artifacts.
+func isActive(status weles.JobStatus) bool {
+	switch status {
+	case weles.JobStatusNEW, weles.JobStatusPARSING, weles.JobStatusDOWNLOADING,
+		weles.JobStatusWAITING, weles.JobStatusRUNNING:
+		return true
+	}
+	return false
+}
+
+// StartCollector starts purging artifacts periodically according to policy.
+// Statuses of jobs are retrieved from jobs. They are also used to protect artifacts
+// of active jobs from deletion, even if collecting is disabled. Collector is stopped
+// by Close.
+func (s *Storage) StartCollector(policy RetentionPolicy, jobs weles.JobManager) {
+	s.usersMutex.Lock()
+	s.jobs = jobs
+	s.usersMutex.Unlock()
+	if policy.Interval <= 0 || !policy.limited() {
+		return
+	}
+	s.collector.Add(1)
+	go func() {
+		defer s.collector.Done()
+		ticker := time.NewTicker(policy.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-ticker.C:
+				if err := s.collect(policy, jobs, time.Now()); err != nil {
+					log.Println("Failed to collect artifacts: " + err.Error())
+				}
+			}
+		}
+	}()
+}
+
+// collect purges artifacts older than allowed by policy at now. If disk budget
+// is still exceeded, oldest of remaining artifacts are purged too.
+func (s *Storage) collect(policy RetentionPolicy, jobs weles.JobManager, now time.Time) error {
+	candidates, err := s.db.SelectCollectable()
+	if err != nil {
+		return err
+	}
+	users := make(map[int64][]weles.JobID)
+	var ids []weles.JobID
+	// Size of results which were never marked ready is not stored in ArtifactDB,
+	// but their files may exist, e.g. if pulling them was interrupted.
+	var unrecorded int64
+	for i, ai := range candidates {
+		if ai.Status == "" {
+			candidates[i].Size = fileSize(ai.Path)
+			unrecorded += candidates[i].Size
+		}
+		users[ai.ID] = s.artifactUsers(ai.ID)
+		ids = append(append(ids, ai.JobID), users[ai.ID]...)
+	}
+	statuses, err := jobStatuses(jobs, ids)
+	if err != nil {
+		return err
+	}
+
+	var kept []weles.ArtifactInfo
+	for _, ai := range candidates {
+		status := statuses[ai.JobID]
+		if isActive(status) || s.usedByActive(ai.ID, users[ai.ID], statuses) {
+			continue
+		}
+		age := policy.maxAge(ai.Type, status)
+		if age != 0 && now.Sub(time.Time(ai.Timestamp)) > age {
+			s.purge(ai)
+			continue
+		}
+		kept = append(kept, ai)
+	}
+
+	if policy.DiskBudget <= 0 {
+		return nil
+	}
+	total, err := s.db.TotalSize()
+	if err != nil {
+		return err
+	}
+	total += unrecorded
+	for _, ai := range kept {
+		if total <= policy.DiskBudget {
+			break
+		}
+		if s.purge(ai) {
+			total -= ai.Size
+		}
+	}
+	return nil
+}
+
+// usedByActive returns true if any of users of the artifact is active according
+// to statuses. Users which are no longer active are forgotten.
+func (s *Storage) usedByActive(id int64, users []weles.JobID,
+	statuses map[weles.JobID]weles.JobStatus) bool {
+	used := false
+	for _, j := range users {
+		if isActive(statuses[j]) {
+			used = true
+		} else {
+			s.forgetUser(id, j)
+		}
+	}
+	return used
+}
+
+// jobStatuses returns statuses of jobs with given IDs. Jobs unknown to jobs
+// are omitted.
+func jobStatuses(jobs weles.JobManager, jobIDs []weles.JobID,
+) (map[weles.JobID]weles.JobStatus, error) {
+	statuses := make(map[weles.JobID]weles.JobStatus)
+	if len(jobIDs) == 0 {
+		return statuses, nil
+	}
+	seen := make(map[weles.JobID]bool)
+	var ids []weles.JobID
+	for _, id := range jobIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	infos, _, err := jobs.ListJobs(weles.JobFilter{JobID: ids}, weles.JobSorter{},
+		weles.JobPagination{})
+	if err != nil {
+		if err == weles.ErrJobNotFound {
+			return statuses, nil
+		}
+		return nil, err
+	}
+	for _, info := range infos {
+		statuses[info.JobID] = info.Status
+	}
+	return statuses, nil
+}
+
+// fileSize returns size of the file in path or 0 if it does not exist.
+func fileSize(path weles.ArtifactPath) int64 {
+	fi, err := os.Stat(string(path))
+	if err != nil {
+		return 0
+	}
+	return fi.Size()
+}
+
+// purge removes file of the artifact and marks it as purged. It returns true
+// on success.
+func (s *Storage) purge(ai weles.ArtifactInfo) bool {
+	err := os.Remove(string(ai.Path))
+	if err != nil && !os.IsNotExist(err) {
+		log.Println("Failed to remove artifact file: " + err.Error())
+		return false
+	}
+	err = s.db.SetStatus(weles.ArtifactStatusChange{
+		Path:      ai.Path,
+		NewStatus: weles.ArtifactStatusPURGED,
+	})
+	if err != nil {
+		log.Println("Failed to mark artifact as purged: " + err.Error())
+		return false
+	}
+	return true
+}
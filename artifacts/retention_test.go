@@ -0,0 +1,250 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package artifacts
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/artifacts/downloader"
+	"github.com/SamsungSLAV/weles/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retention", func() {
+	const (
+		day     = 24 * time.Hour
+		content = "The time has come, the Walrus said."
+	)
+
+	var (
+		ctrl       *gomock.Controller
+		jm         *mock.MockJobManager
+		bronzeYak  *Storage
+		testDir    string
+		finishedID weles.JobID = 1
+		activeID   weles.JobID = 2
+	)
+
+	BeforeEach(func() {
+		var err error
+		testDir, err = ioutil.TempDir("", "test-weles-")
+		Expect(err).ToNot(HaveOccurred())
+		bronzeYak, err = newArtifactManager(filepath.Join(testDir, "test.db"), testDir, 100, 1,
+			100, 0, downloader.DefaultRetryPolicy, downloader.LimitPolicy{}, nil, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		ctrl = gomock.NewController(GinkgoT())
+		jm = mock.NewMockJobManager(ctrl)
+		jm.EXPECT().ListJobs(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			[]weles.JobInfo{
+				{JobID: finishedID, Status: weles.JobStatusCOMPLETED},
+				{JobID: activeID, Status: weles.JobStatusRUNNING},
+			}, weles.ListInfo{}, nil).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		Expect(bronzeYak.Close()).To(Succeed())
+		Expect(os.RemoveAll(testDir)).To(Succeed())
+	})
+
+	upload := func(job weles.JobID, typ weles.ArtifactType) weles.ArtifactInfo {
+		ai, err := bronzeYak.UploadArtifact(weles.ArtifactDescription{
+			Alias: "walrus",
+			JobID: job,
+			Type:  typ,
+		}, strings.NewReader(content))
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		return ai
+	}
+
+	purged := func(ai weles.ArtifactInfo) bool {
+		info, err := bronzeYak.GetArtifactInfo(ai.Path)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		if info.Status != weles.ArtifactStatusPURGED {
+			ExpectWithOffset(1, string(ai.Path)).To(BeAnExistingFile())
+			return false
+		}
+		ExpectWithOffset(1, string(ai.Path)).NotTo(BeAnExistingFile())
+		return true
+	}
+
+	DescribeTable("maximum age of artifact",
+		func(typ weles.ArtifactType, status weles.JobStatus, expected time.Duration) {
+			policy := RetentionPolicy{
+				MaxAge: 30 * day,
+				TypeMaxAge: map[weles.ArtifactType]time.Duration{
+					weles.ArtifactTypeRESULT: 90 * day,
+					weles.ArtifactTypeIMAGE:  7 * day,
+					weles.ArtifactTypeYAML:   0,
+				},
+				JobStatusMaxAge: map[weles.JobStatus]time.Duration{
+					weles.JobStatusFAILED:   60 * day,
+					weles.JobStatusCANCELED: day,
+				},
+			}
+			Expect(policy.maxAge(typ, status)).To(Equal(expected))
+		},
+		Entry("default", weles.ArtifactTypeTEST, weles.JobStatusCOMPLETED, 30*day),
+		Entry("unknown job", weles.ArtifactTypeTEST, weles.JobStatus(""), 30*day),
+		Entry("type override", weles.ArtifactTypeIMAGE, weles.JobStatusCOMPLETED, 7*day),
+		Entry("job status override", weles.ArtifactTypeTEST, weles.JobStatusFAILED, 60*day),
+		Entry("longer of overrides", weles.ArtifactTypeIMAGE, weles.JobStatusFAILED, 60*day),
+		Entry("longer of overrides", weles.ArtifactTypeRESULT, weles.JobStatusCANCELED, 90*day),
+		Entry("unlimited override", weles.ArtifactTypeYAML, weles.JobStatusFAILED,
+			time.Duration(0)),
+	)
+
+	It("should purge artifacts older than allowed", func() {
+		image := upload(finishedID, weles.ArtifactTypeIMAGE)
+		result := upload(finishedID, weles.ArtifactTypeRESULT)
+		policy := RetentionPolicy{
+			TypeMaxAge: map[weles.ArtifactType]time.Duration{
+				weles.ArtifactTypeRESULT: 90 * day,
+				weles.ArtifactTypeIMAGE:  7 * day,
+			},
+		}
+
+		Expect(bronzeYak.collect(policy, jm, time.Now().Add(8*day))).To(Succeed())
+
+		Expect(purged(image)).To(BeTrue())
+		Expect(purged(result)).To(BeFalse())
+	})
+
+	It("should never purge artifacts of active jobs", func() {
+		active := upload(activeID, weles.ArtifactTypeIMAGE)
+		finished := upload(finishedID, weles.ArtifactTypeIMAGE)
+		policy := RetentionPolicy{MaxAge: day}
+
+		Expect(bronzeYak.collect(policy, jm, time.Now().Add(2*day))).To(Succeed())
+
+		Expect(purged(active)).To(BeFalse())
+		Expect(purged(finished)).To(BeTrue())
+	})
+
+	It("should never purge uploaded artifacts used by active jobs", func() {
+		used := upload(0, weles.ArtifactTypeIMAGE)
+		unused := upload(0, weles.ArtifactTypeIMAGE)
+		_, err := bronzeYak.UseArtifact(used.ID, activeID)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = bronzeYak.UseArtifact(unused.ID, finishedID)
+		Expect(err).ToNot(HaveOccurred())
+		policy := RetentionPolicy{MaxAge: day, DiskBudget: 1}
+
+		Expect(bronzeYak.collect(policy, jm, time.Now().Add(2*day))).To(Succeed())
+
+		Expect(purged(used)).To(BeFalse())
+		Expect(purged(unused)).To(BeTrue())
+		Expect(bronzeYak.artifactUsers(used.ID)).To(ConsistOf(activeID))
+		Expect(bronzeYak.artifactUsers(unused.ID)).To(BeEmpty())
+	})
+
+	It("should never purge pinned artifacts", func() {
+		pinned := upload(finishedID, weles.ArtifactTypeIMAGE)
+		Expect(bronzeYak.db.SetPinned(pinned.Path, true)).To(Succeed())
+		policy := RetentionPolicy{MaxAge: day, DiskBudget: 1}
+
+		Expect(bronzeYak.collect(policy, jm, time.Now().Add(2*day))).To(Succeed())
+
+		Expect(purged(pinned)).To(BeFalse())
+	})
+
+	It("should purge oldest artifacts when disk budget is exceeded", func() {
+		oldest := upload(finishedID, weles.ArtifactTypeIMAGE)
+		time.Sleep(10 * time.Millisecond)
+		older := upload(finishedID, weles.ArtifactTypeRESULT)
+		time.Sleep(10 * time.Millisecond)
+		newest := upload(finishedID, weles.ArtifactTypeIMAGE)
+		active := upload(activeID, weles.ArtifactTypeIMAGE)
+		policy := RetentionPolicy{DiskBudget: 2 * int64(len(content))}
+
+		Expect(bronzeYak.collect(policy, jm, time.Now())).To(Succeed())
+
+		Expect(purged(oldest)).To(BeTrue())
+		Expect(purged(older)).To(BeTrue())
+		Expect(purged(newest)).To(BeFalse())
+		Expect(purged(active)).To(BeFalse())
+	})
+
+	Describe("results created for pulls", func() {
+		create := func(job weles.JobID) weles.ArtifactInfo {
+			path, err := bronzeYak.CreateArtifact(weles.ArtifactDescription{
+				Alias: "oyster",
+				JobID: job,
+				Type:  weles.ArtifactTypeRESULT,
+			})
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			ai, err := bronzeYak.GetArtifactInfo(path)
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			ExpectWithOffset(1, ai.Status).To(BeEmpty())
+			return ai
+		}
+
+		It("should purge results which were never pulled", func() {
+			result := create(finishedID)
+			active := create(activeID)
+			policy := RetentionPolicy{
+				TypeMaxAge: map[weles.ArtifactType]time.Duration{
+					weles.ArtifactTypeRESULT: day,
+				},
+			}
+
+			Expect(bronzeYak.collect(policy, jm, time.Now().Add(2*day))).To(Succeed())
+
+			Expect(purged(result)).To(BeTrue())
+			info, err := bronzeYak.GetArtifactInfo(active.Path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Status).To(BeEmpty())
+		})
+
+		It("should count files of results which were not marked ready", func() {
+			result := create(finishedID)
+			Expect(ioutil.WriteFile(string(result.Path), []byte(content), 0644)).To(Succeed())
+			time.Sleep(10 * time.Millisecond)
+			newest := upload(finishedID, weles.ArtifactTypeIMAGE)
+			policy := RetentionPolicy{DiskBudget: int64(len(content))}
+
+			Expect(bronzeYak.collect(policy, jm, time.Now())).To(Succeed())
+
+			Expect(purged(result)).To(BeTrue())
+			Expect(purged(newest)).To(BeFalse())
+		})
+	})
+
+	It("should not start collector without limits", func() {
+		bronzeYak.StartCollector(RetentionPolicy{Interval: time.Millisecond}, jm)
+		ai := upload(finishedID, weles.ArtifactTypeIMAGE)
+
+		Consistently(func() bool { return purged(ai) }).Should(BeFalse())
+	})
+
+	It("should collect artifacts periodically", func() {
+		ai := upload(finishedID, weles.ArtifactTypeIMAGE)
+		bronzeYak.StartCollector(RetentionPolicy{Interval: time.Millisecond, DiskBudget: 1}, jm)
+
+		Eventually(func() bool { return purged(ai) }).Should(BeTrue())
+	})
+})
@@ -18,30 +18,50 @@ package main
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	loads "github.com/go-openapi/loads"
+	"github.com/go-openapi/strfmt"
 	flag "github.com/spf13/pflag"
 
 	"github.com/SamsungSLAV/boruta/http/client"
 	"github.com/SamsungSLAV/weles"
 	"github.com/SamsungSLAV/weles/artifacts"
+	"github.com/SamsungSLAV/weles/artifacts/database"
+	"github.com/SamsungSLAV/weles/artifacts/downloader"
 	"github.com/SamsungSLAV/weles/controller"
 	"github.com/SamsungSLAV/weles/manager"
 	"github.com/SamsungSLAV/weles/parser"
 	"github.com/SamsungSLAV/weles/server"
 	"github.com/SamsungSLAV/weles/server/operations"
+	"github.com/SamsungSLAV/weles/snippets"
 )
 
 var (
 	borutaAddress            string
 	borutaRefreshPeriod      time.Duration
 	artifactDBName           string
+	snippetDBName            string
 	artifactDBLocation       string
 	artifactDownloadQueueCap int
 	activeWorkersCap         int
 	notifierChannelCap       int
+	artifactCacheSize        int64
+	artifactRetry            = downloader.DefaultRetryPolicy
+	artifactLimits           downloader.LimitPolicy
+	hostDownloadsOverrides   map[string]string
+	hostBandwidthOverrides   map[string]string
+	artifactDownloadConfig   string
+	artifactLocalRoot        string
+	artifactRetention        artifacts.RetentionPolicy
+	artifactTypeMaxAge       map[string]string
+	artifactJobStatusMaxAge  map[string]string
+	artifactConsistency      artifacts.ConsistencyPolicy
+	artifactCheckOnStart     bool
 	version                  bool
 )
 
@@ -54,6 +74,124 @@ func exitOnErr(ctx string, err error) {
 	}
 }
 
+// parseMaxAges converts map of durations given as strings to RetentionPolicy overrides.
+// Keys are validated with valid.
+func parseMaxAges(in map[string]string, valid func(string) error,
+) (map[string]time.Duration, error) {
+	out := make(map[string]time.Duration, len(in))
+	for k, v := range in {
+		if err := valid(k); err != nil {
+			return nil, err
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maximum age of %s: %s", k, err.Error())
+		}
+		out[k] = d
+	}
+	return out, nil
+}
+
+// setRetentionOverrides fills artifactRetention with maximum ages parsed from flags.
+func setRetentionOverrides() error {
+	ages, err := parseMaxAges(artifactTypeMaxAge, func(k string) error {
+		return weles.ArtifactType(k).Validate(strfmt.Default)
+	})
+	if err != nil {
+		return err
+	}
+	artifactRetention.TypeMaxAge = make(map[weles.ArtifactType]time.Duration, len(ages))
+	for k, d := range ages {
+		artifactRetention.TypeMaxAge[weles.ArtifactType(k)] = d
+	}
+
+	ages, err = parseMaxAges(artifactJobStatusMaxAge, func(k string) error {
+		return weles.JobStatus(k).Validate(strfmt.Default)
+	})
+	if err != nil {
+		return err
+	}
+	artifactRetention.JobStatusMaxAge = make(map[weles.JobStatus]time.Duration, len(ages))
+	for k, d := range ages {
+		artifactRetention.JobStatusMaxAge[weles.JobStatus(k)] = d
+	}
+	return nil
+}
+
+// setLimitOverrides fills artifactLimits with per host limits parsed from flags.
+func setLimitOverrides() error {
+	artifactLimits.HostDownloadsOverrides = make(map[string]int, len(hostDownloadsOverrides))
+	for host, v := range hostDownloadsOverrides {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid number of downloads from %s: %s", host, err.Error())
+		}
+		artifactLimits.HostDownloadsOverrides[host] = n
+	}
+	artifactLimits.HostBandwidthOverrides = make(map[string]int64, len(hostBandwidthOverrides))
+	for host, v := range hostBandwidthOverrides {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid bandwidth of %s: %s", host, err.Error())
+		}
+		artifactLimits.HostBandwidthOverrides[host] = n
+	}
+	return nil
+}
+
+// newDownloadClient returns HTTP client used for artifact downloads configured with
+// artifactDownloadConfig file. Nil is returned if it is not set.
+func newDownloadClient() (*http.Client, error) {
+	if artifactDownloadConfig == "" {
+		return nil, nil
+	}
+	cfg, err := downloader.LoadTransportConfig(artifactDownloadConfig)
+	if err != nil {
+		return nil, err
+	}
+	return downloader.NewHTTPClient(cfg)
+}
+
+// checkConsistency checks and repairs consistency of ArtifactDB and artifact files
+// and reports found inconsistencies.
+func checkConsistency() {
+	am, err := artifacts.NewArtifactManager(artifactDBName, artifactDBLocation,
+		notifierChannelCap, 0, artifactDownloadQueueCap, 0, artifactRetry,
+		downloader.LimitPolicy{}, nil, "")
+	exitOnErr("failed to initialize ArtifactManager ", err)
+	defer func() {
+		if err = am.Close(); err != nil {
+			log.Println("Failed to close ArtifactManager: " + err.Error())
+		}
+	}()
+	fixes, err := am.CheckConsistency(artifactConsistency)
+	for _, fix := range fixes {
+		fmt.Println(fix)
+	}
+	exitOnErr("failed to check consistency of ArtifactDB ", err)
+	if len(fixes) == 0 {
+		fmt.Println("ArtifactDB is consistent with artifact files")
+	}
+}
+
+// migrate brings schema of ArtifactDB to the current version and reports it. Database file
+// is backed up before any migration step which may lose data.
+func migrate() {
+	err := os.MkdirAll(artifactDBLocation, os.ModePerm)
+	exitOnErr("failed to create ArtifactDB location ", err)
+	var db database.ArtifactDB
+	err = db.Open(filepath.Join(artifactDBLocation, artifactDBName))
+	exitOnErr("failed to migrate ArtifactDB ", err)
+	defer func() {
+		if err = db.Close(); err != nil {
+			log.Println("Failed to close ArtifactDB: " + err.Error())
+		}
+	}()
+	v, err := db.SchemaVersion()
+	exitOnErr("failed to get ArtifactDB schema version ", err)
+	fmt.Println("ArtifactDB schema version", v)
+}
+
 func main() {
 
 	swaggerSpec, err := loads.Embedded(server.SwaggerJSON, server.FlatSwaggerJSON)
@@ -65,6 +203,12 @@ func main() {
 	flag.Int32Var(&apiDefaults.PageLimit, "page-limit", 0, "Default limit of page size returned "+
 		"by Weles API. If set to 0 pagination will be turned off")
 
+	flag.StringVar(&apiDefaults.AdminToken, "admin-token", "",
+		"Token authorizing deletion, pinning and unpinning of artifacts and creation and "+
+			"deletion of snippets. It must be passed in \"Authorization: Bearer <token>\" header. "+
+			"These requests are disabled if it is empty. WELES_ADMIN_TOKEN environment "+
+			"variable is used if it is not set.")
+
 	flag.StringVar(&borutaAddress, "boruta-address", "http://127.0.0.1:8487",
 		"Boruta address. Must contain protocol.")
 
@@ -77,24 +221,95 @@ func main() {
 	flag.StringVar(&artifactDBLocation, "db-location", "/tmp/weles/",
 		"location of *.db file and place where Weles will store artifacts.")
 
+	flag.StringVar(&snippetDBName, "snippets-db-file", "snippets.db",
+		"name of *.db file storing library of job snippets. It is located in --db-location")
+
 	//TODO: when cyberdryads or testlab instance will be present, performance tests should be done
 	// to set default values of below:
 	flag.IntVar(&artifactDownloadQueueCap, "artifact-download-queue-cap", 100,
-		"Capacity of artifact download queue")
+		"Initial capacity of artifact download queue. The queue grows when needed.")
 
 	flag.IntVar(&activeWorkersCap, "active-workers-cap", 16, "Maximum number of active workers.")
 
 	flag.IntVar(&notifierChannelCap, "notifier-channel-cap", 100, "Notifier channel capacity.")
 
+	flag.Int64Var(&artifactCacheSize, "artifact-cache-size", 16<<30,
+		"Maximum size (in bytes) of downloaded artifacts cache. Set to 0 to disable caching.")
+
+	flag.IntVar(&artifactRetry.Attempts, "artifact-download-attempts",
+		downloader.DefaultRetryPolicy.Attempts,
+		"Maximum number of attempts of downloading an artifact. Set to 1 to disable retrying.")
+	flag.DurationVar(&artifactRetry.InitialDelay, "artifact-retry-delay",
+		downloader.DefaultRetryPolicy.InitialDelay,
+		"Delay before the first retry of artifact download. It doubles with every next retry.")
+	flag.DurationVar(&artifactRetry.MaxDelay, "artifact-retry-max-delay",
+		downloader.DefaultRetryPolicy.MaxDelay,
+		"Maximum delay between retries of artifact download.")
+
+	flag.IntVar(&artifactLimits.HostDownloads, "artifact-host-downloads", 0,
+		"Maximum number of concurrent artifact downloads from a single host. "+
+			"Set to 0 to disable the limit.")
+	flag.StringToStringVar(&hostDownloadsOverrides, "artifact-host-downloads-override", nil,
+		"Maximum number of concurrent artifact downloads from given hosts overriding "+
+			"--artifact-host-downloads, e.g. example.com=2,example.com:8080=4")
+	flag.Int64Var(&artifactLimits.Bandwidth, "artifact-bandwidth", 0,
+		"Maximum total rate (in bytes per second) of artifact downloads. "+
+			"Set to 0 to disable the limit.")
+	flag.Int64Var(&artifactLimits.HostBandwidth, "artifact-host-bandwidth", 0,
+		"Maximum rate (in bytes per second) of artifact downloads from a single host. "+
+			"Set to 0 to disable the limit.")
+	flag.StringToStringVar(&hostBandwidthOverrides, "artifact-host-bandwidth-override", nil,
+		"Maximum rate (in bytes per second) of artifact downloads from given hosts "+
+			"overriding --artifact-host-bandwidth, e.g. example.com=10485760")
+	flag.StringVar(&artifactDownloadConfig, "artifact-download-config", "",
+		"Path of YAML file with credentials and TLS settings of hosts, trusted CA bundle "+
+			"and proxy settings used for artifact downloads.")
+	flag.StringVar(&artifactLocalRoot, "artifact-local-root", "",
+		"Directory from which artifacts with file:// and git+file:// URIs may be copied. "+
+			"Such URIs are rejected if it is not set.")
+
+	flag.DurationVar(&artifactRetention.Interval, "artifact-retention-interval", time.Hour,
+		"Interval between runs of artifact collector. Set to 0 to disable collecting.")
+	flag.DurationVar(&artifactRetention.MaxAge, "artifact-max-age", 0,
+		"Maximum age of artifacts. Set to 0 to keep artifacts forever.")
+	flag.StringToStringVar(&artifactTypeMaxAge, "artifact-type-max-age", nil,
+		"Maximum age of artifacts of given types overriding --artifact-max-age, "+
+			"e.g. RESULT=2160h,IMAGE=168h")
+	flag.StringToStringVar(&artifactJobStatusMaxAge, "artifact-job-status-max-age", nil,
+		"Maximum age of artifacts of jobs in given statuses overriding --artifact-max-age, "+
+			"e.g. FAILED=720h. If both type and job status ages apply, the longer is used.")
+	flag.Int64Var(&artifactRetention.DiskBudget, "artifact-disk-budget", 0,
+		"Maximum total size (in bytes) of artifacts. Oldest artifacts are removed "+
+			"when it is exceeded. Set to 0 to disable the limit.")
+
+	flag.BoolVar(&artifactCheckOnStart, "artifact-check-on-start", true,
+		"Check and repair consistency of ArtifactDB and artifact files on start.")
+	flag.BoolVar(&artifactConsistency.VerifyDigests, "artifact-verify-digests", false,
+		"Compare SHA256 digests of artifacts with stored ones during consistency check. "+
+			"Only sizes are compared otherwise.")
+	flag.BoolVar(&artifactConsistency.AdoptOrphans, "artifact-adopt-orphans", false,
+		"Add artifact files without ArtifactDB records as READY artifacts during "+
+			"consistency check instead of removing them.")
+	flag.BoolVar(&artifactConsistency.DryRun, "dry-run", false,
+		"Only report inconsistencies found by consistency check without repairing them.")
+
 	flag.BoolVar(&version, "version", false, "Print Weles server version and exit.")
 
 	//TODO: input validation
 
 	flag.Usage = func() {
 		_, _ = fmt.Fprint(os.Stderr,
-			`Usage: `+os.Args[0]+` [OPTIONS]
+			`Usage: `+os.Args[0]+` [OPTIONS] [migrate|check]
 Weles is a lightweight testing framework for Boruta, inspired by LAVA.
-You can find out more at weles.rtfd.io`+"\n\n"+flag.CommandLine.FlagUsages())
+You can find out more at weles.rtfd.io
+
+Commands:
+  migrate    migrate ArtifactDB schema to the current version and exit.
+             ArtifactDB is migrated on start of the server as well.
+  check      check and repair consistency of ArtifactDB and artifact files,
+             report found inconsistencies and exit. It must not be run while
+             the server is running.`+"\n\n"+
+				flag.CommandLine.FlagUsages())
 	}
 	// parse the CLI flags
 	flag.Parse()
@@ -104,17 +319,63 @@ You can find out more at weles.rtfd.io`+"\n\n"+flag.CommandLine.FlagUsages())
 		os.Exit(0)
 	}
 
+	if apiDefaults.AdminToken == "" {
+		// Token is not used as flag's default value, so that it is not shown in usage.
+		apiDefaults.AdminToken = os.Getenv("WELES_ADMIN_TOKEN")
+	}
+
+	switch flag.Arg(0) {
+	case "":
+	case "migrate":
+		migrate()
+		return
+	case "check":
+		checkConsistency()
+		return
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err = setRetentionOverrides()
+	exitOnErr("invalid artifact retention policy ", err)
+	err = setLimitOverrides()
+	exitOnErr("invalid artifact download limits ", err)
+	httpClient, err := newDownloadClient()
+	exitOnErr("invalid artifact download config ", err)
+
 	var yap parser.Parser
 	am, err := artifacts.NewArtifactManager(
 		artifactDBName,
 		artifactDBLocation,
 		notifierChannelCap,
 		activeWorkersCap,
-		artifactDownloadQueueCap)
+		artifactDownloadQueueCap,
+		artifactCacheSize,
+		artifactRetry,
+		artifactLimits,
+		httpClient,
+		artifactLocalRoot)
 	exitOnErr("failed to initialize ArtifactManager ", err)
+	if artifactCheckOnStart {
+		fixes, err := am.CheckConsistency(artifactConsistency)
+		for _, fix := range fixes {
+			log.Println("ArtifactDB inconsistency: " + fix.String())
+		}
+		exitOnErr("failed to check consistency of ArtifactDB ", err)
+	}
 	bor := client.NewBorutaClient(borutaAddress)
-	djm := manager.NewDryadJobManager(artifactDBLocation)
-	jm := controller.NewJobManager(am, &yap, bor, borutaRefreshPeriod, djm)
+	djm := manager.NewDryadJobManager(artifactDBLocation, am)
+	snm, err := snippets.NewSnippetManager(snippetDBName, artifactDBLocation)
+	exitOnErr("failed to initialize SnippetManager ", err)
+	defer func() {
+		if err = snm.Close(); err != nil {
+			log.Println("Failed to close SnippetManager: " + err.Error())
+		}
+	}()
+	jm := controller.NewJobManager(am, &yap, bor, bor, borutaRefreshPeriod, djm, snm,
+		httpClient)
+	am.StartCollector(artifactRetention, jm)
 
 	api := operations.NewWelesAPI(swaggerSpec)
 	// get server with flag values filled out
@@ -126,7 +387,7 @@ You can find out more at weles.rtfd.io`+"\n\n"+flag.CommandLine.FlagUsages())
 		}
 	}()
 
-	apiDefaults.Managers = server.NewManagers(jm, am)
+	apiDefaults.Managers = server.NewManagers(jm, am, snm)
 
 	srv.WelesConfigureAPI(&apiDefaults)
 	err = srv.Serve()
@@ -23,12 +23,14 @@
 package controller
 
 import (
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/SamsungSLAV/boruta"
 
 	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/parser"
 )
 
 // Controller binds all major components of Weles and provides logic layer
@@ -50,6 +52,10 @@ type Controller struct {
 	boruter Boruter
 	// dryader delegates Jobs execution to DryadJobManager and monitors progress.
 	dryader Dryader
+	// validator checks Jobs' yaml files without creating Jobs.
+	validator Validator
+	// snippets provides snippets included in Jobs' yaml files.
+	snippets weles.SnippetManager
 	// finish is channel for stopping internal goroutine.
 	finish chan int
 	// looper waits for internal goroutine running loop to finish.
@@ -59,28 +65,35 @@ type Controller struct {
 // NewJobManager creates and initializes a new instance of Controller with
 // internal submodules and returns JobManager interface.
 // It is the only valid way to get JobManager interface.
+// Snippets included in Jobs' yaml files are taken from snm.
+// Client is used for checking if artifacts of validated Jobs are reachable.
+// If it is nil, http.DefaultClient is used.
 func NewJobManager(arm weles.ArtifactManager, yap weles.Parser, bor boruta.Requests,
-	borutaRefreshPeriod time.Duration, djm weles.DryadJobManager) weles.JobManager {
+	wor boruta.Workers, borutaRefreshPeriod time.Duration, djm weles.DryadJobManager,
+	snm weles.SnippetManager, client *http.Client) weles.JobManager {
 
 	js := NewJobsController()
 	pa := NewParser(js, arm, yap)
 	do := NewDownloader(js, arm)
 	bo := NewBoruter(js, bor, borutaRefreshPeriod)
 	dr := NewDryader(js, djm)
+	va := NewValidator(yap, wor, snm, client)
 
-	return NewController(js, pa, do, bo, dr)
+	return NewController(js, pa, do, bo, dr, va, snm)
 }
 
 // NewController creates and initializes a new instance of Controller.
-// It requires internal Controller's submodules.
+// It requires internal Controller's submodules and SnippetManager.
 func NewController(js JobsController, pa Parser, do Downloader, bo Boruter, dr Dryader,
-) *Controller {
+	va Validator, snm weles.SnippetManager) *Controller {
 	c := &Controller{
 		jobs:       js,
 		parser:     pa,
 		downloader: do,
 		boruter:    bo,
 		dryader:    dr,
+		validator:  va,
+		snippets:   snm,
 		finish:     make(chan int),
 	}
 	c.looper.Add(1)
@@ -95,9 +108,23 @@ func (c *Controller) Finish() {
 }
 
 // CreateJob creates a new Job in Weles using recipe passed in YAML format.
-// It is a part of JobManager implementation.
-func (c *Controller) CreateJob(yaml []byte) (weles.JobID, error) {
-	j, err := c.jobs.NewJob(yaml)
+// Included snippets are resolved first, so that placeholders in snippets are rendered
+// with vars too. The result is stored as Job's yaml, so the Job can be reproduced even
+// if snippets change. It is a part of JobManager implementation.
+func (c *Controller) CreateJob(yaml []byte, vars map[string]string) (weles.JobID, error) {
+	expanded, err := parser.Include(yaml, c.snippets)
+	if err != nil {
+		if _, ok := err.(parser.ValidationErrors); ok {
+			return weles.JobID(0), weles.ErrInvalidArgument(err.Error())
+		}
+		return weles.JobID(0), err
+	}
+	rendered, err := parser.Render(expanded, vars)
+	if err != nil {
+		return weles.JobID(0), weles.ErrInvalidArgument(err.Error())
+	}
+
+	j, err := c.jobs.NewJob(rendered)
 	if err != nil {
 		return weles.JobID(0), err
 	}
@@ -114,11 +141,19 @@ func (c *Controller) CancelJob(j weles.JobID) error {
 	if err != nil {
 		return err
 	}
+	c.downloader.CancelJob(j)
 	c.dryader.CancelJob(j)
 	c.boruter.Release(j)
 	return nil
 }
 
+// ValidateJob checks Job's recipe passed in YAML format without creating a Job.
+// It is a part of JobManager implementation.
+func (c *Controller) ValidateJob(yaml []byte, vars map[string]string,
+) (weles.JobValidation, error) {
+	return c.validator.Validate(yaml, vars), nil
+}
+
 // ListJobs returns information on Jobs.
 // It is a part of JobManager implementation.
 func (c *Controller) ListJobs(filter weles.JobFilter, sorter weles.JobSorter,
@@ -162,11 +197,12 @@ func (c *Controller) loop() {
 	}
 }
 
-// fail sets Job in FAILED state and if needed stops Job's execution on Dryad
-// and releases Dryad to Boruta.
+// fail sets Job in FAILED state, cancels downloads of its artifacts and if needed
+// stops Job's execution on Dryad and releases Dryad to Boruta.
 func (c *Controller) fail(j weles.JobID, msg string) {
 	// errors logged in the SetStatusAndInfo.
 	_ = c.jobs.SetStatusAndInfo(j, weles.JobStatusFAILED, msg) // nolint:gosec
+	c.downloader.CancelJob(j)
 	c.dryader.CancelJob(j)
 	c.boruter.Release(j)
 }
@@ -38,11 +38,13 @@ var _ = Describe("JobManager", func() {
 		arm := mock.NewMockArtifactManager(ctrl)
 		yap := mock.NewMockParser(ctrl)
 		bor := cmock.NewMockRequests(ctrl)
+		wor := cmock.NewMockWorkers(ctrl)
 		djm := mock.NewMockDryadJobManager(ctrl)
+		snm := mock.NewMockSnippetManager(ctrl)
 
 		bor.EXPECT().ListRequests(nil).AnyTimes()
 
-		jm := NewJobManager(arm, yap, bor, time.Second, djm)
+		jm := NewJobManager(arm, yap, bor, wor, time.Second, djm, snm, nil)
 		Expect(jm).NotTo(BeNil())
 
 		ctrl.Finish()
@@ -56,6 +58,8 @@ var _ = Describe("Controller", func() {
 		dow     *cmock.MockDownloader
 		bor     *cmock.MockBoruter
 		dry     *cmock.MockDryader
+		val     *cmock.MockValidator
+		snm     *mock.MockSnippetManager
 		h       *Controller
 		ctrl    *gomock.Controller
 		parChan chan notifier.Notification
@@ -94,6 +98,8 @@ var _ = Describe("Controller", func() {
 		dow = cmock.NewMockDownloader(ctrl)
 		bor = cmock.NewMockBoruter(ctrl)
 		dry = cmock.NewMockDryader(ctrl)
+		val = cmock.NewMockValidator(ctrl)
+		snm = mock.NewMockSnippetManager(ctrl)
 
 		parChan = make(chan notifier.Notification)
 		dowChan = make(chan notifier.Notification)
@@ -105,7 +111,7 @@ var _ = Describe("Controller", func() {
 		bor.EXPECT().Listen().AnyTimes().Return((<-chan notifier.Notification)(borChan))
 		dry.EXPECT().Listen().AnyTimes().Return((<-chan notifier.Notification)(dryChan))
 
-		h = NewController(jc, par, dow, bor, dry)
+		h = NewController(jc, par, dow, bor, dry, val, snm)
 
 		mutex = new(sync.Mutex)
 		done = false
@@ -123,24 +129,89 @@ var _ = Describe("Controller", func() {
 			Expect(h.downloader).To(Equal(dow))
 			Expect(h.boruter).To(Equal(bor))
 			Expect(h.dryader).To(Equal(dry))
+			Expect(h.validator).To(Equal(val))
+			Expect(h.snippets).To(Equal(snm))
 			Expect(h.finish).NotTo(BeNil())
 		})
 	})
 	Describe("CreateJob", func() {
+		vars := map[string]string{"NAME": "qemu"}
 		It("should create a new Job and delegate parsing", func() {
 			jc.EXPECT().NewJob(yaml).Return(j, nil)
 			par.EXPECT().Parse(j).Do(setDone)
 
-			retJobID, retErr := h.CreateJob(yaml)
+			retJobID, retErr := h.CreateJob(yaml, nil)
 
 			Expect(retErr).NotTo(HaveOccurred())
 			Expect(retJobID).To(Equal(j))
 			eventuallyDone()
 		})
+		It("should create a new Job from rendered template", func() {
+			jc.EXPECT().NewJob([]byte("job_name: qemu-test\n")).Return(j, nil)
+			par.EXPECT().Parse(j).Do(setDone)
+
+			retJobID, retErr := h.CreateJob([]byte("job_name: ${NAME}-${SUFFIX:-test}"), vars)
+
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retJobID).To(Equal(j))
+			eventuallyDone()
+		})
+		It("should fail if variable is missing", func() {
+			retJobID, retErr := h.CreateJob([]byte("job_name: ${MISSING}"), vars)
+
+			Expect(retErr).To(Equal(weles.ErrInvalidArgument(
+				`line 1, column 11: undefined variable "MISSING"`)))
+			Expect(retJobID).To(Equal(weles.JobID(0)))
+		})
+		It("should create a new Job with resolved includes", func() {
+			snippet := weles.Snippet{Name: "qemu-boot", Version: 2, Content: "login: root\n"}
+			snm.EXPECT().GetSnippet("qemu-boot", int64(2)).Return(snippet, nil)
+			jc.EXPECT().NewJob([]byte("job_name: qemu\nboot:\n  login: root\n")).Return(j, nil)
+			par.EXPECT().Parse(j).Do(setDone)
+
+			retJobID, retErr := h.CreateJob(
+				[]byte("job_name: ${NAME}\nboot:\n  include: qemu-boot@v2\n"), vars)
+
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retJobID).To(Equal(j))
+			eventuallyDone()
+		})
+		It("should render placeholders in included snippets", func() {
+			snippet := weles.Snippet{Name: "qemu-boot", Version: 2,
+				Content: "login: ${USER:-root}\n"}
+			snm.EXPECT().GetSnippet("qemu-boot", int64(2)).Return(snippet, nil)
+			jc.EXPECT().NewJob([]byte("boot:\n  login: qemu\n")).Return(j, nil)
+			par.EXPECT().Parse(j).Do(setDone)
+
+			retJobID, retErr := h.CreateJob([]byte("boot:\n  include: qemu-boot@v2\n"),
+				map[string]string{"USER": "qemu"})
+
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retJobID).To(Equal(j))
+			eventuallyDone()
+		})
+		It("should fail if included snippet is missing", func() {
+			snm.EXPECT().GetSnippet("qemu-boot", int64(0)).Return(
+				weles.Snippet{}, weles.ErrSnippetNotFound)
+
+			retJobID, retErr := h.CreateJob([]byte("boot:\n  include: qemu-boot\n"), vars)
+
+			Expect(retErr).To(Equal(weles.ErrInvalidArgument(
+				`line 2, column 12: snippet "qemu-boot" not found`)))
+			Expect(retJobID).To(Equal(weles.JobID(0)))
+		})
+		It("should fail if SnippetManager fails", func() {
+			snm.EXPECT().GetSnippet("qemu-boot", int64(0)).Return(weles.Snippet{}, testErr)
+
+			retJobID, retErr := h.CreateJob([]byte("boot:\n  include: qemu-boot\n"), vars)
+
+			Expect(retErr).To(Equal(testErr))
+			Expect(retJobID).To(Equal(weles.JobID(0)))
+		})
 		It("should fail if JobsController.NewJob fails", func() {
 			jc.EXPECT().NewJob(yaml).Return(weles.JobID(0), testErr)
 
-			retJobID, retErr := h.CreateJob(yaml)
+			retJobID, retErr := h.CreateJob(yaml, vars)
 
 			Expect(retErr).To(Equal(testErr))
 			Expect(retJobID).To(Equal(weles.JobID(0)))
@@ -148,15 +219,17 @@ var _ = Describe("Controller", func() {
 	})
 
 	Describe("CancelJob", func() {
-		It("should cancel Job, stop execution on Dryad and release Dryad to Boruta", func() {
-			jc.EXPECT().SetStatusAndInfo(j, weles.JobStatusCANCELED, "")
-			dry.EXPECT().CancelJob(j)
-			bor.EXPECT().Release(j)
+		It("should cancel Job, stop downloads, execution on Dryad and release Dryad to Boruta",
+			func() {
+				jc.EXPECT().SetStatusAndInfo(j, weles.JobStatusCANCELED, "")
+				dow.EXPECT().CancelJob(j)
+				dry.EXPECT().CancelJob(j)
+				bor.EXPECT().Release(j)
 
-			retErr := h.CancelJob(j)
+				retErr := h.CancelJob(j)
 
-			Expect(retErr).To(BeNil())
-		})
+				Expect(retErr).To(BeNil())
+			})
 		It("should return error if Job fails to be cancelled", func() {
 			jc.EXPECT().SetStatusAndInfo(j, weles.JobStatusCANCELED, "").Return(testErr)
 
@@ -165,6 +238,18 @@ var _ = Describe("Controller", func() {
 			Expect(retErr).To(Equal(testErr))
 		})
 	})
+	Describe("ValidateJob", func() {
+		vars := map[string]string{"NAME": "qemu"}
+		It("should delegate validation to Validator", func() {
+			result := weles.JobValidation{Valid: true}
+			val.EXPECT().Validate(yaml, vars).Return(result)
+
+			ret, retErr := h.ValidateJob(yaml, vars)
+
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(ret).To(Equal(result))
+		})
+	})
 	Describe("ListJobs", func() {
 		It("should call JobsController method", func() {
 			filter := weles.JobFilter{}
@@ -214,6 +299,7 @@ var _ = Describe("Controller", func() {
 		DescribeTable("Action fail",
 			func(cnn *chan notifier.Notification) {
 				jc.EXPECT().SetStatusAndInfo(j, weles.JobStatusFAILED, testMsg)
+				dow.EXPECT().CancelJob(j)
 				dry.EXPECT().CancelJob(j)
 				bor.EXPECT().Release(j)
 				*cnn <- notiFail
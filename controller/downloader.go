@@ -31,4 +31,6 @@ type Downloader interface {
 	// DispatchDownloads requests downloading of artifacts required to start the Job.
 	// It prepares paths for tests results and updates Job's config with artifacts paths.
 	DispatchDownloads(weles.JobID)
+	// CancelJob stops downloading of artifacts required by the Job.
+	CancelJob(weles.JobID)
 }
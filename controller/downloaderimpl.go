@@ -20,20 +20,34 @@ package controller
 
 import (
 	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/artifacts/checksum"
+	"github.com/SamsungSLAV/weles/artifacts/compression"
+	"github.com/SamsungSLAV/weles/artifacts/downloader"
 	"github.com/SamsungSLAV/weles/controller/notifier"
 )
 
 const (
-	formatJobStatus = "Internal Weles error while changing Job status : %s"
-	formatJobConfig = "Internal Weles error while getting Job config : %s"
-	formatURI       = "Internal Weles error while registering URI:<%s> in ArtifactManager : %s"
-	formatPath      = "Internal Weles error while creating a new path in ArtifactManager : %s"
-	formatConfig    = "Internal Weles error while setting config : %s"
-	formatDownload  = "Failed to download some artifacts for the Job"
-	formatReady     = "%d / %d artifacts ready"
+	formatJobStatus  = "Internal Weles error while changing Job status : %s"
+	formatJobConfig  = "Internal Weles error while getting Job config : %s"
+	formatURI        = "Internal Weles error while registering URI:<%s> in ArtifactManager : %s"
+	formatPath       = "Internal Weles error while creating a new path in ArtifactManager : %s"
+	formatConfig     = "Internal Weles error while setting config : %s"
+	formatDownload   = "Failed to download some artifacts for the Job"
+	formatReady      = "%d / %d artifacts ready"
+	formatCached     = " (%d from cache)"
+	formatProgress   = ", %s / %s downloaded"
+	formatReceived   = ", %s downloaded"
+	formatETA        = ", ETA %s"
+	formatChecksum   = "Checksum verification failed for %s <%s> : %s"
+	formatDecompress = "Decompression failed for %s <%s> : %s"
 )
 
 // jobArtifactsInfo contains information about progress of downloading
@@ -41,8 +55,34 @@ const (
 type jobArtifactsInfo struct {
 	paths       int
 	ready       int
+	cached      int
 	failed      int
 	configSaved bool
+	// progress of downloading artifacts mapped by their paths.
+	progress map[string]weles.ArtifactProgress
+	// images lists images to be verified against checksums or decompressed
+	// when all artifacts are ready.
+	images     []imageProcessing
+	processing bool
+}
+
+// imageProcessing describes an image which should be verified against
+// its checksum file or decompressed after download.
+type imageProcessing struct {
+	alias string
+	uri   string
+	// path is path of the image used by the Job.
+	path string
+	// source is path of the downloaded or uploaded image. It differs from path
+	// only if shared image is decompressed to the Job's own artifact.
+	source string
+	// shared is set if source is an artifact uploaded to ArtifactDB. Such artifacts
+	// may be used by many Jobs, so they are never modified nor failed.
+	shared         bool
+	checksumPath   string
+	checksumType   string
+	compression    string
+	keepCompressed bool
 }
 
 // DownloaderImpl implements delegating downloading of artifacts required
@@ -66,6 +106,11 @@ type DownloaderImpl struct {
 	info map[weles.JobID]*jobArtifactsInfo
 	//mutex protects access to path2Job and info maps.
 	mutex *sync.Mutex
+
+	// verifyChecksum verifies file against checksum file.
+	verifyChecksum func(path, checksumPath, checksumType, name string) error
+	// decompress decompresses src file to dst.
+	decompress func(src, dst, compression string) error
 }
 
 // NewDownloader creates a new DownloaderImpl structure setting up references
@@ -79,6 +124,9 @@ func NewDownloader(j JobsController, a weles.ArtifactManager) Downloader {
 		path2Job:  make(map[string]weles.JobID),
 		info:      make(map[weles.JobID]*jobArtifactsInfo),
 		mutex:     new(sync.Mutex),
+
+		verifyChecksum: checksum.Verify,
+		decompress:     compression.Decompress,
 	}
 	go ret.loop()
 	return ret
@@ -86,10 +134,11 @@ func NewDownloader(j JobsController, a weles.ArtifactManager) Downloader {
 
 // pathStatusChange reacts on notification from ArtifactManager and updates
 // path and job structures.
-func (h *DownloaderImpl) pathStatusChange(path string, status weles.ArtifactStatus,
+func (h *DownloaderImpl) pathStatusChange(change weles.ArtifactStatusChange,
 ) (changed bool, j weles.JobID, info string) {
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
+	path := string(change.Path)
 	j, ok := h.path2Job[path]
 	if !ok {
 		return
@@ -99,10 +148,28 @@ func (h *DownloaderImpl) pathStatusChange(path string, status weles.ArtifactStat
 		delete(h.path2Job, path)
 		return
 	}
-	switch status {
+	if change.Progress != nil {
+		if i.progress == nil {
+			i.progress = make(map[string]weles.ArtifactProgress)
+		}
+		i.progress[path] = *change.Progress
+		return true, j, i.info()
+	}
+	switch change.NewStatus {
 	case weles.ArtifactStatusREADY:
 		i.ready++
-		info = fmt.Sprintf(formatReady, i.ready, i.paths)
+		if change.Cached {
+			i.cached++
+		}
+		if p, ok := i.progress[path]; ok {
+			// Last chunks of the file might not be reported.
+			if p.Total < 0 {
+				p.Total = p.Received
+			}
+			p.Received, p.Rate = p.Total, 0
+			i.progress[path] = p
+		}
+		info = i.info()
 	case weles.ArtifactStatusFAILED:
 		i.failed++
 		info = "Failed to download artifact"
@@ -114,6 +181,51 @@ func (h *DownloaderImpl) pathStatusChange(path string, status weles.ArtifactStat
 	return
 }
 
+// info describes progress of downloading artifacts of the job. Aggregated number
+// of received bytes and estimated time of finishing are added if downloader
+// reported progress of any artifact.
+func (i *jobArtifactsInfo) info() string {
+	info := fmt.Sprintf(formatReady, i.ready, i.paths)
+	if i.cached > 0 {
+		info += fmt.Sprintf(formatCached, i.cached)
+	}
+	if len(i.progress) == 0 {
+		return info
+	}
+	var received, total, rate int64
+	known := true
+	for _, p := range i.progress {
+		received += p.Received
+		rate += p.Rate
+		if p.Total < 0 {
+			known = false
+		}
+		total += p.Total
+	}
+	if !known {
+		return info + fmt.Sprintf(formatReceived, formatBytes(received))
+	}
+	info += fmt.Sprintf(formatProgress, formatBytes(received), formatBytes(total))
+	if rate > 0 && total > received {
+		info += fmt.Sprintf(formatETA, time.Duration((total-received)/rate)*time.Second)
+	}
+	return info
+}
+
+// formatBytes returns human readable representation of size given in bytes.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // removePath removes mapping from the path to related Job.
 func (h *DownloaderImpl) removePath(path string) {
 	h.mutex.Lock()
@@ -132,7 +244,7 @@ func (h *DownloaderImpl) loop() {
 		if !open {
 			return
 		}
-		update, j, info := h.pathStatusChange(string(change.Path), change.NewStatus)
+		update, j, info := h.pathStatusChange(change)
 		if !update {
 			continue
 		}
@@ -160,6 +272,24 @@ func (h *DownloaderImpl) succeed(j weles.JobID) {
 	}
 }
 
+// CancelJob stops tracking artifacts of the Job and cancels their downloads
+// in ArtifactManager. Artifacts shared with other Jobs are still downloaded.
+func (h *DownloaderImpl) CancelJob(j weles.JobID) {
+	h.mutex.Lock()
+	delete(h.info, j)
+	for path, job := range h.path2Job {
+		if job == j {
+			delete(h.path2Job, path)
+		}
+	}
+	h.mutex.Unlock()
+
+	err := h.artifacts.CancelJobArtifacts(j)
+	if err != nil {
+		log.Printf("Failed to cancel downloads of artifacts of Job %d: %s", j, err)
+	}
+}
+
 // initializeJobInfo creates a jobArtifactInfo structure.
 func (h *DownloaderImpl) initializeJobInfo(j weles.JobID) {
 	h.mutex.Lock()
@@ -183,15 +313,37 @@ func (h *DownloaderImpl) removeJobInfo(j weles.JobID) error {
 	return nil
 }
 
-// push delegates downloading single uri to ArtifactDB.
-func (h *DownloaderImpl) push(j weles.JobID, t weles.ArtifactType, alias, uri string,
-) (string, error) {
+// resolveUploaded returns path of an artifact uploaded directly to ArtifactDB
+// and records that it is used by the Job.
+func (h *DownloaderImpl) resolveUploaded(j weles.JobID, uri string) (string, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(uri, weles.UploadedArtifactURIPrefix), 10, 64)
+	if err != nil {
+		return "", weles.ErrInvalidArgument("malformed artifact ID in URI: " + uri)
+	}
+	ai, err := h.artifacts.UseArtifact(id, j)
+	if err != nil {
+		return "", err
+	}
+	if ai.Status != weles.ArtifactStatusREADY {
+		return "", weles.ErrArtifactNotReady
+	}
+	return string(ai.Path), nil
+}
+
+// push delegates downloading single uri to ArtifactDB with Job's priority. Artifacts
+// uploaded directly to ArtifactDB are resolved to their paths without downloading.
+func (h *DownloaderImpl) push(j weles.JobID, priority weles.Priority, t weles.ArtifactType,
+	alias, uri string) (string, error) {
+	if strings.HasPrefix(uri, weles.UploadedArtifactURIPrefix) {
+		return h.resolveUploaded(j, uri)
+	}
+
 	p, err := h.artifacts.PushArtifact(weles.ArtifactDescription{
 		JobID: j,
 		Type:  t,
 		Alias: weles.ArtifactAlias(alias),
 		URI:   weles.ArtifactURI(uri),
-	}, h.collector)
+	}, priority, h.collector)
 	if err != nil {
 		return "", err
 	}
@@ -209,6 +361,17 @@ func (h *DownloaderImpl) push(j weles.JobID, t weles.ArtifactType, alias, uri st
 	return string(p), nil
 }
 
+// imageCreate creates a new path for image decompressed from a shared artifact.
+func (h *DownloaderImpl) imageCreate(j weles.JobID, alias, uri string) (string, error) {
+	p, err := h.artifacts.CreateArtifact(weles.ArtifactDescription{
+		JobID: j,
+		Type:  weles.ArtifactTypeIMAGE,
+		Alias: weles.ArtifactAlias(alias),
+		URI:   weles.ArtifactURI(uri),
+	})
+	return string(p), err
+}
+
 // pullCreate creates a new path for pull artifact.
 func (h *DownloaderImpl) pullCreate(j weles.JobID, alias string) (string, error) {
 	p, err := h.artifacts.CreateArtifact(weles.ArtifactDescription{
@@ -219,6 +382,18 @@ func (h *DownloaderImpl) pullCreate(j weles.JobID, alias string) (string, error)
 	return string(p), err
 }
 
+// addImageProcessing registers an image to be processed before job is responded.
+func (h *DownloaderImpl) addImageProcessing(j weles.JobID, img imageProcessing) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	i, ok := h.info[j]
+	if !ok {
+		return
+	}
+	i.images = append(i.images, img)
+}
+
 // configSaved updates info structure.
 func (h *DownloaderImpl) configSaved(j weles.JobID) {
 	h.mutex.Lock()
@@ -232,39 +407,147 @@ func (h *DownloaderImpl) configSaved(j weles.JobID) {
 	i.configSaved = true
 }
 
-// verify if an answer to the Controller should be send.
-func (h *DownloaderImpl) verify(j weles.JobID) (success, send bool) {
+// verify if an answer to the Controller should be send. Returned images
+// must be processed before successful answer is sent.
+func (h *DownloaderImpl) verify(j weles.JobID) (success, send bool, images []imageProcessing) {
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
 
 	i, ok := h.info[j]
 	if !ok { // Job is not monitored (maybe it has been responded already).
-		return false, false
+		return false, false, nil
 	}
 	if i.failed > 0 { // Some artifacts fail to be downloaded.
-		return false, true
+		return false, true, nil
 	}
 	if !i.configSaved { // Config is not yet fully analyzed and saved.
-		return false, false
+		return false, false, nil
+	}
+	if i.processing { // Images are already being processed.
+		return false, false, nil
 	}
 	if i.ready == i.paths { // All artifacts are ready.
-		return true, true
+		i.processing = len(i.images) > 0
+		return true, true, i.images
 	}
-	return false, false
+	return false, false, nil
 }
 
 // sendIfReady sends an answer to the Controller if it is ready.
 func (h *DownloaderImpl) sendIfReady(j weles.JobID) {
-	success, send := h.verify(j)
+	success, send, images := h.verify(j)
 
 	if !send {
 		return
 	}
 
-	if success {
-		h.succeed(j)
-	} else {
+	switch {
+	case !success:
 		h.fail(j, formatDownload)
+	case len(images) > 0:
+		go h.processImages(j, images)
+	default:
+		h.succeed(j)
+	}
+}
+
+// redactURI returns uri with password removed, so that it can be shown in Job info.
+func redactURI(uri string) string {
+	return downloader.RedactURI(weles.ArtifactURI(uri))
+}
+
+// processImages verifies downloaded images against their checksums, decompresses
+// them and answers the Controller. It is run in a separate goroutine as processing
+// of big images takes time.
+func (h *DownloaderImpl) processImages(j weles.JobID, images []imageProcessing) {
+	for _, img := range images {
+		if img.checksumPath != "" {
+			err := h.verifyChecksum(img.source, img.checksumPath, img.checksumType, img.uri)
+			if err != nil {
+				h.failImage(j, img, fmt.Sprintf(formatChecksum, img.alias, redactURI(img.uri),
+					err.Error()))
+				return
+			}
+		}
+		if img.compression != "" {
+			err := h.decompressImage(j, img)
+			if err != nil {
+				h.failImage(j, img, fmt.Sprintf(formatDecompress, img.alias, redactURI(img.uri),
+					err.Error()))
+				return
+			}
+		}
+	}
+	h.succeed(j)
+}
+
+// decompressImage replaces downloaded image with its decompressed content.
+// Compressed file is stored as a separate artifact if it should be kept.
+// Shared images are decompressed to the Job's own artifact and are kept intact.
+func (h *DownloaderImpl) decompressImage(j weles.JobID, img imageProcessing) error {
+	tmp := img.path + compression.TempSuffix
+	err := h.decompress(img.source, tmp, img.compression)
+	if err != nil {
+		removeFile(tmp)
+		return err
+	}
+	if img.keepCompressed && !img.shared {
+		var p weles.ArtifactPath
+		p, err = h.artifacts.CreateArtifact(weles.ArtifactDescription{
+			JobID: j,
+			Type:  weles.ArtifactTypeIMAGE,
+			Alias: weles.ArtifactAlias(img.alias + "." + img.compression),
+			URI:   weles.ArtifactURI(img.uri),
+		})
+		if err == nil {
+			err = os.Rename(img.path, string(p))
+		}
+		if err != nil {
+			removeFile(tmp)
+			return err
+		}
+		err = h.artifacts.SetArtifactStatus(weles.ArtifactStatusChange{
+			Path:      p,
+			NewStatus: weles.ArtifactStatusREADY,
+		})
+		if err != nil {
+			log.Println("failed to set status of artifact: " + err.Error())
+		}
+	}
+	err = os.Rename(tmp, img.path)
+	if err != nil {
+		return err
+	}
+	// Setting status again refreshes stored size and digest of the image.
+	err = h.artifacts.SetArtifactStatus(weles.ArtifactStatusChange{
+		Path:      weles.ArtifactPath(img.path),
+		NewStatus: weles.ArtifactStatusREADY,
+	})
+	if err != nil {
+		log.Println("failed to set status of artifact: " + err.Error())
+	}
+	return nil
+}
+
+// failImage marks image artifact of the Job as failed and responses failure
+// to Controller. Shared images are not marked.
+func (h *DownloaderImpl) failImage(j weles.JobID, img imageProcessing, msg string) {
+	if !img.shared || img.path != img.source {
+		err := h.artifacts.SetArtifactStatus(weles.ArtifactStatusChange{
+			Path:      weles.ArtifactPath(img.path),
+			NewStatus: weles.ArtifactStatusFAILED,
+		})
+		if err != nil {
+			log.Println("failed to set status of artifact: " + err.Error())
+		}
+	}
+	h.fail(j, msg)
+}
+
+// removeFile removes file logging failure.
+func removeFile(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Println("failed to remove file: " + err.Error())
 	}
 }
 
@@ -287,25 +570,49 @@ func (h *DownloaderImpl) DispatchDownloads(j weles.JobID) {
 	}
 
 	for i, image := range config.Action.Deploy.Images {
+		alias := fmt.Sprintf("Image_%d", i)
+		var source string
+		shared := strings.HasPrefix(image.URI, weles.UploadedArtifactURIPrefix)
 		if image.URI != "" {
 			var path string
-			path, err = h.push(j, weles.ArtifactTypeIMAGE, fmt.Sprintf("Image_%d", i), image.URI)
+			path, err = h.push(j, config.Priority, weles.ArtifactTypeIMAGE, alias, image.URI)
 			if err != nil {
-				h.fail(j, fmt.Sprintf(formatURI, image.URI, err.Error()))
+				h.fail(j, fmt.Sprintf(formatURI, redactURI(image.URI), err.Error()))
 				return
 			}
+			source = path
+			if shared && image.Compression != "" {
+				path, err = h.imageCreate(j, alias, image.URI)
+				if err != nil {
+					h.fail(j, fmt.Sprintf(formatPath, err.Error()))
+					return
+				}
+			}
 			config.Action.Deploy.Images[i].Path = path
 		}
 		if image.ChecksumURI != "" {
 			var path string
-			path, err = h.push(j, weles.ArtifactTypeIMAGE, fmt.Sprintf("ImageMD5_%d", i),
-				image.ChecksumURI)
+			path, err = h.push(j, config.Priority, weles.ArtifactTypeIMAGE,
+				fmt.Sprintf("ImageMD5_%d", i), image.ChecksumURI)
 			if err != nil {
-				h.fail(j, fmt.Sprintf(formatURI, image.ChecksumURI, err.Error()))
+				h.fail(j, fmt.Sprintf(formatURI, redactURI(image.ChecksumURI), err.Error()))
 				return
 			}
 			config.Action.Deploy.Images[i].ChecksumPath = path
 		}
+		if image.URI != "" && (image.ChecksumURI != "" || image.Compression != "") {
+			h.addImageProcessing(j, imageProcessing{
+				alias:          alias,
+				uri:            image.URI,
+				path:           config.Action.Deploy.Images[i].Path,
+				source:         source,
+				shared:         shared,
+				checksumPath:   config.Action.Deploy.Images[i].ChecksumPath,
+				checksumType:   image.ChecksumType,
+				compression:    image.Compression,
+				keepCompressed: image.KeepCompressed,
+			})
+		}
 	}
 	var path string
 	for i, tc := range config.Action.Test.TestCases {
@@ -313,9 +620,10 @@ func (h *DownloaderImpl) DispatchDownloads(j weles.JobID) {
 			switch ta.(type) {
 			case weles.Push:
 				action := ta.(weles.Push)
-				path, err = h.push(j, weles.ArtifactTypeTEST, action.Alias, action.URI)
+				path, err = h.push(j, config.Priority, weles.ArtifactTypeTEST, action.Alias,
+					action.URI)
 				if err != nil {
-					h.fail(j, fmt.Sprintf(formatURI, action.URI, err.Error()))
+					h.fail(j, fmt.Sprintf(formatURI, redactURI(action.URI), err.Error()))
 					return
 				}
 				action.Path = path
@@ -34,6 +34,16 @@ func (m *MockDownloader) EXPECT() *MockDownloaderMockRecorder {
 	return m.recorder
 }
 
+// CancelJob mocks base method
+func (m *MockDownloader) CancelJob(arg0 weles.JobID) {
+	m.ctrl.Call(m, "CancelJob", arg0)
+}
+
+// CancelJob indicates an expected call of CancelJob
+func (mr *MockDownloaderMockRecorder) CancelJob(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockDownloader)(nil).CancelJob), arg0)
+}
+
 // DispatchDownloads mocks base method
 func (m *MockDownloader) DispatchDownloads(arg0 weles.JobID) {
 	m.ctrl.Call(m, "DispatchDownloads", arg0)
@@ -20,6 +20,8 @@ package mock
 
 //go:generate ../../bin/dev-tools/mockgen -package mock -destination=./requests.go github.com/SamsungSLAV/boruta Requests
 
+//go:generate ../../bin/dev-tools/mockgen -package mock -destination=./workers.go github.com/SamsungSLAV/boruta Workers
+
 //go:generate ../../bin/dev-tools/mockgen -package mock -destination=./boruter.go github.com/SamsungSLAV/weles/controller Boruter
 
 //go:generate ../../bin/dev-tools/mockgen -package mock -destination=./downloader.go github.com/SamsungSLAV/weles/controller Downloader
@@ -27,3 +29,5 @@ package mock
 //go:generate ../../bin/dev-tools/mockgen -package mock -destination=./dryader.go github.com/SamsungSLAV/weles/controller Dryader
 
 //go:generate ../../bin/dev-tools/mockgen -package mock -destination=./parser.go github.com/SamsungSLAV/weles/controller Parser
+
+//go:generate ../../bin/dev-tools/mockgen -package mock -destination=./validator.go github.com/SamsungSLAV/weles/controller Validator
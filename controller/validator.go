@@ -0,0 +1,31 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File controller/validator.go defines interface for validating Job's yaml
+// file without creating a Job.
+
+package controller
+
+import (
+	"github.com/SamsungSLAV/weles"
+)
+
+// Validator checks if a Job described in yaml could be run without creating it.
+type Validator interface {
+	// Validate renders yaml with vars, parses it and verifies resources required
+	// by the Job.
+	Validate(yaml []byte, vars map[string]string) weles.JobValidation
+}
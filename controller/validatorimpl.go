@@ -0,0 +1,210 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File controller/validatorimpl.go implements Validator.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/SamsungSLAV/boruta"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/parser"
+)
+
+// uriCheckTimeout limits time of checking if a single URI is reachable.
+const uriCheckTimeout = 10 * time.Second
+
+const (
+	formatNoDevice    = "No device of type %q in Boruta"
+	formatDeviceCheck = "Cannot verify device_type %q in Boruta : %s"
+	formatUnreachable = "URI:<%s> is not reachable : %s"
+)
+
+// ValidatorImpl implements Validator. It runs the same parser as used for
+// creating Jobs, checks if Boruta has devices of requested type and if
+// artifacts are reachable.
+type ValidatorImpl struct {
+	// parser creates Job's recipe from yaml.
+	parser weles.Parser
+	// workers lists Dryads registered in Boruta.
+	workers boruta.Workers
+	// snippets provides snippets included in yaml.
+	snippets weles.SnippetManager
+	// client sends HEAD requests to check if URIs of artifacts are reachable.
+	client *http.Client
+}
+
+// NewValidator creates a new ValidatorImpl structure setting up references
+// to used Weles and Boruta modules. If client is nil, http.DefaultClient is used.
+func NewValidator(p weles.Parser, w boruta.Workers, s weles.SnippetManager,
+	client *http.Client) Validator {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &ValidatorImpl{
+		parser:   p,
+		workers:  w,
+		snippets: s,
+		client:   client,
+	}
+}
+
+// Validate is part of implementation of Validator interface. Included snippets
+// are resolved and placeholders are rendered before parsing, as on creation of
+// a Job. Resources are verified only if yaml is parsed successfully. Unavailable
+// devices are reported as errors. Unreachable URIs are reported as warnings as they
+// might become available before the Job is run.
+func (h *ValidatorImpl) Validate(yaml []byte, vars map[string]string) weles.JobValidation {
+	ret := weles.JobValidation{
+		Errors:   []*weles.ValidationIssue{},
+		Warnings: []*weles.ValidationIssue{},
+	}
+	expanded, err := parser.Include(yaml, h.snippets)
+	if err != nil {
+		ret.Errors = append(ret.Errors, parseIssues(err)...)
+		return ret
+	}
+	rendered, err := parser.Render(expanded, vars)
+	if err != nil {
+		ret.Errors = append(ret.Errors, parseIssues(err)...)
+		return ret
+	}
+	conf, err := h.parser.ParseYaml(rendered)
+	if err != nil {
+		ret.Errors = append(ret.Errors, parseIssues(err)...)
+		return ret
+	}
+	h.checkDevice(conf.DeviceType, &ret)
+	h.checkURIs(artifactURIs(conf), &ret)
+	ret.Valid = len(ret.Errors) == 0
+	return ret
+}
+
+// parseIssues converts error returned by parser to issues.
+func parseIssues(err error) []*weles.ValidationIssue {
+	verrs, ok := err.(parser.ValidationErrors)
+	if !ok {
+		return []*weles.ValidationIssue{{Message: err.Error()}}
+	}
+	ret := make([]*weles.ValidationIssue, len(verrs))
+	for i, e := range verrs {
+		ret[i] = &weles.ValidationIssue{
+			Line:    int64(e.Line),
+			Column:  int64(e.Column),
+			Message: e.Msg,
+		}
+	}
+	return ret
+}
+
+// checkDevice verifies if there are Dryads of given type registered in Boruta.
+func (h *ValidatorImpl) checkDevice(deviceType string, ret *weles.JobValidation) {
+	if deviceType == "" {
+		return
+	}
+	workers, err := h.workers.ListWorkers(nil, boruta.Capabilities{"device_type": deviceType})
+	switch {
+	case err != nil:
+		ret.Warnings = append(ret.Warnings, &weles.ValidationIssue{
+			Message: fmt.Sprintf(formatDeviceCheck, deviceType, err.Error()),
+		})
+	case len(workers) == 0:
+		ret.Errors = append(ret.Errors, &weles.ValidationIssue{
+			Message: fmt.Sprintf(formatNoDevice, deviceType),
+		})
+	}
+}
+
+// artifactURIs returns URIs of artifacts downloaded for the Job without duplicates.
+func artifactURIs(conf *weles.Config) []string {
+	var uris []string
+	seen := make(map[string]bool)
+	add := func(uri string) {
+		if uri != "" && !seen[uri] {
+			seen[uri] = true
+			uris = append(uris, uri)
+		}
+	}
+	for _, image := range conf.Action.Deploy.Images {
+		add(image.URI)
+		add(image.ChecksumURI)
+	}
+	for _, tc := range conf.Action.Test.TestCases {
+		for _, ta := range tc.TestActions {
+			if push, ok := ta.(weles.Push); ok {
+				add(push.URI)
+			}
+		}
+	}
+	return uris
+}
+
+// checkURIs sends HEAD requests to all HTTP URIs concurrently. URIs of other
+// schemes are not checked.
+func (h *ValidatorImpl) checkURIs(uris []string, ret *weles.JobValidation) {
+	errs := make([]error, len(uris))
+	var wg sync.WaitGroup
+	for i, uri := range uris {
+		u, err := url.Parse(uri)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, uri string) {
+			defer wg.Done()
+			errs[i] = h.head(uri)
+		}(i, uri)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			ret.Warnings = append(ret.Warnings, &weles.ValidationIssue{
+				Message: fmt.Sprintf(formatUnreachable, redactURI(uris[i]), err.Error()),
+			})
+		}
+	}
+}
+
+// head checks if uri is reachable. Servers not supporting HEAD method are assumed
+// to serve the uri.
+func (h *ValidatorImpl) head(uri string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uriCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodHead, uri, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := h.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	// Response of HEAD request has no body.
+	_ = resp.Body.Close() // nolint:gosec
+	if resp.StatusCode >= http.StatusBadRequest &&
+		resp.StatusCode != http.StatusMethodNotAllowed {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/SamsungSLAV/boruta"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	cmock "github.com/SamsungSLAV/weles/controller/mock"
+	"github.com/SamsungSLAV/weles/mock"
+	"github.com/SamsungSLAV/weles/parser"
+)
+
+var _ = Describe("ValidatorImpl", func() {
+	var (
+		ctrl *gomock.Controller
+		yap  *mock.MockParser
+		wor  *cmock.MockWorkers
+		snm  *mock.MockSnippetManager
+		ts   *httptest.Server
+		h    Validator
+	)
+	yaml := []byte("test yaml")
+	caps := boruta.Capabilities{"device_type": "qemu"}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		yap = mock.NewMockParser(ctrl)
+		wor = cmock.NewMockWorkers(ctrl)
+		snm = mock.NewMockSnippetManager(ctrl)
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodHead))
+			switch r.URL.Path {
+			case "/missing":
+				w.WriteHeader(http.StatusNotFound)
+			case "/nohead":
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}))
+		h = NewValidator(yap, wor, snm, nil)
+	})
+
+	AfterEach(func() {
+		ts.Close()
+		ctrl.Finish()
+	})
+
+	config := func(uris ...string) *weles.Config {
+		conf := &weles.Config{DeviceType: "qemu"}
+		for _, uri := range uris {
+			conf.Action.Deploy.Images = append(conf.Action.Deploy.Images,
+				weles.ImageDefinition{URI: uri, ChecksumURI: uri})
+		}
+		conf.Action.Test.TestCases = []weles.TestCase{{TestActions: []weles.TestAction{
+			weles.Push{URI: "weles://artifact/1"},
+			weles.Push{URI: "file:///tmp/local"},
+		}}}
+		return conf
+	}
+
+	It("should accept reachable artifacts and available device", func() {
+		yap.EXPECT().ParseYaml(yaml).Return(config(ts.URL+"/ok", ts.URL+"/nohead"), nil)
+		wor.EXPECT().ListWorkers(nil, caps).Return([]boruta.WorkerInfo{{}}, nil)
+
+		Expect(h.Validate(yaml, nil)).To(Equal(weles.JobValidation{
+			Valid:    true,
+			Errors:   []*weles.ValidationIssue{},
+			Warnings: []*weles.ValidationIssue{},
+		}))
+	})
+
+	It("should warn about unreachable artifacts", func() {
+		yap.EXPECT().ParseYaml(yaml).Return(config(ts.URL+"/ok", ts.URL+"/missing"), nil)
+		wor.EXPECT().ListWorkers(nil, caps).Return([]boruta.WorkerInfo{{}}, nil)
+
+		Expect(h.Validate(yaml, nil)).To(Equal(weles.JobValidation{
+			Valid:  true,
+			Errors: []*weles.ValidationIssue{},
+			Warnings: []*weles.ValidationIssue{{
+				Message: "URI:<" + ts.URL + "/missing> is not reachable : 404 Not Found",
+			}},
+		}))
+	})
+
+	It("should report errors of parser with their positions", func() {
+		yap.EXPECT().ParseYaml(yaml).Return(nil, parser.ValidationErrors{
+			{Line: 3, Column: 7, Msg: `unknown field "timout"`},
+			{Msg: "Invalid timeout"},
+		})
+
+		Expect(h.Validate(yaml, nil)).To(Equal(weles.JobValidation{
+			Errors: []*weles.ValidationIssue{
+				{Line: 3, Column: 7, Message: `unknown field "timout"`},
+				{Message: "Invalid timeout"},
+			},
+			Warnings: []*weles.ValidationIssue{},
+		}))
+	})
+
+	It("should parse yaml with resolved includes", func() {
+		snm.EXPECT().GetSnippet("qemu-boot", int64(1)).Return(
+			weles.Snippet{Name: "qemu-boot", Version: 1, Content: "login: root\n"}, nil)
+		yap.EXPECT().ParseYaml([]byte("boot:\n  login: root\n")).Return(config(), nil)
+		wor.EXPECT().ListWorkers(nil, caps).Return([]boruta.WorkerInfo{{}}, nil)
+
+		Expect(h.Validate([]byte("boot:\n  include: qemu-boot@v1\n"), nil).Valid).To(BeTrue())
+	})
+
+	It("should parse yaml rendered with variables", func() {
+		yap.EXPECT().ParseYaml([]byte("device_type: rpi3\n")).Return(config(), nil)
+		wor.EXPECT().ListWorkers(nil, caps).Return([]boruta.WorkerInfo{{}}, nil)
+
+		Expect(h.Validate([]byte("device_type: ${DEVICE}\n"),
+			map[string]string{"DEVICE": "rpi3"}).Valid).To(BeTrue())
+	})
+
+	It("should report undefined variables", func() {
+		Expect(h.Validate([]byte("device_type: ${DEVICE}\n"), nil)).To(Equal(
+			weles.JobValidation{
+				Errors: []*weles.ValidationIssue{
+					{Line: 1, Column: 14, Message: `undefined variable "DEVICE"`},
+				},
+				Warnings: []*weles.ValidationIssue{},
+			}))
+	})
+
+	It("should report includes which cannot be resolved", func() {
+		snm.EXPECT().GetSnippet("qemu-boot", int64(0)).Return(
+			weles.Snippet{}, weles.ErrSnippetNotFound)
+
+		Expect(h.Validate([]byte("boot:\n  include: qemu-boot\n"), nil)).To(Equal(
+			weles.JobValidation{
+				Errors: []*weles.ValidationIssue{
+					{Line: 2, Column: 12, Message: `snippet "qemu-boot" not found`},
+				},
+				Warnings: []*weles.ValidationIssue{},
+			}))
+	})
+
+	It("should report other errors of parser", func() {
+		yap.EXPECT().ParseYaml(yaml).Return(nil, errors.New("parser error"))
+
+		Expect(h.Validate(yaml, nil).Errors).To(Equal([]*weles.ValidationIssue{
+			{Message: "parser error"},
+		}))
+	})
+
+	It("should fail if there are no devices of requested type", func() {
+		yap.EXPECT().ParseYaml(yaml).Return(config(), nil)
+		wor.EXPECT().ListWorkers(nil, caps).Return([]boruta.WorkerInfo{}, nil)
+
+		Expect(h.Validate(yaml, nil)).To(Equal(weles.JobValidation{
+			Errors:   []*weles.ValidationIssue{{Message: `No device of type "qemu" in Boruta`}},
+			Warnings: []*weles.ValidationIssue{},
+		}))
+	})
+
+	It("should warn if Boruta cannot be asked for devices", func() {
+		yap.EXPECT().ParseYaml(yaml).Return(config(), nil)
+		wor.EXPECT().ListWorkers(nil, caps).Return(nil, errors.New("connection refused"))
+
+		Expect(h.Validate(yaml, nil)).To(Equal(weles.JobValidation{
+			Valid:  true,
+			Errors: []*weles.ValidationIssue{},
+			Warnings: []*weles.ValidationIssue{{
+				Message: `Cannot verify device_type "qemu" in Boruta : connection refused`,
+			}},
+		}))
+	})
+})
@@ -37,6 +37,17 @@ var (
 		"setting both before and after qeury parameters is not allowed")
 	// ErrArtifactNotFound is returned by API when no artifact is returned by ArtifactManager
 	ErrArtifactNotFound = errors.New("artifact not found")
+	// ErrArtifactNotReady is returned when artifact exists in ArtifactDB, but it cannot
+	// be used yet (e.g. it is still being downloaded).
+	ErrArtifactNotReady = errors.New("artifact not ready")
+	// ErrArtifactInUse is returned when artifact cannot be deleted, because it belongs
+	// to or is used by an active Job.
+	ErrArtifactInUse = errors.New("artifact is used by an active job")
+	// ErrNotAuthorized is returned by API when request requires administrator token
+	// which is missing or invalid.
+	ErrNotAuthorized = errors.New("valid administrator token is required")
+	// ErrSnippetNotFound is returned when requested version of snippet does not exist.
+	ErrSnippetNotFound = errors.New("snippet not found")
 )
 
 // ErrInvalidArgument is returned when argument passed to public API cannot
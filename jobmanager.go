@@ -22,8 +22,10 @@ package weles
 // by external modules. These methods are intended to be used by HTTP server.
 type JobManager interface {
 	// CreateJob creates a new Job in Weles using recipe passed in YAML format.
-	// It returns ID of created Job or error.
-	CreateJob(yaml []byte) (JobID, error)
+	// Placeholders in the recipe and in snippets included by it are replaced with
+	// values of vars first. It returns ID of created Job or error. ErrInvalidArgument
+	// is returned if a placeholder cannot be rendered or snippet cannot be included.
+	CreateJob(yaml []byte, vars map[string]string) (JobID, error)
 	// CancelJob stops execution of Job identified by JobID.
 	CancelJob(JobID) error
 	// ListJobs returns information on Jobs. It takes 3 arguments:
@@ -33,4 +35,9 @@ type JobManager interface {
 	// contains information about direction of listing and the size of the returned page which
 	// must always be set.
 	ListJobs(JobFilter, JobSorter, JobPagination) ([]JobInfo, ListInfo, error)
+	// ValidateJob checks Job's recipe passed in YAML format without creating a Job.
+	// The recipe is rendered with vars the same way as by CreateJob. Problems found
+	// in the recipe are returned in JobValidation, error is returned only if validation
+	// could not be done.
+	ValidateJob(yaml []byte, vars map[string]string) (JobValidation, error)
 }
@@ -30,14 +30,18 @@ type DryadJobs struct {
 	jobs           map[weles.JobID]*dryadJob
 	jobsMutex      *sync.RWMutex
 	artifactDBPath string
+	artifacts      weles.ArtifactManager
 }
 
 // NewDryadJobManager returns DryadJobManager interface of a new instance of DryadJobs.
-func NewDryadJobManager(artifactDBPath string) weles.DryadJobManager {
+// Statuses of artifacts pulled from Dryads are set in artifacts.
+func NewDryadJobManager(artifactDBPath string, artifacts weles.ArtifactManager,
+) weles.DryadJobManager {
 	return &DryadJobs{
 		jobs:           make(map[weles.JobID]*dryadJob),
 		jobsMutex:      new(sync.RWMutex),
 		artifactDBPath: artifactDBPath,
+		artifacts:      artifacts,
 	}
 }
 
@@ -52,7 +56,7 @@ func (d *DryadJobs) Create(job weles.JobID, rusalka weles.Dryad, conf weles.Conf
 	d.jobsMutex.Lock()
 	defer d.jobsMutex.Unlock()
 	// FIXME(amistewicz): dryadJobs should not be stored indefinitely.
-	d.jobs[job] = newDryadJob(job, rusalka, conf, changes, d.artifactDBPath)
+	d.jobs[job] = newDryadJob(job, rusalka, conf, changes, d.artifactDBPath, d.artifacts)
 	return nil
 }
 
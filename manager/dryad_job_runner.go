@@ -29,21 +29,24 @@ import (
 // dryadJobRunner implements DryadJobRunner interface.
 type dryadJobRunner struct {
 	DryadJobRunner
-	ctx     context.Context
-	rusalka dryad.SessionProvider
-	device  dryad.DeviceCommunicationProvider
-	conf    weles.Config
+	ctx       context.Context
+	rusalka   dryad.SessionProvider
+	device    dryad.DeviceCommunicationProvider
+	conf      weles.Config
+	artifacts weles.ArtifactManager
 }
 
 // newDryadJobRunner prepares a new instance of dryadJobRunner
 // and returns DryadJobRunner interface to it.
 func newDryadJobRunner(ctx context.Context, rusalka dryad.SessionProvider,
-	device dryad.DeviceCommunicationProvider, conf weles.Config) DryadJobRunner {
+	device dryad.DeviceCommunicationProvider, conf weles.Config,
+	artifacts weles.ArtifactManager) DryadJobRunner {
 	return &dryadJobRunner{
-		ctx:     ctx,
-		rusalka: rusalka,
-		device:  device,
-		conf:    conf,
+		ctx:       ctx,
+		rusalka:   rusalka,
+		device:    device,
+		conf:      conf,
+		artifacts: artifacts,
 	}
 }
 
@@ -113,7 +116,9 @@ func (d *dryadJobRunner) Test() error {
 					return err
 				}
 			case weles.Pull:
-				if err := d.device.CopyFilesFrom([]string{action.Src}, action.Path); err != nil {
+				err := d.device.CopyFilesFrom([]string{action.Src}, action.Path)
+				d.setPulled(action.Path, err)
+				if err != nil {
 					log.Println("Failed to copy files from DUT", err)
 					return err
 				}
@@ -124,3 +129,18 @@ func (d *dryadJobRunner) Test() error {
 	}
 	return nil
 }
+
+// setPulled marks artifact in path READY if it was pulled successfully or FAILED otherwise.
+func (d *dryadJobRunner) setPulled(path string, pullErr error) {
+	status := weles.ArtifactStatusREADY
+	if pullErr != nil {
+		status = weles.ArtifactStatusFAILED
+	}
+	err := d.artifacts.SetArtifactStatus(weles.ArtifactStatusChange{
+		Path:      weles.ArtifactPath(path),
+		NewStatus: status,
+	})
+	if err != nil {
+		log.Println("Failed to set status of pulled artifact", err)
+	}
+}
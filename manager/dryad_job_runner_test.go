@@ -18,11 +18,13 @@ package manager
 
 import (
 	"context"
+	"errors"
 
 	"github.com/SamsungSLAV/weles"
 	"github.com/SamsungSLAV/weles/manager/dryad"
 	dmock "github.com/SamsungSLAV/weles/manager/dryad/mock"
 	"github.com/SamsungSLAV/weles/manager/mock"
+	wmock "github.com/SamsungSLAV/weles/mock"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -33,6 +35,7 @@ var _ = Describe("DryadJobRunner", func() {
 	var (
 		mockSession *dmock.MockSessionProvider
 		mockDevice  *mock.MockDeviceCommunicationProvider
+		mockAM      *wmock.MockArtifactManager
 		ctrl        *gomock.Controller
 		djr         DryadJobRunner
 	)
@@ -41,7 +44,9 @@ var _ = Describe("DryadJobRunner", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockSession = dmock.NewMockSessionProvider(ctrl)
 		mockDevice = mock.NewMockDeviceCommunicationProvider(ctrl)
-		djr = newDryadJobRunner(context.Background(), mockSession, mockDevice, weles.Config{})
+		mockAM = wmock.NewMockArtifactManager(ctrl)
+		djr = newDryadJobRunner(context.Background(), mockSession, mockDevice, weles.Config{},
+			mockAM)
 	})
 
 	AfterEach(func() {
@@ -49,7 +54,8 @@ var _ = Describe("DryadJobRunner", func() {
 	})
 
 	It("should execute the basic weles job definition", func() {
-		djr = newDryadJobRunner(context.Background(), mockSession, mockDevice, basicConfig)
+		djr = newDryadJobRunner(context.Background(), mockSession, mockDevice, basicConfig,
+			mockAM)
 		By("Deploy")
 		gomock.InOrder(
 			mockSession.EXPECT().TS(),
@@ -83,8 +89,31 @@ var _ = Describe("DryadJobRunner", func() {
 			mockDevice.EXPECT().CopyFilesFrom(
 				[]string{basicConfig.Action.Test.TestCases[0].TestActions[2].(weles.Pull).Src},
 				basicConfig.Action.Test.TestCases[0].TestActions[2].(weles.Pull).Path),
+			mockAM.EXPECT().SetArtifactStatus(weles.ArtifactStatusChange{
+				Path: weles.ArtifactPath(
+					basicConfig.Action.Test.TestCases[0].TestActions[2].(weles.Pull).Path),
+				NewStatus: weles.ArtifactStatusREADY,
+			}),
 		)
 
 		Expect(djr.Test()).To(Succeed())
 	})
+
+	It("should mark artifact as failed if pull fails", func() {
+		err := errors.New("sapphireOtter")
+		pull := weles.Pull{Src: "src", Path: "path"}
+		djr = newDryadJobRunner(context.Background(), mockSession, mockDevice,
+			weles.Config{Action: weles.Action{Test: weles.Test{TestCases: []weles.TestCase{
+				{TestActions: []weles.TestAction{pull}},
+			}}}}, mockAM)
+		gomock.InOrder(
+			mockDevice.EXPECT().CopyFilesFrom([]string{pull.Src}, pull.Path).Return(err),
+			mockAM.EXPECT().SetArtifactStatus(weles.ArtifactStatusChange{
+				Path:      weles.ArtifactPath(pull.Path),
+				NewStatus: weles.ArtifactStatusFAILED,
+			}),
+		)
+
+		Expect(djr.Test()).To(Equal(err))
+	})
 })
@@ -36,5 +36,7 @@ type DryadJobRunner interface {
 	// Push - deploy additional content,
 	// Execute - run requested commands,
 	// Collect - gather results.
+	//
+	// Artifacts of collected results are marked READY or FAILED in ArtifactManager.
 	Test() error
 }
@@ -7,6 +7,7 @@ package mock
 import (
 	weles "github.com/SamsungSLAV/weles"
 	gomock "github.com/golang/mock/gomock"
+	io "io"
 	reflect "reflect"
 )
 
@@ -33,6 +34,18 @@ func (m *MockArtifactManager) EXPECT() *MockArtifactManagerMockRecorder {
 	return m.recorder
 }
 
+// CancelJobArtifacts mocks base method
+func (m *MockArtifactManager) CancelJobArtifacts(arg0 weles.JobID) error {
+	ret := m.ctrl.Call(m, "CancelJobArtifacts", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelJobArtifacts indicates an expected call of CancelJobArtifacts
+func (mr *MockArtifactManagerMockRecorder) CancelJobArtifacts(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJobArtifacts", reflect.TypeOf((*MockArtifactManager)(nil).CancelJobArtifacts), arg0)
+}
+
 // Close mocks base method
 func (m *MockArtifactManager) Close() error {
 	ret := m.ctrl.Call(m, "Close")
@@ -58,6 +71,43 @@ func (mr *MockArtifactManagerMockRecorder) CreateArtifact(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArtifact", reflect.TypeOf((*MockArtifactManager)(nil).CreateArtifact), arg0)
 }
 
+// DeleteArtifact mocks base method
+func (m *MockArtifactManager) DeleteArtifact(arg0 int64) error {
+	ret := m.ctrl.Call(m, "DeleteArtifact", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArtifact indicates an expected call of DeleteArtifact
+func (mr *MockArtifactManagerMockRecorder) DeleteArtifact(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArtifact", reflect.TypeOf((*MockArtifactManager)(nil).DeleteArtifact), arg0)
+}
+
+// DeleteJobArtifacts mocks base method
+func (m *MockArtifactManager) DeleteJobArtifacts(arg0 weles.JobID) error {
+	ret := m.ctrl.Call(m, "DeleteJobArtifacts", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteJobArtifacts indicates an expected call of DeleteJobArtifacts
+func (mr *MockArtifactManagerMockRecorder) DeleteJobArtifacts(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJobArtifacts", reflect.TypeOf((*MockArtifactManager)(nil).DeleteJobArtifacts), arg0)
+}
+
+// GetArtifactAttempts mocks base method
+func (m *MockArtifactManager) GetArtifactAttempts(arg0 weles.ArtifactPath) ([]weles.ArtifactAttempt, error) {
+	ret := m.ctrl.Call(m, "GetArtifactAttempts", arg0)
+	ret0, _ := ret[0].([]weles.ArtifactAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtifactAttempts indicates an expected call of GetArtifactAttempts
+func (mr *MockArtifactManagerMockRecorder) GetArtifactAttempts(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactAttempts", reflect.TypeOf((*MockArtifactManager)(nil).GetArtifactAttempts), arg0)
+}
+
 // GetArtifactInfo mocks base method
 func (m *MockArtifactManager) GetArtifactInfo(arg0 weles.ArtifactPath) (weles.ArtifactInfo, error) {
 	ret := m.ctrl.Call(m, "GetArtifactInfo", arg0)
@@ -71,6 +121,19 @@ func (mr *MockArtifactManagerMockRecorder) GetArtifactInfo(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactInfo", reflect.TypeOf((*MockArtifactManager)(nil).GetArtifactInfo), arg0)
 }
 
+// GetArtifactInfoByID mocks base method
+func (m *MockArtifactManager) GetArtifactInfoByID(arg0 int64) (weles.ArtifactInfo, error) {
+	ret := m.ctrl.Call(m, "GetArtifactInfoByID", arg0)
+	ret0, _ := ret[0].(weles.ArtifactInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtifactInfoByID indicates an expected call of GetArtifactInfoByID
+func (mr *MockArtifactManagerMockRecorder) GetArtifactInfoByID(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactInfoByID", reflect.TypeOf((*MockArtifactManager)(nil).GetArtifactInfoByID), arg0)
+}
+
 // ListArtifact mocks base method
 func (m *MockArtifactManager) ListArtifact(arg0 weles.ArtifactFilter, arg1 weles.ArtifactSorter, arg2 weles.ArtifactPagination) ([]weles.ArtifactInfo, weles.ListInfo, error) {
 	ret := m.ctrl.Call(m, "ListArtifact", arg0, arg1, arg2)
@@ -86,14 +149,64 @@ func (mr *MockArtifactManagerMockRecorder) ListArtifact(arg0, arg1, arg2 interfa
 }
 
 // PushArtifact mocks base method
-func (m *MockArtifactManager) PushArtifact(arg0 weles.ArtifactDescription, arg1 chan weles.ArtifactStatusChange) (weles.ArtifactPath, error) {
-	ret := m.ctrl.Call(m, "PushArtifact", arg0, arg1)
+func (m *MockArtifactManager) PushArtifact(arg0 weles.ArtifactDescription, arg1 weles.Priority, arg2 chan weles.ArtifactStatusChange) (weles.ArtifactPath, error) {
+	ret := m.ctrl.Call(m, "PushArtifact", arg0, arg1, arg2)
 	ret0, _ := ret[0].(weles.ArtifactPath)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PushArtifact indicates an expected call of PushArtifact
-func (mr *MockArtifactManagerMockRecorder) PushArtifact(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushArtifact", reflect.TypeOf((*MockArtifactManager)(nil).PushArtifact), arg0, arg1)
+func (mr *MockArtifactManagerMockRecorder) PushArtifact(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushArtifact", reflect.TypeOf((*MockArtifactManager)(nil).PushArtifact), arg0, arg1, arg2)
+}
+
+// SetArtifactPinned mocks base method
+func (m *MockArtifactManager) SetArtifactPinned(arg0 int64, arg1 bool) error {
+	ret := m.ctrl.Call(m, "SetArtifactPinned", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArtifactPinned indicates an expected call of SetArtifactPinned
+func (mr *MockArtifactManagerMockRecorder) SetArtifactPinned(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArtifactPinned", reflect.TypeOf((*MockArtifactManager)(nil).SetArtifactPinned), arg0, arg1)
+}
+
+// SetArtifactStatus mocks base method
+func (m *MockArtifactManager) SetArtifactStatus(arg0 weles.ArtifactStatusChange) error {
+	ret := m.ctrl.Call(m, "SetArtifactStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArtifactStatus indicates an expected call of SetArtifactStatus
+func (mr *MockArtifactManagerMockRecorder) SetArtifactStatus(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArtifactStatus", reflect.TypeOf((*MockArtifactManager)(nil).SetArtifactStatus), arg0)
+}
+
+// UploadArtifact mocks base method
+func (m *MockArtifactManager) UploadArtifact(arg0 weles.ArtifactDescription, arg1 io.Reader) (weles.ArtifactInfo, error) {
+	ret := m.ctrl.Call(m, "UploadArtifact", arg0, arg1)
+	ret0, _ := ret[0].(weles.ArtifactInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadArtifact indicates an expected call of UploadArtifact
+func (mr *MockArtifactManagerMockRecorder) UploadArtifact(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadArtifact", reflect.TypeOf((*MockArtifactManager)(nil).UploadArtifact), arg0, arg1)
+}
+
+// UseArtifact mocks base method
+func (m *MockArtifactManager) UseArtifact(arg0 int64, arg1 weles.JobID) (weles.ArtifactInfo, error) {
+	ret := m.ctrl.Call(m, "UseArtifact", arg0, arg1)
+	ret0, _ := ret[0].(weles.ArtifactInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseArtifact indicates an expected call of UseArtifact
+func (mr *MockArtifactManagerMockRecorder) UseArtifact(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseArtifact", reflect.TypeOf((*MockArtifactManager)(nil).UseArtifact), arg0, arg1)
 }
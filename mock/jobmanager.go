@@ -46,16 +46,16 @@ func (mr *MockJobManagerMockRecorder) CancelJob(arg0 interface{}) *gomock.Call {
 }
 
 // CreateJob mocks base method
-func (m *MockJobManager) CreateJob(arg0 []byte) (weles.JobID, error) {
-	ret := m.ctrl.Call(m, "CreateJob", arg0)
+func (m *MockJobManager) CreateJob(arg0 []byte, arg1 map[string]string) (weles.JobID, error) {
+	ret := m.ctrl.Call(m, "CreateJob", arg0, arg1)
 	ret0, _ := ret[0].(weles.JobID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateJob indicates an expected call of CreateJob
-func (mr *MockJobManagerMockRecorder) CreateJob(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockJobManager)(nil).CreateJob), arg0)
+func (mr *MockJobManagerMockRecorder) CreateJob(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockJobManager)(nil).CreateJob), arg0, arg1)
 }
 
 // ListJobs mocks base method
@@ -71,3 +71,16 @@ func (m *MockJobManager) ListJobs(arg0 weles.JobFilter, arg1 weles.JobSorter, ar
 func (mr *MockJobManagerMockRecorder) ListJobs(arg0, arg1, arg2 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockJobManager)(nil).ListJobs), arg0, arg1, arg2)
 }
+
+// ValidateJob mocks base method
+func (m *MockJobManager) ValidateJob(arg0 []byte, arg1 map[string]string) (weles.JobValidation, error) {
+	ret := m.ctrl.Call(m, "ValidateJob", arg0, arg1)
+	ret0, _ := ret[0].(weles.JobValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateJob indicates an expected call of ValidateJob
+func (mr *MockJobManagerMockRecorder) ValidateJob(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateJob", reflect.TypeOf((*MockJobManager)(nil).ValidateJob), arg0, arg1)
+}
@@ -23,3 +23,5 @@ package mock
 //go:generate ../bin/dev-tools/mockgen -package mock -destination=./jobmanager.go github.com/SamsungSLAV/weles JobManager
 
 //go:generate ../bin/dev-tools/mockgen -package mock -destination=./parser.go github.com/SamsungSLAV/weles Parser
+
+//go:generate ../bin/dev-tools/mockgen -package mock -destination=./snippetmanager.go github.com/SamsungSLAV/weles SnippetManager
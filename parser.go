@@ -81,10 +81,14 @@ type Pull struct {
 
 // ImageDefinition describes images required for the tests.
 type ImageDefinition struct {
+	// Name identifies image in partition layout.
+	Name         string `yaml:"name"`
 	URI          string `yaml:"uri"`
 	ChecksumURI  string `yaml:"checksum_uri"`
 	ChecksumType string `yaml:"checksum_type"`
 	Compression  string `yaml:"compression"`
+	// KeepCompressed requests storing downloaded compressed image as a separate artifact.
+	KeepCompressed bool `yaml:"keep_compressed"`
 
 	// Path defines ArtifactDB path. It's added for Controller purposes.
 	Path         string `yaml:"-"`
@@ -0,0 +1,244 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File parser/include.go contains resolving of snippets included in job's YAML.
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+const (
+	// includeKey is the key of mapping which is replaced by included snippet.
+	includeKey = "include"
+	// maxIncludes limits number of includes resolved in a single job.
+	maxIncludes = 1000
+	// maxIncludedSize limits approximate size (in bytes) of job with included snippets,
+	// as snippets included several times may expand exponentially.
+	maxIncludedSize = 1 << 20
+)
+
+// includePattern matches references to snippets: name@vN or name for the latest version.
+var includePattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(?:@v([1-9][0-9]*))?$`)
+
+// loadedSnippet is a resolved root node of snippet and its approximate size.
+type loadedSnippet struct {
+	root *yaml.Node
+	size int
+}
+
+// includer resolves includes of snippets in YAML nodes.
+type includer struct {
+	snippets weles.SnippetManager
+	errs     ValidationErrors
+	// err is set if snippet could not be got from SnippetManager.
+	err   error
+	found bool
+	// loaded maps name@vN to snippets which were already resolved, so that each one is
+	// parsed once. Nodes of such snippets are shared by all places including them.
+	loaded   map[string]loadedSnippet
+	includes int
+}
+
+// Include replaces mappings like {include: name@vN} in job's YAML with content of
+// snippets from the library. Mapping or list snippet replaces the whole mapping.
+// If the mapping is an item of a list and snippet is a list too, items of snippet are
+// inserted into the list. Snippets may include other snippets. Input without includes
+// and input which is not a valid YAML are returned unchanged (the latter is reported by
+// ParseYaml). ValidationErrors are returned if an include cannot be resolved.
+func Include(in []byte, snippets weles.SnippetManager) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(in, &doc); err != nil {
+		return in, nil
+	}
+	i := &includer{snippets: snippets, loaded: make(map[string]loadedSnippet)}
+	size := i.resolve(&doc, nil, nil)
+	if i.err != nil {
+		return nil, i.err
+	}
+	if size > maxIncludedSize && len(i.errs) == 0 {
+		i.errs = append(i.errs, ValidationError{Msg: fmt.Sprintf(
+			"job with included snippets exceeds %d bytes", maxIncludedSize)})
+	}
+	if len(i.errs) != 0 {
+		return nil, i.errs
+	}
+	if !i.found {
+		return in, nil
+	}
+	return encode(&doc)
+}
+
+// encode returns YAML of document node doc.
+func encode(doc *yaml.Node) ([]byte, error) {
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// errorf records problem at position of node n or of include at, if it is set.
+// The latter is used for problems found in included snippets.
+func (i *includer) errorf(n, at *yaml.Node, format string, args ...interface{}) {
+	if at != nil {
+		n = at
+	}
+	i.errs = append(i.errs, ValidationError{
+		Line:   n.Line,
+		Column: n.Column,
+		Msg:    fmt.Sprintf(format, args...),
+	})
+}
+
+// addSize adds sizes of nodes. The sum is capped just above maxIncludedSize,
+// so that it never overflows.
+func addSize(a, b int) int {
+	if a+b > maxIncludedSize {
+		return maxIncludedSize + 1
+	}
+	return a + b
+}
+
+// reference returns value of include if node n is a mapping consisting only of an include.
+func reference(n *yaml.Node) *yaml.Node {
+	if n.Kind != yaml.MappingNode || len(n.Content) != 2 || n.Content[0].Value != includeKey {
+		return nil
+	}
+	return n.Content[1]
+}
+
+// load returns resolved root node of snippet referenced by ref and its size. Stack lists
+// snippets which are being included to detect recursion. Nil is returned if snippet
+// cannot be included.
+func (i *includer) load(ref, at *yaml.Node, stack []string) (*yaml.Node, int) {
+	i.includes++
+	if i.includes > maxIncludes {
+		if i.includes == maxIncludes+1 {
+			i.errorf(ref, at, "too many includes, at most %d are allowed", maxIncludes)
+		}
+		return nil, 0
+	}
+	m := includePattern.FindStringSubmatch(ref.Value)
+	if ref.Kind != yaml.ScalarNode || m == nil {
+		i.errorf(ref, at, "invalid include %q, expected name@vN or name", ref.Value)
+		return nil, 0
+	}
+	var version int64
+	if m[2] != "" {
+		version, _ = strconv.ParseInt(m[2], 10, 64)
+	}
+	snippet, err := i.snippets.GetSnippet(m[1], version)
+	if err == weles.ErrSnippetNotFound {
+		i.errorf(ref, at, "snippet %q not found", ref.Value)
+		return nil, 0
+	} else if err != nil {
+		i.err = err
+		return nil, 0
+	}
+	name := fmt.Sprintf("%s@v%d", snippet.Name, snippet.Version)
+	for _, s := range stack {
+		if s == name {
+			i.errorf(ref, at, "recursive include of snippet %q", name)
+			return nil, 0
+		}
+	}
+	if l, ok := i.loaded[name]; ok {
+		return l.root, l.size
+	}
+
+	var l loadedSnippet
+	var doc yaml.Node
+	if err = yaml.Unmarshal([]byte(snippet.Content), &doc); err != nil ||
+		len(doc.Content) == 0 {
+		i.errorf(ref, at, "snippet %q is not a valid YAML", name)
+	} else {
+		if at == nil {
+			at = ref
+		}
+		l.root = doc.Content[0]
+		l.size = i.resolve(l.root, at, append(stack, name))
+	}
+	// Snippets which cannot be included are remembered too, so that their problems
+	// are reported once.
+	i.loaded[name] = l
+	return l.root, l.size
+}
+
+// resolve replaces includes in node n and its children. It returns approximate size
+// of resolved node.
+func (i *includer) resolve(n, at *yaml.Node, stack []string) int {
+	size := len(n.Value) + 1
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			size = addSize(size, i.resolve(c, at, stack))
+		}
+	case yaml.MappingNode:
+		if ref := reference(n); ref != nil {
+			i.found = true
+			root, rootSize := i.load(ref, at, stack)
+			if root != nil {
+				*n = *root
+			}
+			return rootSize
+		}
+		if field(n, includeKey) != nil {
+			i.errorf(n, at, "field %q must be the only field of a mapping", includeKey)
+		}
+		for j := 1; j < len(n.Content); j += 2 {
+			size = addSize(size, len(n.Content[j-1].Value)+1)
+			size = addSize(size, i.resolve(n.Content[j], at, stack))
+		}
+	case yaml.SequenceNode:
+		content := make([]*yaml.Node, 0, len(n.Content))
+		for _, item := range n.Content {
+			ref := reference(item)
+			if ref == nil {
+				size = addSize(size, i.resolve(item, at, stack))
+				content = append(content, item)
+				continue
+			}
+			i.found = true
+			root, rootSize := i.load(ref, at, stack)
+			size = addSize(size, rootSize)
+			switch {
+			// Items of large snippets are not inserted, as lists could grow exponentially.
+			// Such job is refused anyway.
+			case root == nil, size > maxIncludedSize:
+			case root.Kind == yaml.SequenceNode:
+				content = append(content, root.Content...)
+			default:
+				content = append(content, root)
+			}
+		}
+		n.Content = content
+	}
+	return size
+}
@@ -0,0 +1,178 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package parser_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/mock"
+	"github.com/SamsungSLAV/weles/parser"
+)
+
+var _ = Describe("Include", func() {
+	var (
+		ctrl *gomock.Controller
+		sm   *mock.MockSnippetManager
+	)
+
+	snippets := map[string]weles.Snippet{
+		"tizen-boot@v3": {Name: "tizen-boot", Version: 3,
+			Content: "boot:\n  login: root\n  prompts:\n    - '#'\n"},
+		"smoke@v1": {Name: "smoke", Version: 1,
+			Content: "- case_name: first\n- case_name: second\n"},
+		"deploy@v2": {Name: "deploy", Version: 2,
+			Content: "timeout:\n  minutes: 5\nimages:\n  - include: image@v1\n"},
+		"image@v1": {Name: "image", Version: 1,
+			Content: "uri: http://example.com/image.img\n"},
+		"loop@v1": {Name: "loop", Version: 1, Content: "- include: loop@v1\n"},
+		"ping@v1": {Name: "ping", Version: 1, Content: "- include: pong\n"},
+		"pong@v4": {Name: "pong", Version: 4, Content: "- include: missing@v1\n"},
+	}
+	latest := map[string]string{"pong": "pong@v4", "smoke": "smoke@v1"}
+	// Each version of double includes the next one twice, so version 1 expands to
+	// 2^40 items.
+	for v := 1; v <= 40; v++ {
+		snippets[fmt.Sprintf("double@v%d", v)] = weles.Snippet{Name: "double", Version: int64(v),
+			Content: fmt.Sprintf("- include: double@v%d\n- include: double@v%d\n", v+1, v+1)}
+	}
+	snippets["double@v41"] = weles.Snippet{Name: "double", Version: 41,
+		Content: "- case_name: " + strings.Repeat("x", 64) + "\n"}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		sm = mock.NewMockSnippetManager(ctrl)
+		sm.EXPECT().GetSnippet(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(name string, version int64) (weles.Snippet, error) {
+				key := latest[name]
+				if version != 0 {
+					key = fmt.Sprintf("%s@v%d", name, version)
+				}
+				s, ok := snippets[key]
+				if !ok {
+					return weles.Snippet{}, weles.ErrSnippetNotFound
+				}
+				return s, nil
+			})
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should resolve includes of mappings and list items", func() {
+		out, err := parser.Include([]byte(`job_name: includes
+actions:
+  - include: deploy@v2
+  - include: tizen-boot@v3
+  - test:
+      test_cases:
+        - include: smoke
+        - case_name: third
+`), sm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(`job_name: includes
+actions:
+  - timeout:
+      minutes: 5
+    images:
+      - uri: http://example.com/image.img
+  - boot:
+      login: root
+      prompts:
+        - '#'
+  - test:
+      test_cases:
+        - case_name: first
+        - case_name: second
+        - case_name: third
+`))
+	})
+
+	It("should return input without includes unchanged", func() {
+		in := []byte("job_name:   plain # comment\n")
+		out, err := parser.Include(in, sm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(Equal(in))
+
+		By("Invalid YAML should be left for ParseYaml")
+		in = []byte("job_name: [broken")
+		out, err = parser.Include(in, sm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(Equal(in))
+	})
+
+	DescribeTable("should report positions of unresolved includes",
+		func(in string, expected ...parser.ValidationError) {
+			out, err := parser.Include([]byte(in), sm)
+			Expect(out).To(BeNil())
+			Expect(err).To(Equal(parser.ValidationErrors(expected)))
+		},
+		Entry("missing snippet", "actions:\n  - include: tizen-boot@v2\n",
+			parser.ValidationError{Line: 2, Column: 14,
+				Msg: `snippet "tizen-boot@v2" not found`}),
+		Entry("invalid reference", "actions:\n  - boot:\n      include: tizen boot\n",
+			parser.ValidationError{Line: 3, Column: 16,
+				Msg: `invalid include "tizen boot", expected name@vN or name`}),
+		Entry("include with other fields", "actions:\n  - include: smoke\n    boot: {}\n",
+			parser.ValidationError{Line: 2, Column: 5,
+				Msg: `field "include" must be the only field of a mapping`}),
+		Entry("recursive include", "test_cases:\n  - include: ping@v1\n  - include: loop@v1\n",
+			parser.ValidationError{Line: 2, Column: 14,
+				Msg: `snippet "missing@v1" not found`},
+			parser.ValidationError{Line: 3, Column: 14,
+				Msg: `recursive include of snippet "loop@v1"`}),
+	)
+
+	It("should resolve snippet included many times", func() {
+		out, err := parser.Include([]byte("- include: double@v35\n"), sm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(strings.Count(string(out), "case_name")).To(Equal(64))
+	})
+
+	It("should refuse job exceeding size limit", func() {
+		out, err := parser.Include([]byte("test_cases:\n  - include: double@v1\n"), sm)
+		Expect(out).To(BeNil())
+		Expect(err).To(Equal(parser.ValidationErrors{{
+			Msg: "job with included snippets exceeds 1048576 bytes"}}))
+	})
+
+	It("should refuse too many includes", func() {
+		in := "test_cases:\n" + strings.Repeat("  - include: smoke\n", 1001)
+		out, err := parser.Include([]byte(in), sm)
+		Expect(out).To(BeNil())
+		Expect(err).To(Equal(parser.ValidationErrors{{Line: 1002, Column: 14,
+			Msg: "too many includes, at most 1000 are allowed"}}))
+	})
+
+	It("should return error of SnippetManager", func() {
+		smErr := errors.New("database is locked")
+		failing := mock.NewMockSnippetManager(ctrl)
+		failing.EXPECT().GetSnippet("smoke", int64(0)).Return(weles.Snippet{}, smErr)
+
+		out, err := parser.Include([]byte("- include: smoke\n"), failing)
+		Expect(out).To(BeNil())
+		Expect(err).To(Equal(smErr))
+	})
+})
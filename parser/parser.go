@@ -17,38 +17,40 @@
 package parser
 
 import (
-	"errors"
+	"bytes"
 
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 
 	"github.com/SamsungSLAV/weles"
 )
 
-func validatePriority(conf weles.Config) error {
-	switch conf.Priority {
-	case weles.LOW, weles.MEDIUM, weles.HIGH:
-	default:
-		return errors.New("invalid priority")
-	}
-	return nil
-}
-
 // Parser type implements Parser interface.
 type Parser struct{}
 
 // ParseYaml parses yaml content and validates the results.
-// Caller should make sure that input yaml fits to required format (sample_yaml),
-// if not an empty Config and error will be returned.
+// Input is validated against JobSchema first. Then URIs, checksum types, compression
+// formats and images referenced by partitions are checked and the input is decoded
+// strictly. If input does not fit to required format (sample_yaml), nil Config and
+// ValidationErrors describing positions of all problems found are returned.
 func (p *Parser) ParseYaml(in []byte) (*weles.Config, error) {
-	var conf weles.Config
-	err := yaml.Unmarshal(in, &conf)
+	v := &validator{root: new(yaml.Node)}
+	err := yaml.Unmarshal(in, v.root)
 	if err != nil {
-		return nil, err
+		v.decodeError(err)
+		return nil, v.sorted()
+	}
+	v.validate()
+	if len(v.errs) != 0 {
+		return nil, v.sorted()
 	}
 
-	err = validatePriority(conf)
+	var conf weles.Config
+	dec := yaml.NewDecoder(bytes.NewReader(in))
+	dec.KnownFields(true)
+	err = dec.Decode(&conf)
 	if err != nil {
-		return nil, err
+		v.decodeError(err)
+		return nil, v.sorted()
 	}
 
 	return &conf, nil
@@ -0,0 +1,469 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File parser/schema.go contains JSON Schema of job's YAML. It must be kept in sync with
+// weles.Config and the UnmarshalYAML methods of its fields.
+
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-openapi/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// JobSchema is a JSON Schema (draft-04) describing the format of job's YAML.
+// It is served by Weles, so editors can use it for autocompletion and linting of job files.
+const JobSchema = `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "title": "Weles job",
+  "description": "Description of a job executed by Weles.",
+  "type": "object",
+  "required": ["device_type", "job_name", "priority"],
+  "additionalProperties": false,
+  "properties": {
+    "device_type": {
+      "description": "Type of device (Boruta's device_type capability) the job is run on.",
+      "type": "string",
+      "minLength": 1
+    },
+    "job_name": {
+      "description": "Name of the job.",
+      "type": "string",
+      "minLength": 1
+    },
+    "timeouts": {
+      "description": "Default timeouts.",
+      "anyOf": [
+        {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "job": {
+              "description": "Timeout of the whole job.",
+              "$ref": "#/definitions/timeout"
+            },
+            "action": {
+              "description": "Default timeout of each action.",
+              "$ref": "#/definitions/timeout"
+            }
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "priority": {
+      "description": "Priority of the job.",
+      "type": "string",
+      "enum": ["low", "medium", "high"]
+    },
+    "actions": {
+      "description": "Actions executed on the device.",
+      "type": "array",
+      "items": {"$ref": "#/definitions/action"}
+    }
+  },
+  "definitions": {
+    "include": {
+      "description": "Snippet from the library included instead of the mapping or list item.",
+      "type": "object",
+      "required": ["include"],
+      "additionalProperties": false,
+      "properties": {
+        "include": {
+          "description": "Reference to snippet: name@vN or name for its latest version.",
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "timeout": {
+      "description": "Period of time given in a single unit.",
+      "anyOf": [
+        {
+          "type": "object",
+          "minProperties": 1,
+          "maxProperties": 1,
+          "additionalProperties": false,
+          "properties": {
+            "seconds": {"type": "integer", "minimum": 0},
+            "minutes": {"type": "integer", "minimum": 0},
+            "hours": {"type": "integer", "minimum": 0},
+            "days": {"type": "integer", "minimum": 0}
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "action": {
+      "anyOf": [
+        {
+          "type": "object",
+          "minProperties": 1,
+          "additionalProperties": false,
+          "properties": {
+            "deploy": {"$ref": "#/definitions/deploy"},
+            "boot": {"$ref": "#/definitions/boot"},
+            "test": {"$ref": "#/definitions/test"}
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "deploy": {
+      "description": "Images written to the device.",
+      "anyOf": [
+        {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "timeout": {"$ref": "#/definitions/timeout"},
+            "images": {
+              "type": "array",
+              "items": {"$ref": "#/definitions/image"}
+            },
+            "partition_layout": {
+              "type": "array",
+              "items": {"$ref": "#/definitions/partition"}
+            }
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "image": {
+      "anyOf": [
+        {
+          "type": "object",
+          "required": ["uri"],
+          "additionalProperties": false,
+          "properties": {
+            "name": {
+              "description": "Name referenced by partition_layout.",
+              "type": "string"
+            },
+            "uri": {"type": "string", "minLength": 1},
+            "checksum_uri": {"type": "string"},
+            "checksum_type": {
+              "description": "Type of checksum, e.g. md5 or sha256. Detected if empty.",
+              "type": "string"
+            },
+            "compression": {
+              "description": "Compression format of the image, e.g. gz, xz or zip.",
+              "type": "string"
+            },
+            "keep_compressed": {
+              "description": "Keep downloaded compressed image as a separate artifact.",
+              "type": "boolean"
+            }
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "partition": {
+      "anyOf": [
+        {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "id": {"type": "integer"},
+            "image_name": {
+              "description": "Name of the image written to the partition.",
+              "type": "string"
+            },
+            "size": {"type": ["string", "integer"]},
+            "type": {"type": "string"}
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "boot": {
+      "description": "Boot of the device.",
+      "anyOf": [
+        {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "login": {"type": "string"},
+            "password": {"type": "string"},
+            "prompts": {
+              "type": "array",
+              "items": {"type": "string"}
+            },
+            "failure_retry": {"type": "integer", "minimum": 0},
+            "timeout": {"$ref": "#/definitions/timeout"},
+            "input_sequence": {"type": "string"},
+            "wait_pattern": {"type": "string"},
+            "wait_time": {"$ref": "#/definitions/timeout"}
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "test": {
+      "description": "Test procedure.",
+      "anyOf": [
+        {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "failure_retry": {"type": "integer", "minimum": 0},
+            "name": {"type": "string"},
+            "timeout": {"$ref": "#/definitions/timeout"},
+            "test_cases": {
+              "type": "array",
+              "items": {"$ref": "#/definitions/test_case"}
+            }
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "test_case": {
+      "anyOf": [
+        {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "case_name": {"type": "string"},
+            "test_actions": {
+              "type": "array",
+              "items": {"$ref": "#/definitions/test_action"}
+            }
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "test_action": {
+      "anyOf": [
+        {
+          "type": "object",
+          "minProperties": 1,
+          "additionalProperties": false,
+          "properties": {
+            "boot": {"$ref": "#/definitions/boot"},
+            "push": {"$ref": "#/definitions/push"},
+            "run": {"$ref": "#/definitions/run"},
+            "pull": {"$ref": "#/definitions/pull"}
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "push": {
+      "description": "Copy of an artifact to the device.",
+      "anyOf": [
+        {
+          "type": "object",
+          "required": ["uri", "dest"],
+          "additionalProperties": false,
+          "properties": {
+            "uri": {"type": "string", "minLength": 1},
+            "dest": {"type": "string", "minLength": 1},
+            "alias": {"type": "string"},
+            "timeout": {"$ref": "#/definitions/timeout"}
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "run": {
+      "description": "Command run on the device.",
+      "anyOf": [
+        {
+          "type": "object",
+          "required": ["name"],
+          "additionalProperties": false,
+          "properties": {
+            "name": {"type": "string", "minLength": 1},
+            "timeout": {"$ref": "#/definitions/timeout"}
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    },
+    "pull": {
+      "description": "Copy of a file from the device to artifacts.",
+      "anyOf": [
+        {
+          "type": "object",
+          "required": ["src"],
+          "additionalProperties": false,
+          "properties": {
+            "src": {"type": "string", "minLength": 1},
+            "alias": {"type": "string"},
+            "timeout": {"$ref": "#/definitions/timeout"}
+          }
+        },
+        {"$ref": "#/definitions/include"}
+      ]
+    }
+  }
+}`
+
+// jobSchema is JobSchema with resolved references used for validation.
+var jobSchema = func() *spec.Schema {
+	s := new(spec.Schema)
+	if err := json.Unmarshal([]byte(JobSchema), s); err != nil {
+		panic(err)
+	}
+	if err := spec.ExpandSchema(s, s, nil); err != nil {
+		panic(err)
+	}
+	return s
+}()
+
+// typeOf returns JSON Schema type of YAML node n.
+func typeOf(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+// schema records problems of node n not matching schema s. Name is the key of n
+// in the enclosing mapping. Positions of problems are taken from the document,
+// hence validation is done on nodes rather than on decoded values. Only keywords
+// used in JobSchema are supported. Null values are treated as missing ones.
+func (v *validator) schema(n *yaml.Node, s *spec.Schema, name string) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	t := typeOf(n)
+	if t == "null" {
+		return
+	}
+	if len(s.AnyOf) != 0 {
+		v.anyOf(n, s.AnyOf, name)
+		return
+	}
+	if len(s.Type) != 0 && !s.Type.Contains(t) && !(t == "integer" && s.Type.Contains("number")) {
+		v.errorf(n, "invalid type of field %q: expected %s, got %s", name,
+			strings.Join(s.Type, " or "), t)
+		return
+	}
+	switch t {
+	case "object":
+		v.object(n, s)
+	case "array":
+		if s.Items != nil && s.Items.Schema != nil {
+			for _, item := range n.Content {
+				v.schema(item, s.Items.Schema, name)
+			}
+		}
+	default:
+		v.value(n, s, name)
+	}
+}
+
+// anyOf records problems of node n if it matches none of schemas. Problems of
+// the schema fitting n best, i.e. the first one which describes most fields
+// of n, are recorded.
+func (v *validator) anyOf(n *yaml.Node, schemas []spec.Schema, name string) {
+	var best ValidationErrors
+	bestFit := -1
+	for i := range schemas {
+		alt := &validator{root: v.root}
+		alt.schema(n, &schemas[i], name)
+		if len(alt.errs) == 0 {
+			return
+		}
+		if f := fit(n, &schemas[i]); f > bestFit {
+			best, bestFit = alt.errs, f
+		}
+	}
+	v.errs = append(v.errs, best...)
+}
+
+// fit returns number of fields of mapping node n described by schema s.
+func fit(n *yaml.Node, s *spec.Schema) int {
+	ret := 0
+	for i := 0; i+1 < len(n.Content) && n.Kind == yaml.MappingNode; i += 2 {
+		if _, ok := s.Properties[n.Content[i].Value]; ok {
+			ret++
+		}
+	}
+	return ret
+}
+
+// object records problems of mapping node n not matching schema s.
+func (v *validator) object(n *yaml.Node, s *spec.Schema) {
+	fields := int64(len(n.Content) / 2)
+	if s.MinProperties != nil && fields < *s.MinProperties {
+		v.errorf(n, "number of fields must be at least %d", *s.MinProperties)
+	}
+	if s.MaxProperties != nil && fields > *s.MaxProperties {
+		v.errorf(n, "number of fields must be at most %d", *s.MaxProperties)
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if p, ok := s.Properties[key.Value]; ok {
+			v.schema(value, &p, key.Value)
+		} else if s.AdditionalProperties != nil && !s.AdditionalProperties.Allows {
+			v.errorf(key, "unknown field %q", key.Value)
+		}
+	}
+	for _, key := range s.Required {
+		if value := field(n, key); value == nil || typeOf(value) == "null" {
+			v.errorf(n, "missing required field %q", key)
+		}
+	}
+}
+
+// value records problems of scalar node n not matching schema s.
+func (v *validator) value(n *yaml.Node, s *spec.Schema, name string) {
+	if len(s.Enum) != 0 {
+		allowed := make([]string, len(s.Enum))
+		found := false
+		for i, e := range s.Enum {
+			allowed[i] = fmt.Sprint(e)
+			found = found || allowed[i] == n.Value
+		}
+		if !found {
+			v.errorf(n, "invalid %s %q, expected one of: %s", name, n.Value,
+				strings.Join(allowed, ", "))
+		}
+	}
+	if s.MinLength != nil && int64(utf8.RuneCountInString(n.Value)) < *s.MinLength {
+		v.errorf(n, "field %q is too short, minimum length is %d", name, *s.MinLength)
+	}
+	if s.Minimum != nil {
+		if f, err := strconv.ParseFloat(n.Value, 64); err == nil && f < *s.Minimum {
+			v.errorf(n, "field %q must not be less than %v", name, *s.Minimum)
+		}
+	}
+}
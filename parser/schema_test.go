@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package parser_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/parser"
+)
+
+var _ = Describe("JobSchema", func() {
+	var schema *spec.Schema
+
+	BeforeEach(func() {
+		schema = new(spec.Schema)
+		Expect(json.Unmarshal([]byte(parser.JobSchema), schema)).To(Succeed())
+		Expect(spec.ExpandSchema(schema, schema, nil)).To(Succeed())
+	})
+
+	// fields returns types of fields of struct t by their YAML keys.
+	fields := func(t reflect.Type) map[string]reflect.Type {
+		ret := make(map[string]reflect.Type)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			key := strings.Split(f.Tag.Get("yaml"), ",")[0]
+			if key == "-" {
+				continue
+			}
+			if key == "" {
+				key = strings.ToLower(f.Name)
+			}
+			ret[key] = f.Type
+		}
+		return ret
+	}
+
+	keys := func(m interface{}) []string {
+		var ret []string
+		for _, k := range reflect.ValueOf(m).MapKeys() {
+			ret = append(ret, k.String())
+		}
+		sort.Strings(ret)
+		return ret
+	}
+
+	// check verifies that schema s describes all fields of type t and nothing more.
+	// Action and TestActions are checked against types used by their UnmarshalYAML.
+	// Mappings may be replaced by includes of snippets, which are their second alternative.
+	var check func(t reflect.Type, s *spec.Schema, path string)
+	check = func(t reflect.Type, s *spec.Schema, path string) {
+		if len(s.AnyOf) != 0 {
+			Expect(s.AnyOf).To(HaveLen(2), path)
+			Expect(keys(s.AnyOf[1].Properties)).To(ConsistOf("include"), path)
+			s = &s.AnyOf[0]
+		}
+		switch t {
+		case reflect.TypeOf(weles.ValidPeriod(0)):
+			Expect(keys(s.Properties)).To(ConsistOf("seconds", "minutes", "hours", "days"),
+				path)
+			return
+		case reflect.TypeOf(weles.Action{}):
+			t = reflect.TypeOf(weles.ActionTab{})
+		case reflect.TypeOf(weles.TestActions{}):
+			t = reflect.TypeOf(weles.TestActionTab{})
+		}
+		switch t.Kind() {
+		case reflect.Slice:
+			Expect(s.Type).To(ConsistOf("array"), path)
+			check(t.Elem(), s.Items.Schema, path+"[]")
+		case reflect.Struct:
+			Expect(s.Type).To(ConsistOf("object"), path)
+			f := fields(t)
+			Expect(keys(s.Properties)).To(Equal(keys(f)), path)
+			for key, ft := range f {
+				p := s.Properties[key]
+				check(ft, &p, path+"."+key)
+			}
+		case reflect.String:
+			Expect(s.Type).To(ContainElement("string"), path)
+		case reflect.Int:
+			Expect(s.Type).To(ContainElement("integer"), path)
+		case reflect.Bool:
+			Expect(s.Type).To(ContainElement("boolean"), path)
+		default:
+			Fail("unexpected type " + t.String() + " of " + path)
+		}
+	}
+
+	It("should describe all fields of Config", func() {
+		check(reflect.TypeOf(weles.Config{}), schema, "")
+	})
+
+	It("should allow includes instead of mappings and list items", func() {
+		action := schema.Properties["actions"].Items.Schema
+		Expect(action.AnyOf).To(HaveLen(2))
+		Expect(action.AnyOf[1].Required).To(ConsistOf("include"))
+		Expect(schema.Properties["timeouts"].AnyOf).To(HaveLen(2))
+	})
+
+	It("should require the same priorities as defined in weles package", func() {
+		Expect(schema.Properties["priority"].Enum).To(ConsistOf(
+			string(weles.LOW), string(weles.MEDIUM), string(weles.HIGH)))
+	})
+})
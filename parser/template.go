@@ -0,0 +1,133 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File parser/template.go contains rendering of job templates with variables
+// given on job submission.
+
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// placeholderPattern matches ${NAME} and ${NAME:-default} placeholders. Placeholders
+// preceded by additional $ (e.g. $${NAME}) are escaped and rendered without it.
+var placeholderPattern = regexp.MustCompile(
+	`\$(\$?)\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}\n]*))?\}`)
+
+// renderer replaces placeholders in scalar values of YAML nodes.
+type renderer struct {
+	vars  map[string]string
+	errs  ValidationErrors
+	found bool
+}
+
+// Render replaces placeholders in job's YAML with values of variables. Placeholders
+// have form of ${NAME} or ${NAME:-default}, where default is used if variable NAME
+// is not given. Only scalar values are rendered, so placeholders in keys and comments
+// are left unchanged and values of variables cannot change structure of the document.
+// Rendered plain values get their type from the result, e.g. "${TIMEOUT}" may become
+// an integer. Input without placeholders and input which is not a valid YAML are
+// returned unchanged (the latter is reported by ParseYaml). If there is no value for
+// a placeholder, ValidationErrors describing positions of all such placeholders
+// are returned.
+func Render(in []byte, vars map[string]string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(in, &doc); err != nil {
+		return in, nil
+	}
+	r := &renderer{vars: vars}
+	r.render(&doc)
+	if len(r.errs) != 0 {
+		return nil, r.errs
+	}
+	if !r.found {
+		return in, nil
+	}
+	return encode(&doc)
+}
+
+// render replaces placeholders in values of node n and its children.
+func (r *renderer) render(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		r.scalar(n)
+	case yaml.MappingNode:
+		for j := 1; j < len(n.Content); j += 2 {
+			r.render(n.Content[j])
+		}
+	default:
+		for _, c := range n.Content {
+			r.render(c)
+		}
+	}
+}
+
+// scalar replaces placeholders in value of scalar node n.
+func (r *renderer) scalar(n *yaml.Node) {
+	in := []byte(n.Value)
+	matches := placeholderPattern.FindAllSubmatchIndex(in, -1)
+	if len(matches) == 0 {
+		return
+	}
+	r.found = true
+	out := make([]byte, 0, len(in))
+	last := 0
+	for _, m := range matches {
+		out = append(out, in[last:m[0]]...)
+		last = m[1]
+		if m[3] > m[2] {
+			// Escaped placeholder.
+			out = append(out, in[m[0]+1:m[1]]...)
+			continue
+		}
+		name := string(in[m[4]:m[5]])
+		if value, ok := r.vars[name]; ok {
+			out = append(out, value...)
+		} else if m[6] >= 0 {
+			out = append(out, in[m[6]:m[7]]...)
+		} else {
+			line, column := position(n, in, m[0])
+			r.errs = append(r.errs, ValidationError{
+				Line:   line,
+				Column: column,
+				Msg:    fmt.Sprintf("undefined variable %q", name),
+			})
+		}
+	}
+	n.Value = string(append(out, in[last:]...))
+	if n.Style == 0 {
+		// Type of plain value is resolved again.
+		n.Tag = ""
+	}
+}
+
+// position returns line and column of byte at offset in value of scalar node n.
+// Both are counted from 1. Offset is taken into account only in plain and quoted
+// scalars, position of the node is returned for other styles.
+func position(n *yaml.Node, value []byte, offset int) (line, column int) {
+	switch n.Style {
+	case 0:
+		return n.Line, n.Column + utf8.RuneCount(value[:offset])
+	case yaml.SingleQuotedStyle, yaml.DoubleQuotedStyle:
+		return n.Line, n.Column + 1 + utf8.RuneCount(value[:offset])
+	}
+	return n.Line, n.Column
+}
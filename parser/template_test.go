@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package parser_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles/parser"
+)
+
+var _ = Describe("Render", func() {
+	vars := map[string]string{
+		"IMAGE_URL":    "http://example.com/image.img",
+		"BUILD":        "42",
+		"EMPTY":        "",
+		"device_type2": "rpi3",
+	}
+
+	DescribeTable("should render placeholders",
+		func(in, expected string) {
+			out, err := parser.Render([]byte(in), vars)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal(expected))
+		},
+		Entry("without placeholders", "job_name: plain\n", "job_name: plain\n"),
+		Entry("with variables", "uri: ${IMAGE_URL}\njob_name: build-${BUILD}-${device_type2}\n",
+			"uri: http://example.com/image.img\njob_name: build-42-rpi3\n"),
+		Entry("with defaults", "device_type: ${DEVICE:-qemu}\nname: ${BUILD:-0}${EMPTY:-x}\n",
+			"device_type: qemu\nname: 42\n"),
+		Entry("with empty default", "name: a${MISSING:-}b\n", "name: ab\n"),
+		Entry("with escaped placeholders", "name: echo $${HOME} $${BUILD:-1} $HOME $$\n",
+			"name: echo ${HOME} ${BUILD:-1} $HOME $$\n"),
+		Entry("with invalid placeholders", "name: ${1} ${ BUILD } ${BUILD\n",
+			"name: ${1} ${ BUILD } ${BUILD\n"),
+		Entry("in comments and keys", "# ${MISSING}\n${KEY}: ${BUILD} # ${MISSING}\n",
+			"# ${MISSING}\n${KEY}: 42 # ${MISSING}\n"),
+		Entry("with quoted placeholders", "name: '${BUILD}'\nuri: \"${IMAGE_URL}\"\n",
+			"name: '42'\nuri: \"http://example.com/image.img\"\n"),
+		Entry("with typed values", "minutes: ${BUILD}\nname: !!str ${BUILD}\n",
+			"minutes: 42\nname: !!str 42\n"),
+	)
+
+	DescribeTable("should not change structure of the document",
+		func(value, expected string) {
+			out, err := parser.Render([]byte("job_name: ${NAME}\npriority: low\n"),
+				map[string]string{"NAME": value})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal("job_name: " + expected + "\npriority: low\n"))
+		},
+		Entry("with mapping", "name\npriority: high", "|-\n  name\n  priority: high"),
+		Entry("with colon", "a: b", "'a: b'"),
+		Entry("with comment", "a #b", "'a #b'"),
+		Entry("with flow mapping", "{a: b}", "'{a: b}'"),
+	)
+
+	It("should report positions of all undefined variables", func() {
+		out, err := parser.Render([]byte("job_name: ${NAME}\npriority: low\n"+
+			"uri: ąę/${IMAGE_URL}/${FILE}\ndevice_type: '${DEVICE}'\n"+
+			"test:\n  name: |\n    ${TEST}\n"), vars)
+		Expect(out).To(BeNil())
+		Expect(err).To(Equal(parser.ValidationErrors{
+			{Line: 1, Column: 11, Msg: `undefined variable "NAME"`},
+			{Line: 3, Column: 22, Msg: `undefined variable "FILE"`},
+			{Line: 4, Column: 15, Msg: `undefined variable "DEVICE"`},
+			{Line: 6, Column: 9, Msg: `undefined variable "TEST"`},
+		}))
+	})
+})
@@ -0,0 +1,247 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File parser/validate.go contains validation of job's YAML reporting positions
+// of problems found.
+
+package parser
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/SamsungSLAV/weles/artifacts/checksum"
+	"github.com/SamsungSLAV/weles/artifacts/compression"
+)
+
+// ValidationError describes a problem found in job's YAML. Line and Column are
+// counted from 1. They are 0 if position of the problem is unknown.
+type ValidationError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+// Error is part of implementation of error interface.
+func (e ValidationError) Error() string {
+	switch {
+	case e.Line == 0:
+		return e.Msg
+	case e.Column == 0:
+		return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+	default:
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+	}
+}
+
+// ValidationErrors lists all problems found in job's YAML ordered by position.
+type ValidationErrors []ValidationError
+
+// Error is part of implementation of error interface.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+var (
+	// linePattern matches position in errors reported by yaml package.
+	linePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	// unknownFieldPattern matches errors of strict decoding about unknown keys.
+	unknownFieldPattern = regexp.MustCompile(`^field (.*) not found in type .*$`)
+	// typePattern matches errors about values of wrong type.
+	typePattern = regexp.MustCompile("^cannot unmarshal !!\\w+ `(.*)` into .*$")
+)
+
+// validator collects problems found in job's YAML.
+type validator struct {
+	root *yaml.Node
+	errs ValidationErrors
+}
+
+// errorf records problem at position of node n.
+func (v *validator) errorf(n *yaml.Node, format string, args ...interface{}) {
+	v.errs = append(v.errs, ValidationError{
+		Line:   n.Line,
+		Column: n.Column,
+		Msg:    fmt.Sprintf(format, args...),
+	})
+}
+
+// decodeError records problems reported by yaml package. Column of a problem is
+// found in the document, as yaml package reports only lines.
+func (v *validator) decodeError(err error) {
+	msgs := []string{err.Error()}
+	if terr, ok := err.(*yaml.TypeError); ok {
+		msgs = terr.Errors
+	}
+	for _, msg := range msgs {
+		m := linePattern.FindStringSubmatch(msg)
+		if m == nil {
+			v.errs = append(v.errs, ValidationError{Msg: strings.TrimPrefix(msg, "yaml: ")})
+			continue
+		}
+		line, _ := strconv.Atoi(m[1])
+		e := ValidationError{Line: line, Msg: m[2]}
+		value := ""
+		if f := unknownFieldPattern.FindStringSubmatch(m[2]); f != nil {
+			value = f[1]
+			e.Msg = fmt.Sprintf("unknown field %q", value)
+		} else if t := typePattern.FindStringSubmatch(m[2]); t != nil {
+			value = t[1]
+		}
+		e.Column = column(v.root, line, value)
+		if e.Column == 0 {
+			// Long values are shortened in messages.
+			e.Column = column(v.root, line, "")
+		}
+		v.errs = append(v.errs, e)
+	}
+}
+
+// column returns column of the first node at line with given value or of any node
+// at line if value is empty. It returns 0 if there is no such node.
+func column(n *yaml.Node, line int, value string) int {
+	if n == nil {
+		return 0
+	}
+	if n.Line == line && n.Kind != yaml.DocumentNode && (value == "" || n.Value == value) {
+		return n.Column
+	}
+	for _, c := range n.Content {
+		if col := column(c, line, value); col != 0 {
+			return col
+		}
+	}
+	return 0
+}
+
+// field returns value of key in mapping node n or nil if there is no such key.
+func field(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// items returns nodes of sequence n. It returns nil if n is not a sequence.
+func items(n *yaml.Node) []*yaml.Node {
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return n.Content
+}
+
+// scalar returns value of key in mapping node n if it is a scalar.
+func scalar(n *yaml.Node, key string) (*yaml.Node, string) {
+	value := field(n, key)
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return value, ""
+	}
+	return value, value.Value
+}
+
+// uri records problem if value of key in mapping node n is not a valid absolute URI.
+func (v *validator) uri(n *yaml.Node, key string) {
+	value, s := scalar(n, key)
+	if s == "" {
+		return
+	}
+	u, err := url.Parse(s)
+	if err != nil || !u.IsAbs() {
+		v.errorf(value, "invalid URI %q in field %q", s, key)
+	}
+}
+
+// validate checks document against JobSchema and for problems which cannot be
+// expressed in the schema.
+func (v *validator) validate() {
+	if len(v.root.Content) == 0 {
+		v.errs = append(v.errs, ValidationError{Msg: "empty job description"})
+		return
+	}
+	root := v.root.Content[0]
+	v.schema(root, jobSchema, "")
+	for _, action := range items(field(root, "actions")) {
+		if deploy := field(action, "deploy"); deploy != nil {
+			v.deploy(deploy)
+		}
+		if test := field(action, "test"); test != nil {
+			v.test(test)
+		}
+	}
+}
+
+// deploy validates images and partition layout of deploy action.
+func (v *validator) deploy(deploy *yaml.Node) {
+	names := make(map[string]bool)
+	for _, image := range items(field(deploy, "images")) {
+		v.uri(image, "uri")
+		v.uri(image, "checksum_uri")
+		if value, t := scalar(image, "checksum_type"); !checksum.Supported(t) {
+			v.errorf(value, "unsupported checksum type %q", t)
+		}
+		if value, c := scalar(image, "compression"); !compression.Supported(c) {
+			v.errorf(value, "unsupported compression %q", c)
+		}
+		if value, name := scalar(image, "name"); name != "" {
+			if names[name] {
+				v.errorf(value, "duplicated image name %q", name)
+			}
+			names[name] = true
+		}
+	}
+	for _, partition := range items(field(deploy, "partition_layout")) {
+		if value, name := scalar(partition, "image_name"); name != "" && !names[name] {
+			v.errorf(value, "image %q is not defined in images", name)
+		}
+	}
+}
+
+// test validates URIs of push actions of all test cases.
+func (v *validator) test(test *yaml.Node) {
+	for _, testCase := range items(field(test, "test_cases")) {
+		for _, action := range items(field(testCase, "test_actions")) {
+			if push := field(action, "push"); push != nil {
+				v.uri(push, "uri")
+			}
+		}
+	}
+}
+
+// sorted returns problems ordered by their position.
+func (v *validator) sorted() ValidationErrors {
+	sort.SliceStable(v.errs, func(i, j int) bool {
+		if v.errs[i].Line != v.errs[j].Line {
+			return v.errs[i].Line < v.errs[j].Line
+		}
+		return v.errs[i].Column < v.errs[j].Column
+	})
+	return v.errs
+}
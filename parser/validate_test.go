@@ -0,0 +1,181 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package parser_test
+
+import (
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles/parser"
+)
+
+var _ = Describe("Validation", func() {
+	var p parser.Parser
+
+	It("should accept sample yaml", func() {
+		sample, err := ioutil.ReadFile("sample_yaml")
+		Expect(err).ToNot(HaveOccurred())
+		conf, err := p.ParseYaml(sample)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conf.Action.Deploy.Images).To(HaveLen(2))
+	})
+
+	DescribeTable("invalid yaml",
+		func(yaml string, expected ...parser.ValidationError) {
+			conf, err := p.ParseYaml([]byte(yaml))
+			Expect(conf).To(BeNil())
+			Expect(err).To(Equal(parser.ValidationErrors(expected)))
+		},
+		Entry("unknown field", `
+device_type: qemu
+job_name: typo
+priority: low
+actions:
+  - boot:
+      timout:
+        minutes: 1
+`,
+			parser.ValidationError{Line: 7, Column: 7, Msg: `unknown field "timout"`}),
+		Entry("unknown field of test case", `
+device_type: qemu
+job_name: typo
+priority: low
+actions:
+  - test:
+      test_cases:
+        - case_name: first
+          test_action:
+            - run:
+                name: ls
+`,
+			parser.ValidationError{Line: 9, Column: 11, Msg: `unknown field "test_action"`}),
+		Entry("missing required fields and invalid priority", `
+device_type: qemu
+priority: urgent
+`,
+			parser.ValidationError{Line: 2, Column: 1, Msg: `missing required field "job_name"`},
+			parser.ValidationError{Line: 3, Column: 11,
+				Msg: `invalid priority "urgent", expected one of: low, medium, high`}),
+		Entry("invalid images", `
+device_type: qemu
+job_name: images
+priority: low
+actions:
+  - deploy:
+      images:
+        - name: boot
+          uri: boot.img
+          checksum_type: crc
+          compression: rar
+        - name: boot
+          checksum_uri: http://example.com/boot.md5
+`,
+			parser.ValidationError{Line: 9, Column: 16,
+				Msg: `invalid URI "boot.img" in field "uri"`},
+			parser.ValidationError{Line: 10, Column: 26, Msg: `unsupported checksum type "crc"`},
+			parser.ValidationError{Line: 11, Column: 24, Msg: `unsupported compression "rar"`},
+			parser.ValidationError{Line: 12, Column: 11, Msg: `missing required field "uri"`},
+			parser.ValidationError{Line: 12, Column: 17, Msg: `duplicated image name "boot"`}),
+		Entry("partition referencing unknown image", `
+device_type: qemu
+job_name: partitions
+priority: low
+actions:
+  - deploy:
+      images:
+        - name: boot
+          uri: http://example.com/boot.img
+      partition_layout:
+        - id: 1
+          image_name: root
+`,
+			parser.ValidationError{Line: 12, Column: 23,
+				Msg: `image "root" is not defined in images`}),
+		Entry("incomplete test actions", `
+device_type: qemu
+job_name: actions
+priority: low
+actions:
+  - test:
+      test_cases:
+        - case_name: first
+          test_actions:
+            - push:
+                dest: /tmp/file
+            - run:
+                timeout:
+                  minutes: 1
+            - pull:
+                alias: logs
+`,
+			parser.ValidationError{Line: 11, Column: 17, Msg: `missing required field "uri"`},
+			parser.ValidationError{Line: 13, Column: 17, Msg: `missing required field "name"`},
+			parser.ValidationError{Line: 16, Column: 17, Msg: `missing required field "src"`}),
+		Entry("wrong type of value", `
+device_type: qemu
+job_name: types
+priority: low
+actions:
+  - boot:
+      failure_retry: many
+`,
+			parser.ValidationError{Line: 7, Column: 22,
+				Msg: `invalid type of field "failure_retry": expected integer, got string`}),
+		Entry("invalid timeouts and empty test action", `
+device_type: qemu
+job_name: timeouts
+priority: low
+timeouts:
+  job:
+    weeks: 1
+  action:
+    minutes: 1
+    seconds: 30
+actions:
+  - test:
+      test_cases:
+        - case_name: first
+          test_actions:
+            - {}
+            - run:
+                name: ""
+`,
+			parser.ValidationError{Line: 7, Column: 5, Msg: `unknown field "weeks"`},
+			parser.ValidationError{Line: 9, Column: 5,
+				Msg: "number of fields must be at most 1"},
+			parser.ValidationError{Line: 16, Column: 15,
+				Msg: "number of fields must be at least 1"},
+			parser.ValidationError{Line: 18, Column: 23,
+				Msg: `field "name" is too short, minimum length is 1`}),
+		Entry("syntax error", "device_type: qemu\njob_name: qemu: pipeline\n",
+			parser.ValidationError{Line: 2,
+				Msg: "mapping values are not allowed in this context"}),
+		Entry("empty input", "", parser.ValidationError{Msg: "empty job description"}),
+	)
+
+	It("should format position of problems", func() {
+		err := parser.ValidationErrors{
+			{Line: 7, Column: 3, Msg: "first"},
+			{Line: 9, Msg: "second"},
+			{Msg: "third"},
+		}
+		Expect(err.Error()).To(Equal("line 7, column 3: first; line 9: second; third"))
+	})
+})
@@ -39,6 +39,7 @@ var expectedConfig = weles.Config{
 			Timeout: weles.ValidPeriod(20 * time.Minute),
 			Images: []weles.ImageDefinition{
 				{
+					Name: "image_name1_string",
 					URI: "https://images.validation.linaro.org/kvm/standard/" +
 						"stretch-1.img.gz",
 					ChecksumURI:  "https://images.validation.linaro.org/kvm/standard/stretch-1.md5",
@@ -48,6 +49,7 @@ var expectedConfig = weles.Config{
 					ChecksumPath: "",
 				},
 				{
+					Name: "image_name2_string",
 					URI: "https://images.validation.linaro.org/kvm/standard/" +
 						"stretch-2.img.zip",
 					ChecksumURI:  "https://images.validation.linaro.org/kvm/standard/stretch-2.md5",
@@ -72,7 +74,7 @@ var expectedConfig = weles.Config{
 				},
 				{
 					ID:        3,
-					ImageName: "image_name3_string",
+					ImageName: "image_name2_string",
 					Size:      "34567",
 					Type:      "ext3",
 				},
@@ -107,7 +109,7 @@ var expectedConfig = weles.Config{
 							WaitTime:      weles.ValidPeriod(4 * time.Minute),
 						},
 						weles.Push{
-							URI:     "uri1_string",
+							URI:     "http://example.com/uri1_string",
 							Dest:    "path1_string",
 							Alias:   "alias1_string",
 							Timeout: weles.ValidPeriod(6 * time.Minute),
@@ -139,14 +141,14 @@ var expectedConfig = weles.Config{
 							WaitTime:      weles.ValidPeriod(3 * time.Minute),
 						},
 						weles.Push{
-							URI:     "uri1_string",
+							URI:     "http://example.com/uri1_string",
 							Dest:    "path1_string",
 							Alias:   "alias1_string",
 							Timeout: weles.ValidPeriod(4 * time.Minute),
 							Path:    "",
 						},
 						weles.Push{
-							URI:     "uri1_string",
+							URI:     "http://example.com/uri1_string",
 							Dest:    "path1_string",
 							Alias:   "alias1_string",
 							Timeout: weles.ValidPeriod(5 * time.Minute),
@@ -192,28 +194,27 @@ actions:
       timeout:
         minutes: 20
       images:       # list of images
-         - uri: https://images.validation.linaro.org/kvm/standard/stretch-1.img.gz
+         - name: image_name1_string
+           uri: https://images.validation.linaro.org/kvm/standard/stretch-1.img.gz
            checksum_uri: https://images.validation.linaro.org/kvm/standard/stretch-1.md5
            checksum_type: md5
            compression: gz
-         - uri: https://images.validation.linaro.org/kvm/standard/stretch-2.img.zip
+         - name: image_name2_string
+           uri: https://images.validation.linaro.org/kvm/standard/stretch-2.img.zip
            checksum_uri: https://images.validation.linaro.org/kvm/standard/stretch-2.md5
            checksum_type: md5
            compression: zip
       partition_layout:     # list of partitions structures
          - id: 1
-           device_name: device_name1_string
            image_name: image_name1_string
            size: 12345
            type: fat
          - id: 2
-           device_name: device_name2_string
            image_name: image_name2_string
            size: 23456
            type: ext2
          - id: 3
-           device_name: device_name3_string
-           image_name: image_name3_string
+           image_name: image_name2_string
            size: 34567
            type: ext3
   - boot:
@@ -251,7 +252,7 @@ actions:
                 wait_time:
                   minutes: 4
             - push:
-                uri: uri1_string
+                uri: http://example.com/uri1_string
                 dest: path1_string
                 alias: alias1_string
                 timeout:
@@ -281,13 +282,13 @@ actions:
                 wait_time:
                   minutes: 3
             - push:
-                uri: uri1_string
+                uri: http://example.com/uri1_string
                 dest: path1_string
                 alias: alias1_string
                 timeout:
                   minutes: 4
             - push:
-                uri: uri1_string
+                uri: http://example.com/uri1_string
                 dest: path1_string
                 alias: alias1_string
                 timeout:
@@ -0,0 +1,89 @@
+// Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/server/operations/artifacts"
+)
+
+// bearerPrefix precedes token in Authorization header.
+const bearerPrefix = "Bearer "
+
+// authorized checks if request carries administrator token in Authorization header.
+func (a *APIDefaults) authorized(r *http.Request) bool {
+	if a.AdminToken == "" || r == nil {
+		return false
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, bearerPrefix) {
+		return false
+	}
+	token := strings.TrimPrefix(auth, bearerPrefix)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(a.AdminToken)) == 1
+}
+
+// ArtifactDeleter is a handler which passes ID of artifact to be deleted to ArtifactManager.
+func (a *APIDefaults) ArtifactDeleter(params artifacts.ArtifactDeleterParams,
+) middleware.Responder {
+	if !a.authorized(params.HTTPRequest) {
+		return artifacts.NewArtifactDeleterForbidden().WithPayload(
+			&weles.ErrResponse{Message: weles.ErrNotAuthorized.Error()})
+	}
+	err := a.Managers.AM.DeleteArtifact(params.ArtifactID)
+	switch err {
+	case nil:
+		return artifacts.NewArtifactDeleterNoContent()
+	case weles.ErrArtifactNotFound:
+		return artifacts.NewArtifactDeleterNotFound().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	case weles.ErrArtifactNotReady, weles.ErrArtifactInUse:
+		return artifacts.NewArtifactDeleterConflict().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	default:
+		return artifacts.NewArtifactDeleterInternalServerError().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	}
+}
+
+// JobArtifactsDeleter is a handler which passes JobID to ArtifactManager to delete all
+// artifacts of the job.
+func (a *APIDefaults) JobArtifactsDeleter(params artifacts.JobArtifactsDeleterParams,
+) middleware.Responder {
+	if !a.authorized(params.HTTPRequest) {
+		return artifacts.NewJobArtifactsDeleterForbidden().WithPayload(
+			&weles.ErrResponse{Message: weles.ErrNotAuthorized.Error()})
+	}
+	err := a.Managers.AM.DeleteJobArtifacts(weles.JobID(params.JobID))
+	switch err {
+	case nil:
+		return artifacts.NewJobArtifactsDeleterNoContent()
+	case weles.ErrArtifactNotFound:
+		return artifacts.NewJobArtifactsDeleterNotFound().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	case weles.ErrArtifactNotReady, weles.ErrArtifactInUse:
+		return artifacts.NewJobArtifactsDeleterConflict().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	default:
+		return artifacts.NewJobArtifactsDeleterInternalServerError().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	}
+}
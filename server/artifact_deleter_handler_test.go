@@ -0,0 +1,127 @@
+// Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/mock"
+	"github.com/SamsungSLAV/weles/server"
+)
+
+var _ = Describe("ArtifactDeleterHandler", func() {
+
+	const token = "s3cr3t"
+
+	var (
+		mockCtrl            *gomock.Controller
+		mockArtifactManager *mock.MockArtifactManager
+		apiDefaults         *server.APIDefaults
+		testserver          *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mockCtrl, _, mockArtifactManager, apiDefaults, testserver = testServerSetup()
+		apiDefaults.AdminToken = token
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+		testserver.Close()
+	})
+
+	deleteReq := func(path, auth string) *http.Response {
+		req, err := http.NewRequest(http.MethodDelete, testserver.URL+basePath+path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		if auth != OMIT {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := testserver.Client().Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	checkError := func(resp *http.Response, erro error, statuscode int) {
+		defer resp.Body.Close()
+		respBody, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		errorEncoded, err := json.Marshal(weles.ErrResponse{Message: erro.Error()})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(respBody)).To(MatchJSON(string(errorEncoded)))
+		Expect(resp.StatusCode).To(Equal(statuscode))
+	}
+
+	Describe("deleting an artifact", func() {
+		It("should respond with 204 Status Code", func() {
+			mockArtifactManager.EXPECT().DeleteArtifact(int64(17))
+			resp := deleteReq("/artifacts/17", "Bearer "+token)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(204))
+		})
+		DescribeTable("refuse unauthorized request",
+			func(auth, configured string) {
+				apiDefaults.AdminToken = configured
+				checkError(deleteReq("/artifacts/17", auth), weles.ErrNotAuthorized, 403)
+			},
+			Entry("no token", OMIT, token),
+			Entry("invalid token", "Bearer invalid", token),
+			Entry("not a bearer token", token, token),
+			Entry("token not configured", "Bearer ", ""),
+		)
+		DescribeTable("with appropriate error",
+			func(erro error, statuscode int) {
+				mockArtifactManager.EXPECT().DeleteArtifact(int64(17)).Return(erro)
+				checkError(deleteReq("/artifacts/17", "Bearer "+token), erro, statuscode)
+			},
+			Entry("artifact does not exist - 404", weles.ErrArtifactNotFound, 404),
+			Entry("artifact is being downloaded - 409", weles.ErrArtifactNotReady, 409),
+			Entry("artifact is used by active job - 409", weles.ErrArtifactInUse, 409),
+			Entry("unexpected error - 500", errors.New("Some other error"), 500),
+		)
+	})
+
+	Describe("deleting artifacts of a job", func() {
+		It("should respond with 204 Status Code", func() {
+			mockArtifactManager.EXPECT().DeleteJobArtifacts(weles.JobID(1234))
+			resp := deleteReq("/jobs/1234/artifacts", "Bearer "+token)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(204))
+		})
+		It("should refuse unauthorized request", func() {
+			checkError(deleteReq("/jobs/1234/artifacts", "Bearer invalid"),
+				weles.ErrNotAuthorized, 403)
+		})
+		DescribeTable("with appropriate error",
+			func(erro error, statuscode int) {
+				mockArtifactManager.EXPECT().DeleteJobArtifacts(weles.JobID(1234)).Return(erro)
+				checkError(deleteReq("/jobs/1234/artifacts", "Bearer "+token), erro, statuscode)
+			},
+			Entry("job has no artifacts - 404", weles.ErrArtifactNotFound, 404),
+			Entry("artifact is being downloaded - 409", weles.ErrArtifactNotReady, 409),
+			Entry("artifact is used by active job - 409", weles.ErrArtifactInUse, 409),
+			Entry("unexpected error - 500", errors.New("Some other error"), 500),
+		)
+	})
+})
@@ -36,13 +36,17 @@ func (a *APIDefaults) ArtifactLister(params artifacts.ArtifactListerParams) midd
 
 	artifactInfoReceived, listInfo, err := a.Managers.AM.ListArtifact(filter, sorter, paginator)
 
-	switch err {
+	switch err.(type) {
 	default:
+		if err == weles.ErrArtifactNotFound {
+			return artifacts.NewArtifactListerNotFound().WithPayload(
+				&weles.ErrResponse{Message: weles.ErrArtifactNotFound.Error()})
+		}
 		return artifacts.NewArtifactListerInternalServerError().WithPayload(
 			&weles.ErrResponse{Message: err.Error()})
-	case weles.ErrArtifactNotFound:
-		return artifacts.NewArtifactListerNotFound().WithPayload(
-			&weles.ErrResponse{Message: weles.ErrArtifactNotFound.Error()})
+	case weles.ErrInvalidArgument:
+		return artifacts.NewArtifactListerBadRequest().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
 	case nil:
 	}
 
@@ -142,6 +146,45 @@ func setArtifactFilter(fi *weles.ArtifactFilter) (fo weles.ArtifactFilter) {
 				fo.Type = fi.Type
 			}
 		}
+		if len(fi.SHA256) > 0 {
+			if !(len(fi.SHA256) == 1 && fi.SHA256[0] == "") {
+				fo.SHA256 = fi.SHA256
+			}
+		}
+		if len(fi.MimeType) > 0 {
+			if !(len(fi.MimeType) == 1 && fi.MimeType[0] == "") {
+				fo.MimeType = fi.MimeType
+			}
+		}
+		if len(fi.ETag) > 0 {
+			if !(len(fi.ETag) == 1 && fi.ETag[0] == "") {
+				fo.ETag = fi.ETag
+			}
+		}
+		fo.CreatedAfter = normalizeDate(fi.CreatedAfter)
+		fo.CreatedBefore = normalizeDate(fi.CreatedBefore)
+		if len(fi.AliasContains) > 0 {
+			if !(len(fi.AliasContains) == 1 && fi.AliasContains[0] == "") {
+				fo.AliasContains = fi.AliasContains
+			}
+		}
+		if len(fi.AliasRegexp) > 0 {
+			if !(len(fi.AliasRegexp) == 1 && fi.AliasRegexp[0] == "") {
+				fo.AliasRegexp = fi.AliasRegexp
+			}
+		}
+		if len(fi.URIContains) > 0 {
+			if !(len(fi.URIContains) == 1 && fi.URIContains[0] == "") {
+				fo.URIContains = fi.URIContains
+			}
+		}
+		if len(fi.URIRegexp) > 0 {
+			if !(len(fi.URIRegexp) == 1 && fi.URIRegexp[0] == "") {
+				fo.URIRegexp = fi.URIRegexp
+			}
+		}
+		fo.MinSize = fi.MinSize
+		fo.MaxSize = fi.MaxSize
 	}
 	return
 }
@@ -23,7 +23,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"time"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -65,6 +67,17 @@ var _ = Describe("Listing artifacts with server initialized", func() {
 			},
 		}
 
+		rangeFilter = weles.ArtifactFilter{
+			CreatedAfter:  strfmt.DateTime(time.Unix(3000, 0).UTC()),
+			CreatedBefore: strfmt.DateTime(time.Unix(4000, 0).UTC()),
+			AliasContains: []string{"silver"},
+			AliasRegexp:   []string{"^silver.*roo$"},
+			URIContains:   []string{"example.com"},
+			URIRegexp:     []string{"/[0-9]+$"},
+			MinSize:       1024,
+			MaxSize:       4096,
+		}
+
 		sorterEmpty = weles.ArtifactSorter{}
 
 		sorterDescNoBy = weles.ArtifactSorter{
@@ -219,6 +232,7 @@ var _ = Describe("Listing artifacts with server initialized", func() {
 				},
 				Entry("when receiving empty filter", emptyFilter),
 				Entry("when receiving filled filter", filledFilter),
+				Entry("when receiving filter with ranges and patterns", rangeFilter),
 			)
 
 			DescribeTable("server should pass sorter to ArtifactManager, but set default values "+
@@ -361,6 +375,8 @@ var _ = Describe("Listing artifacts with server initialized", func() {
 				int32(0), 500, errors.New("This is unexpected error")),
 			Entry("pagination on, 500 status, Unexpected error",
 				int32(100), 500, errors.New("This is unexpected error")),
+			Entry("pagination off, 400 status, Invalid argument error",
+				int32(0), 400, weles.ErrInvalidArgument("cannot compile regex")),
 		)
 	})
 	Describe("Pagination turned on", func() {
@@ -0,0 +1,64 @@
+// Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server
+
+import (
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/server/operations/artifacts"
+)
+
+// ArtifactPinner is a handler which requests ArtifactManager to protect an artifact
+// from being purged by retention policy.
+func (a *APIDefaults) ArtifactPinner(params artifacts.ArtifactPinnerParams,
+) middleware.Responder {
+	if !a.authorized(params.HTTPRequest) {
+		return artifacts.NewArtifactPinnerForbidden().WithPayload(
+			&weles.ErrResponse{Message: weles.ErrNotAuthorized.Error()})
+	}
+	err := a.Managers.AM.SetArtifactPinned(params.ArtifactID, true)
+	switch err {
+	case nil:
+		return artifacts.NewArtifactPinnerNoContent()
+	case weles.ErrArtifactNotFound:
+		return artifacts.NewArtifactPinnerNotFound().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	default:
+		return artifacts.NewArtifactPinnerInternalServerError().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	}
+}
+
+// ArtifactUnpinner is a handler which requests ArtifactManager to allow purging
+// an artifact by retention policy.
+func (a *APIDefaults) ArtifactUnpinner(params artifacts.ArtifactUnpinnerParams,
+) middleware.Responder {
+	if !a.authorized(params.HTTPRequest) {
+		return artifacts.NewArtifactUnpinnerForbidden().WithPayload(
+			&weles.ErrResponse{Message: weles.ErrNotAuthorized.Error()})
+	}
+	err := a.Managers.AM.SetArtifactPinned(params.ArtifactID, false)
+	switch err {
+	case nil:
+		return artifacts.NewArtifactUnpinnerNoContent()
+	case weles.ErrArtifactNotFound:
+		return artifacts.NewArtifactUnpinnerNotFound().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	default:
+		return artifacts.NewArtifactUnpinnerInternalServerError().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/mock"
+	"github.com/SamsungSLAV/weles/server"
+)
+
+var _ = Describe("ArtifactPinnerHandler", func() {
+	const token = "s3cr3t"
+
+	var (
+		mockCtrl            *gomock.Controller
+		mockArtifactManager *mock.MockArtifactManager
+		apiDefaults         *server.APIDefaults
+		testserver          *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mockCtrl, _, mockArtifactManager, apiDefaults, testserver = testServerSetup()
+		apiDefaults.AdminToken = token
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+		testserver.Close()
+	})
+
+	postReqWithAuth := func(action, auth string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost,
+			testserver.URL+basePath+"/artifacts/17/"+action, nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Content-Type", JSON)
+		if auth != OMIT {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := testserver.Client().Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	postReq := func(action string) *http.Response {
+		return postReqWithAuth(action, "Bearer "+token)
+	}
+
+	DescribeTable("changing pinned state of an artifact",
+		func(action string, pinned bool) {
+			mockArtifactManager.EXPECT().SetArtifactPinned(int64(17), pinned)
+			resp := postReq(action)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(204))
+		},
+		Entry("pin", "pin", true),
+		Entry("unpin", "unpin", false),
+	)
+
+	DescribeTable("should refuse unauthorized requests",
+		func(action, auth string) {
+			resp := postReqWithAuth(action, auth)
+			defer resp.Body.Close()
+
+			respBody, err := ioutil.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(respBody)).To(MatchJSON(`{"message": "` +
+				weles.ErrNotAuthorized.Error() + `"}`))
+			Expect(resp.StatusCode).To(Equal(403))
+		},
+		Entry("pin: missing token", "pin", OMIT),
+		Entry("pin: invalid token", "pin", "Bearer invalid"),
+		Entry("unpin: missing token", "unpin", OMIT),
+		Entry("unpin: invalid token", "unpin", "Bearer invalid"),
+	)
+
+	DescribeTable("server should respond with appropriate error",
+		func(action string, pinned bool, erro error, statuscode int) {
+			mockArtifactManager.EXPECT().SetArtifactPinned(int64(17), pinned).Return(erro)
+			resp := postReq(action)
+			defer resp.Body.Close()
+
+			respBody, err := ioutil.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			errorEncoded, err := json.Marshal(weles.ErrResponse{Message: erro.Error()})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(respBody)).To(MatchJSON(string(errorEncoded)))
+			Expect(resp.StatusCode).To(Equal(statuscode))
+		},
+		Entry("pin: artifact does not exist - 404", "pin", true, weles.ErrArtifactNotFound, 404),
+		Entry("pin: unexpected error - 500", "pin", true, errors.New("Some other error"), 500),
+		Entry("unpin: artifact does not exist - 404", "unpin", false,
+			weles.ErrArtifactNotFound, 404),
+		Entry("unpin: unexpected error - 500", "unpin", false, errors.New("Some other error"),
+			500),
+	)
+})
@@ -0,0 +1,45 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server
+
+import (
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/server/operations/artifacts"
+)
+
+// ArtifactUploader is a handler which passes uploaded file to ArtifactManager.
+// If alias is not provided, name of the uploaded file is used instead.
+func (m *Managers) ArtifactUploader(params artifacts.ArtifactUploaderParams,
+) middleware.Responder {
+	artifact := weles.ArtifactDescription{Type: weles.ArtifactTypeTEST}
+	if params.Type != nil {
+		artifact.Type = weles.ArtifactType(*params.Type)
+	}
+	if params.Alias != nil {
+		artifact.Alias = weles.ArtifactAlias(*params.Alias)
+	} else if f, ok := params.Artifactfile.(*runtime.File); ok && f.Header != nil {
+		artifact.Alias = weles.ArtifactAlias(f.Header.Filename)
+	}
+
+	ai, err := m.AM.UploadArtifact(artifact, params.Artifactfile)
+	if err != nil {
+		return artifacts.NewArtifactUploaderInternalServerError().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	}
+	return artifacts.NewArtifactUploaderCreated().WithPayload(ai.ID)
+}
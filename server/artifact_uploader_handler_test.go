@@ -0,0 +1,123 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/mock"
+)
+
+var _ = Describe("ArtifactUploaderHandler", func() {
+
+	var (
+		mockCtrl            *gomock.Controller
+		mockArtifactManager *mock.MockArtifactManager
+		testserver          *httptest.Server
+	)
+
+	const content = "uploaded artifact content"
+
+	BeforeEach(func() {
+		mockCtrl, _, mockArtifactManager, _, testserver = testServerSetup()
+	})
+
+	AfterEach(func() {
+		testserver.Close()
+		mockCtrl.Finish()
+	})
+
+	requestBody := func(fields map[string]string) *http.Request {
+		bodyBuf := &bytes.Buffer{}
+		bodyWriter := multipart.NewWriter(bodyBuf)
+		fileWriter, err := bodyWriter.CreateFormFile("artifactfile", "image.img")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = io.WriteString(fileWriter, content)
+		Expect(err).ToNot(HaveOccurred())
+		for k, v := range fields {
+			Expect(bodyWriter.WriteField(k, v)).To(Succeed())
+		}
+		Expect(bodyWriter.Close()).To(Succeed())
+
+		req, err := http.NewRequest(http.MethodPost, testserver.URL+"/api/v1/artifacts",
+			bodyBuf)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Content-Type", bodyWriter.FormDataContentType())
+		return req
+	}
+
+	expectUpload := func(expected weles.ArtifactDescription) *gomock.Call {
+		return mockArtifactManager.EXPECT().UploadArtifact(expected, gomock.Any()).DoAndReturn(
+			func(_ weles.ArtifactDescription, r io.Reader) (weles.ArtifactInfo, error) {
+				received, err := ioutil.ReadAll(r)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(received)).To(Equal(content))
+				return weles.ArtifactInfo{ID: 17}, nil
+			})
+	}
+
+	DescribeTable("should respond with 201 and artifact ID in body",
+		func(fields map[string]string, expected weles.ArtifactDescription) {
+			expectUpload(expected)
+
+			resp, err := testserver.Client().Do(requestBody(fields))
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			respBody, err := ioutil.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(resp.StatusCode).To(Equal(201))
+			Expect(respBody).To(MatchJSON("17"))
+		},
+		Entry("default alias and type", map[string]string{},
+			weles.ArtifactDescription{Alias: "image.img", Type: weles.ArtifactTypeTEST}),
+		Entry("custom alias and type", map[string]string{"alias": "rootfs", "type": "IMAGE"},
+			weles.ArtifactDescription{Alias: "rootfs", Type: weles.ArtifactTypeIMAGE}),
+	)
+
+	It("should respond with 422 when type is invalid", func() {
+		resp, err := testserver.Client().Do(requestBody(map[string]string{"type": "RESULT"}))
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(422))
+	})
+
+	It("should respond with 500 when ArtifactManager fails", func() {
+		mockArtifactManager.EXPECT().UploadArtifact(gomock.Any(), gomock.Any()).Return(
+			weles.ArtifactInfo{}, errors.New("no space left"))
+
+		resp, err := testserver.Client().Do(requestBody(map[string]string{}))
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		respBody, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(resp.StatusCode).To(Equal(500))
+		Expect(respBody).To(MatchJSON(`{"message":"no space left"}`))
+	})
+})
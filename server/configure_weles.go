@@ -29,6 +29,7 @@ import (
 	"github.com/SamsungSLAV/weles/server/operations/artifacts"
 	"github.com/SamsungSLAV/weles/server/operations/general"
 	"github.com/SamsungSLAV/weles/server/operations/jobs"
+	"github.com/SamsungSLAV/weles/server/operations/snippets"
 )
 
 const (
@@ -54,6 +55,7 @@ func welesConfigureAPI(api *operations.WelesAPI, a *APIDefaults) http.Handler {
 	api.JSONConsumer = runtime.JSONConsumer()
 	api.MultipartformConsumer = runtime.DiscardConsumer
 
+	api.BinProducer = runtime.ByteStreamProducer()
 	api.JSONProducer = runtime.JSONProducer()
 
 	api.SetDefaultProduces("application/json")
@@ -62,10 +64,31 @@ func welesConfigureAPI(api *operations.WelesAPI, a *APIDefaults) http.Handler {
 	api.JobsJobCreatorHandler = jobs.JobCreatorHandlerFunc(a.Managers.JobCreator)
 	api.JobsJobCancelerHandler = jobs.JobCancelerHandlerFunc(a.Managers.JobCanceller)
 	api.JobsJobListerHandler = jobs.JobListerHandlerFunc(a.JobLister)
+	api.JobsJobValidatorHandler = jobs.JobValidatorHandlerFunc(a.Managers.JobValidator)
 
 	api.ArtifactsArtifactListerHandler = artifacts.ArtifactListerHandlerFunc(a.ArtifactLister)
+	api.ArtifactsArtifactUploaderHandler = artifacts.ArtifactUploaderHandlerFunc(
+		a.Managers.ArtifactUploader)
+	api.ArtifactsArtifactDeleterHandler = artifacts.ArtifactDeleterHandlerFunc(a.ArtifactDeleter)
+	api.ArtifactsJobArtifactsDeleterHandler = artifacts.JobArtifactsDeleterHandlerFunc(
+		a.JobArtifactsDeleter)
+	api.ArtifactsJobArtifactsArchiverHandler = artifacts.JobArtifactsArchiverHandlerFunc(
+		a.JobArtifactsArchiver)
+	api.ArtifactsArtifactPinnerHandler = artifacts.ArtifactPinnerHandlerFunc(
+		a.ArtifactPinner)
+	api.ArtifactsArtifactUnpinnerHandler = artifacts.ArtifactUnpinnerHandlerFunc(
+		a.ArtifactUnpinner)
+
+	api.SnippetsSnippetListerHandler = snippets.SnippetListerHandlerFunc(
+		a.Managers.SnippetLister)
+	api.SnippetsSnippetCreatorHandler = snippets.SnippetCreatorHandlerFunc(
+		a.SnippetCreator)
+	api.SnippetsSnippetGetterHandler = snippets.SnippetGetterHandlerFunc(
+		a.Managers.SnippetGetter)
+	api.SnippetsSnippetDeleterHandler = snippets.SnippetDeleterHandlerFunc(a.SnippetDeleter)
 
 	api.GeneralVersionHandler = general.VersionHandlerFunc(a.Version)
+	api.GeneralJobSchemaHandler = general.JobSchemaHandlerFunc(a.JobSchema)
 
 	api.ServerShutdown = func() {}
 
@@ -59,6 +59,66 @@ func init() {
   "host": "localhost:8088",
   "basePath": "/api/v1",
   "paths": {
+    "/artifacts": {
+      "post": {
+        "description": "ArtifactUploader stores file passed by user in ArtifactDB. Returned ID may be used in Job description as weles://artifact/<ID> URI.",
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Upload new artifact",
+        "operationId": "ArtifactUploader",
+        "parameters": [
+          {
+            "type": "file",
+            "description": "is the file to be stored in ArtifactDB.",
+            "name": "artifactfile",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "is an alternative name of the artifact.",
+            "name": "alias",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "IMAGE",
+              "TEST"
+            ],
+            "type": "string",
+            "description": "is type of the artifact. TEST is used if not provided.",
+            "name": "type",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Created",
+            "schema": {
+              "description": "is ID of the uploaded artifact.",
+              "type": "integer",
+              "format": "int64"
+            }
+          },
+          "415": {
+            "$ref": "#/responses/UnsupportedMediaType"
+          },
+          "422": {
+            "$ref": "#/responses/UnprocessableEntity"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      }
+    },
     "/artifacts/list": {
       "post": {
         "description": "ArtifactLister returns information on filtered Weles artifacts.",
@@ -183,9 +243,120 @@ func init() {
         }
       }
     },
+    "/artifacts/{ArtifactID}": {
+      "delete": {
+        "description": "ArtifactDeleter removes file and ArtifactDB record of artifact identified by ArtifactID. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header. Artifacts which are being downloaded or are used by active Jobs cannot be deleted.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Delete artifact",
+        "operationId": "ArtifactDeleter",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "ArtifactID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "403": {
+            "$ref": "#/responses/Forbidden"
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "409": {
+            "$ref": "#/responses/Conflict"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      }
+    },
+    "/artifacts/{ArtifactID}/pin": {
+      "post": {
+        "description": "ArtifactPinner protects artifact identified by ArtifactID from being purged by retention policy. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Pin artifact",
+        "operationId": "ArtifactPinner",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "ArtifactID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "403": {
+            "$ref": "#/responses/Forbidden"
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      }
+    },
+    "/artifacts/{ArtifactID}/unpin": {
+      "post": {
+        "description": "ArtifactUnpinner allows artifact identified by ArtifactID to be purged by retention policy again. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Unpin artifact",
+        "operationId": "ArtifactUnpinner",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "ArtifactID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "403": {
+            "$ref": "#/responses/Forbidden"
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      }
+    },
     "/jobs": {
       "post": {
-        "description": "adds new Job in Weles using recipe passed in YAML format.",
+        "description": "adds new Job in Weles using recipe passed in YAML format. The recipe may be a template with placeholders of variables.",
         "consumes": [
           "multipart/form-data"
         ],
@@ -204,6 +375,16 @@ func init() {
             "name": "yamlfile",
             "in": "formData",
             "required": true
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "are values of variables used in Job description yaml file given in NAME=value form. Placeholders ${NAME} and ${NAME:-default} are replaced with these values, also in included snippets.",
+            "name": "variables",
+            "in": "formData"
           }
         ],
         "responses": {
@@ -349,11 +530,11 @@ func init() {
         }
       }
     },
-    "/jobs/{JobID}/cancel": {
+    "/jobs/validate": {
       "post": {
-        "description": "JobCanceler stops execution of Job identified by JobID.",
+        "description": "JobValidator checks Job description passed in YAML format without creating a Job. Besides parsing and validating YAML, it verifies that device_type is available in Boruta and that URIs of artifacts are reachable. Problems which will certainly make the Job fail are reported as errors, others as warnings.",
         "consumes": [
-          "application/json"
+          "multipart/form-data"
         ],
         "produces": [
           "application/json"
@@ -361,8 +542,57 @@ func init() {
         "tags": [
           "jobs"
         ],
-        "summary": "Cancel existing job",
-        "operationId": "JobCanceler",
+        "summary": "Validate job description",
+        "operationId": "JobValidator",
+        "parameters": [
+          {
+            "type": "file",
+            "description": "is Job description yaml file.",
+            "name": "yamlfile",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "are values of variables used in Job description yaml file given in NAME=value form, the same as passed to JobCreator.",
+            "name": "variables",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/JobValidation"
+            }
+          },
+          "415": {
+            "$ref": "#/responses/UnsupportedMediaType"
+          },
+          "422": {
+            "$ref": "#/responses/UnprocessableEntity"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      }
+    },
+    "/jobs/{JobID}/artifacts": {
+      "delete": {
+        "description": "JobArtifactsDeleter removes files and ArtifactDB records of all artifacts of Job identified by JobID. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header. Artifacts cannot be deleted if any of them is being downloaded or is used by an active Job.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Delete artifacts of job",
+        "operationId": "JobArtifactsDeleter",
         "parameters": [
           {
             "type": "integer",
@@ -382,92 +612,451 @@ func init() {
           "404": {
             "$ref": "#/responses/NotFound"
           },
+          "409": {
+            "$ref": "#/responses/Conflict"
+          },
           "500": {
             "$ref": "#/responses/InternalServer"
           }
         }
       }
     },
-    "/version": {
+    "/jobs/{JobID}/artifacts/archive": {
       "get": {
-        "description": "Version and state of API (e.g. v1 obsolete, v2 stable, v3 devel) and server version.",
+        "description": "JobArtifactsArchiver streams archive containing all READY artifacts of Job identified by JobID. Files are named after type and alias of artifacts. Archive contains also manifest.json file describing archived artifacts.",
         "produces": [
+          "application/octet-stream",
           "application/json"
         ],
         "tags": [
-          "general"
+          "artifacts"
+        ],
+        "summary": "Download artifacts of job as single archive",
+        "operationId": "JobArtifactsArchiver",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "uint64",
+            "name": "JobID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "IMAGE",
+                "RESULT",
+                "TEST",
+                "YAML"
+              ],
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Types of artifacts to be archived. All types are archived if omitted.",
+            "name": "type",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "tar.gz",
+              "zip"
+            ],
+            "type": "string",
+            "default": "tar.gz",
+            "description": "Format of the archive.",
+            "name": "format",
+            "in": "query"
+          }
         ],
-        "summary": "Show current version of Weles internals",
-        "operationId": "Version",
         "responses": {
           "200": {
             "description": "OK",
             "schema": {
-              "$ref": "#/definitions/Version"
+              "type": "file"
             },
             "headers": {
-              "Weles-API-State": {
-                "type": "string",
-                "description": "State of Weles API."
-              },
-              "Weles-API-Version": {
-                "type": "string",
-                "description": "Version of Weles API."
-              },
-              "Weles-Server-Version": {
+              "Content-Disposition": {
                 "type": "string",
-                "description": "Version of Weles server."
+                "description": "Suggested name of the archive file."
               }
             }
           },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
           "500": {
             "$ref": "#/responses/InternalServer"
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "ArtifactAlias": {
-      "description": "is an alternative name of an artifact.",
-      "type": "string"
-    },
-    "ArtifactDescription": {
-      "description": "contains information needed to create new artifact in ArtifactDB.",
-      "type": "object",
-      "properties": {
-        "Alias": {
-          "$ref": "#/definitions/ArtifactAlias"
-        },
-        "JobID": {
-          "description": "specifies  Job for which artifact was created.",
-          "$ref": "#/definitions/JobID"
-        },
-        "Type": {
-          "$ref": "#/definitions/ArtifactType"
-        },
-        "URI": {
-          "$ref": "#/definitions/ArtifactURI"
-        }
-      }
     },
-    "ArtifactFilter": {
-      "description": "is used to filter results from ArtifactDB.",
-      "type": "object",
-      "properties": {
-        "Alias": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/ArtifactAlias"
-          }
-        },
-        "JobID": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/JobID"
-          }
-        },
-        "Status": {
+    "/jobs/{JobID}/cancel": {
+      "post": {
+        "description": "JobCanceler stops execution of Job identified by JobID.",
+        "consumes": [
+          "application/json"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "jobs"
+        ],
+        "summary": "Cancel existing job",
+        "operationId": "JobCanceler",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "uint64",
+            "name": "JobID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "403": {
+            "$ref": "#/responses/Forbidden"
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      }
+    },
+    "/schema/job": {
+      "get": {
+        "description": "JSON Schema (draft-04) of YAML job description accepted by Weles. It may be used by editors for autocompletion and linting of job files.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "general"
+        ],
+        "summary": "Show JSON Schema of job description",
+        "operationId": "JobSchema",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "object"
+            }
+          }
+        }
+      }
+    },
+    "/snippets": {
+      "get": {
+        "description": "SnippetLister returns all versions of snippets ordered by name and version.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "snippets"
+        ],
+        "summary": "List snippets",
+        "operationId": "SnippetLister",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "limits the list to versions of snippet with given name.",
+            "name": "name",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Snippet"
+              }
+            }
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      }
+    },
+    "/snippets/{SnippetName}": {
+      "post": {
+        "description": "SnippetCreator stores YAML fragment as a new version of snippet. Versions are numbered from 1 and cannot be modified, so snippet is updated by adding its new version. Jobs include snippets with \"include: name@vN\" (or \"include: name\" for the latest version) placed instead of a mapping or an item of a list. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header.",
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "snippets"
+        ],
+        "summary": "Add new version of snippet",
+        "operationId": "SnippetCreator",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "SnippetName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "file",
+            "description": "is YAML fragment stored as the snippet.",
+            "name": "snippetfile",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "describes content of the snippet.",
+            "name": "description",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Created",
+            "schema": {
+              "$ref": "#/definitions/Snippet"
+            }
+          },
+          "403": {
+            "$ref": "#/responses/Forbidden"
+          },
+          "415": {
+            "$ref": "#/responses/UnsupportedMediaType"
+          },
+          "422": {
+            "$ref": "#/responses/UnprocessableEntity"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      }
+    },
+    "/snippets/{SnippetName}/{SnippetVersion}": {
+      "get": {
+        "description": "SnippetGetter returns version of snippet.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "snippets"
+        ],
+        "summary": "Get snippet",
+        "operationId": "SnippetGetter",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "SnippetName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "SnippetVersion",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/Snippet"
+            }
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      },
+      "delete": {
+        "description": "SnippetDeleter removes version of snippet. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "snippets"
+        ],
+        "summary": "Delete snippet",
+        "operationId": "SnippetDeleter",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "SnippetName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "SnippetVersion",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "403": {
+            "$ref": "#/responses/Forbidden"
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      }
+    },
+    "/version": {
+      "get": {
+        "description": "Version and state of API (e.g. v1 obsolete, v2 stable, v3 devel) and server version.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "general"
+        ],
+        "summary": "Show current version of Weles internals",
+        "operationId": "Version",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            },
+            "headers": {
+              "Weles-API-State": {
+                "type": "string",
+                "description": "State of Weles API."
+              },
+              "Weles-API-Version": {
+                "type": "string",
+                "description": "Version of Weles API."
+              },
+              "Weles-Server-Version": {
+                "type": "string",
+                "description": "Version of Weles server."
+              }
+            }
+          },
+          "500": {
+            "$ref": "#/responses/InternalServer"
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "ArtifactAlias": {
+      "description": "is an alternative name of an artifact.",
+      "type": "string"
+    },
+    "ArtifactDescription": {
+      "description": "contains information needed to create new artifact in ArtifactDB.",
+      "type": "object",
+      "properties": {
+        "Alias": {
+          "$ref": "#/definitions/ArtifactAlias"
+        },
+        "JobID": {
+          "description": "specifies  Job for which artifact was created.",
+          "$ref": "#/definitions/JobID"
+        },
+        "Type": {
+          "$ref": "#/definitions/ArtifactType"
+        },
+        "URI": {
+          "$ref": "#/definitions/ArtifactURI"
+        }
+      }
+    },
+    "ArtifactFilter": {
+      "description": "is used to filter results from ArtifactDB.",
+      "type": "object",
+      "properties": {
+        "Alias": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ArtifactAlias"
+          }
+        },
+        "AliasContains": {
+          "description": "selects artifacts with alias containing any of given substrings.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "AliasRegexp": {
+          "description": "selects artifacts with alias matching any of given regular expressions.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "CreatedAfter": {
+          "description": "selects artifacts created after given time.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "CreatedBefore": {
+          "description": "selects artifacts created before given time.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "ETag": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "JobID": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/JobID"
+          }
+        },
+        "MaxSize": {
+          "description": "selects artifacts of at most given size in bytes. 0 means no limit.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "MimeType": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "MinSize": {
+          "description": "selects artifacts of at least given size in bytes.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "SHA256": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Status": {
           "type": "array",
           "items": {
             "$ref": "#/definitions/ArtifactStatus"
@@ -478,6 +1067,20 @@ func init() {
           "items": {
             "$ref": "#/definitions/ArtifactType"
           }
+        },
+        "URIContains": {
+          "description": "selects artifacts with URI containing any of given substrings.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "URIRegexp": {
+          "description": "selects artifacts with URI matching any of given regular expressions.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         }
       }
     },
@@ -490,15 +1093,52 @@ func init() {
         }
       ],
       "properties": {
+        "DownloadRate": {
+          "description": "is current download rate of the artifact in bytes per second.",
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "db:\"-\""
+        },
+        "ETag": {
+          "description": "is ETag returned by the source of the artifact.",
+          "type": "string"
+        },
         "ID": {
           "description": "unique identification of the artifact.",
           "type": "integer",
           "format": "int64",
           "x-go-custom-tag": "db:\",primarykey, autoincrement\""
         },
+        "LastModified": {
+          "description": "is Last-Modified value returned by the source of the artifact.",
+          "type": "string"
+        },
+        "MimeType": {
+          "description": "is detected MIME type of the ready artifact.",
+          "type": "string"
+        },
         "Path": {
           "$ref": "#/definitions/ArtifactPath"
         },
+        "Pinned": {
+          "description": "is set if the artifact is never purged by retention policy.",
+          "type": "boolean"
+        },
+        "ReceivedBytes": {
+          "description": "is number of bytes of the artifact downloaded so far.",
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "db:\"-\""
+        },
+        "SHA256": {
+          "description": "is hex encoded SHA256 digest of the ready artifact.",
+          "type": "string"
+        },
+        "Size": {
+          "description": "is size of the ready artifact in bytes.",
+          "type": "integer",
+          "format": "int64"
+        },
         "Status": {
           "$ref": "#/definitions/ArtifactStatus"
         },
@@ -506,6 +1146,12 @@ func init() {
           "description": "is date of creating the artifact.",
           "type": "string",
           "format": "date-time"
+        },
+        "TotalBytes": {
+          "description": "is size of the artifact reported by the server. It is -1 if the size is unknown.",
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "db:\"-\""
         }
       }
     },
@@ -514,10 +1160,16 @@ func init() {
       "type": "string"
     },
     "ArtifactSortBy": {
-      "description": "denotes the key for sorting list of all artifacts.\n* ID - sorting by artifact ID.\n",
+      "description": "denotes the key for sorting list of all artifacts.\n\n* ID - sorting by artifact ID.\n\n* Timestamp - sorting by date of creation of the artifact.\n\n* JobID - sorting by ID of the job the artifact belongs to.\n\n* Type - sorting by type of the artifact.\n\n* Status - sorting by status of the artifact.\n\n* Alias - sorting by alias of the artifact.\n\n* Size - sorting by size of the artifact file.\n\nWhen sorting is applied, and there are many artifacts with the same value of the key, they will be sorted by ID in the same order.\n",
       "type": "string",
       "enum": [
-        "ID"
+        "ID",
+        "Timestamp",
+        "JobID",
+        "Type",
+        "Status",
+        "Alias",
+        "Size"
       ]
     },
     "ArtifactSorter": {
@@ -533,13 +1185,15 @@ func init() {
       }
     },
     "ArtifactStatus": {
-      "description": "describes artifact status and availability.\n\n* DOWNLOADING - artifact is currently being downloaded.\n\n* READY - artifact has been downloaded and is ready to use.\n\n* FAILED - file is not available for use (e.g. download failed).\n\n* PENDING - artifact download has not started yet.\n",
+      "description": "describes artifact status and availability.\n\n* DOWNLOADING - artifact is currently being downloaded.\n\n* READY - artifact has been downloaded and is ready to use.\n\n* FAILED - file is not available for use (e.g. download failed).\n\n* PENDING - artifact download has not started yet.\n\n* PURGED - file has been removed according to retention policy.\n\n* CANCELED - download has been canceled (e.g. Job has been canceled or failed).\n",
       "type": "string",
       "enum": [
         "DOWNLOADING",
         "READY",
         "FAILED",
-        "PENDING"
+        "PENDING",
+        "PURGED",
+        "CANCELED"
       ]
     },
     "ArtifactType": {
@@ -688,6 +1342,58 @@ func init() {
         "CANCELED"
       ]
     },
+    "JobValidation": {
+      "description": "is a result of validating Job description without creating a Job.",
+      "type": "object",
+      "properties": {
+        "errors": {
+          "description": "lists problems which make the Job fail.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ValidationIssue"
+          }
+        },
+        "valid": {
+          "description": "is true if no errors were found. Job may have warnings.",
+          "type": "boolean"
+        },
+        "warnings": {
+          "description": "lists problems which may make the Job fail.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ValidationIssue"
+          }
+        }
+      }
+    },
+    "Snippet": {
+      "description": "is a named and versioned fragment of Job description which may be included in Jobs.",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "is YAML fragment included in Jobs.",
+          "type": "string"
+        },
+        "created": {
+          "description": "is time of adding the version.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "description": {
+          "description": "describes content of the snippet.",
+          "type": "string"
+        },
+        "name": {
+          "description": "identifies the snippet.",
+          "type": "string"
+        },
+        "version": {
+          "description": "is version of the snippet. Versions are numbered from 1.",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "SortOrder": {
       "description": "denotes direction of sorting of weles jobs or artifacts.\n\n* Ascending - from oldest to newest.\n\n* Descending - from newest to oldest.\n",
       "type": "string",
@@ -696,6 +1402,23 @@ func init() {
         "Descending"
       ]
     },
+    "ValidationIssue": {
+      "description": "describes a problem found in Job description.",
+      "type": "object",
+      "properties": {
+        "column": {
+          "description": "is column of Job description where the problem was found. It is 0 if position of the problem is unknown.",
+          "type": "integer"
+        },
+        "line": {
+          "description": "is line of Job description where the problem was found. It is 0 if position of the problem is unknown.",
+          "type": "integer"
+        },
+        "message": {
+          "type": "string"
+        }
+      }
+    },
     "Version": {
       "description": "defines version of Weles API (and its state) and server.\n",
       "type": "object",
@@ -727,86 +1450,500 @@ func init() {
         "$ref": "#/definitions/ErrResponse"
       }
     },
+    "Conflict": {
+      "description": "Conflict",
+      "schema": {
+        "$ref": "#/definitions/ErrResponse"
+      }
+    },
     "Forbidden": {
       "description": "Forbidden",
       "schema": {
         "$ref": "#/definitions/ErrResponse"
       }
     },
-    "InternalServer": {
-      "description": "Internal Server error",
-      "schema": {
-        "$ref": "#/definitions/ErrResponse"
+    "InternalServer": {
+      "description": "Internal Server error",
+      "schema": {
+        "$ref": "#/definitions/ErrResponse"
+      }
+    },
+    "NotFound": {
+      "description": "Not Found",
+      "schema": {
+        "$ref": "#/definitions/ErrResponse"
+      }
+    },
+    "UnprocessableEntity": {
+      "description": "Unprocessable entity",
+      "schema": {
+        "$ref": "#/definitions/ErrResponse"
+      }
+    },
+    "UnsupportedMediaType": {
+      "description": "Unsupported media type",
+      "schema": {
+        "$ref": "#/definitions/ErrResponse"
+      }
+    }
+  },
+  "tags": [
+    {
+      "description": "Info and management of Weles jobs.",
+      "name": "jobs"
+    },
+    {
+      "description": "Info about all artifacts used by Weles jobs.",
+      "name": "artifacts"
+    },
+    {
+      "description": "Library of reusable fragments of job descriptions.",
+      "name": "snippets"
+    },
+    {
+      "description": "Info about Weles (e.g. version)",
+      "name": "general"
+    }
+  ],
+  "externalDocs": {
+    "description": "TBD",
+    "url": "http://TBD.tbd"
+  }
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "This is a Weles server.   You can find out more about Weles at [http://tbd.tbd](http://tbd.tbd).",
+    "title": "Weles",
+    "termsOfService": "http://tbd.tbd/terms/",
+    "contact": {
+      "email": "tbd@tbd.com"
+    },
+    "license": {
+      "name": "Apache 2.0",
+      "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
+    },
+    "version": "v1"
+  },
+  "host": "localhost:8088",
+  "basePath": "/api/v1",
+  "paths": {
+    "/artifacts": {
+      "post": {
+        "description": "ArtifactUploader stores file passed by user in ArtifactDB. Returned ID may be used in Job description as weles://artifact/<ID> URI.",
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Upload new artifact",
+        "operationId": "ArtifactUploader",
+        "parameters": [
+          {
+            "type": "file",
+            "description": "is the file to be stored in ArtifactDB.",
+            "name": "artifactfile",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "is an alternative name of the artifact.",
+            "name": "alias",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "IMAGE",
+              "TEST"
+            ],
+            "type": "string",
+            "description": "is type of the artifact. TEST is used if not provided.",
+            "name": "type",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Created",
+            "schema": {
+              "description": "is ID of the uploaded artifact.",
+              "type": "integer",
+              "format": "int64"
+            }
+          },
+          "415": {
+            "description": "Unsupported media type",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "422": {
+            "description": "Unprocessable entity",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
+      }
+    },
+    "/artifacts/list": {
+      "post": {
+        "description": "ArtifactLister returns information on filtered Weles artifacts.",
+        "consumes": [
+          "application/json"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "List artifacts with filter and sort features",
+        "operationId": "ArtifactLister",
+        "parameters": [
+          {
+            "description": "Artifact Filter and Sort object.",
+            "name": "artifactFilterAndSort",
+            "in": "body",
+            "schema": {
+              "description": "Data for filtering and sorting Weles Jobs lists.",
+              "type": "object",
+              "properties": {
+                "Filter": {
+                  "$ref": "#/definitions/ArtifactFilter"
+                },
+                "Sorter": {
+                  "$ref": "#/definitions/ArtifactSorter"
+                }
+              }
+            }
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "ID of the last element from previous page.",
+            "name": "after",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "ID of first element from next page.",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "description": "Custom page limit. Denotes number of ArtifactInfo structures that will be returned.",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/ArtifactInfo"
+              }
+            },
+            "headers": {
+              "Next": {
+                "type": "string",
+                "format": "URI",
+                "description": "URI to request next page of data. Please note that the same body must be used as in initial request.\n"
+              },
+              "Previous": {
+                "type": "string",
+                "format": "URI",
+                "description": "URI to request next page of data. Please note that the same body must be used as in initial request.\n"
+              },
+              "TotalRecords": {
+                "type": "integer",
+                "format": "uint64",
+                "description": "count of records currently fulfilling the requested ArtifactFilter. Please note that this value may change when requesting for the same data at a different moment in time.\n"
+              }
+            }
+          },
+          "206": {
+            "description": "Partial Content",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/ArtifactInfo"
+              }
+            },
+            "headers": {
+              "Next": {
+                "type": "string",
+                "format": "URI",
+                "description": "URI to request next page of data. Please note that the same body must be used as in initial request.\n"
+              },
+              "Previous": {
+                "type": "string",
+                "format": "URI",
+                "description": "URI to request next page of data. Please note that the same body must be used as in initial request.\n"
+              },
+              "RemainingRecords": {
+                "type": "integer",
+                "format": "uint64",
+                "description": "number of records after current page. Please note that this value may change when requesting for the same data at a different moment in time.\n"
+              },
+              "TotalRecords": {
+                "type": "integer",
+                "format": "uint64",
+                "description": "count of records currently fulfilling the requested ArtifactFilter. Please note that this value may change when requesting for the same data at a different moment in time.\n"
+              }
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
+      }
+    },
+    "/artifacts/{ArtifactID}": {
+      "delete": {
+        "description": "ArtifactDeleter removes file and ArtifactDB record of artifact identified by ArtifactID. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header. Artifacts which are being downloaded or are used by active Jobs cannot be deleted.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Delete artifact",
+        "operationId": "ArtifactDeleter",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "ArtifactID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
       }
     },
-    "NotFound": {
-      "description": "Not Found",
-      "schema": {
-        "$ref": "#/definitions/ErrResponse"
+    "/artifacts/{ArtifactID}/pin": {
+      "post": {
+        "description": "ArtifactPinner protects artifact identified by ArtifactID from being purged by retention policy. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Pin artifact",
+        "operationId": "ArtifactPinner",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "ArtifactID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
       }
     },
-    "UnprocessableEntity": {
-      "description": "Unprocessable entity",
-      "schema": {
-        "$ref": "#/definitions/ErrResponse"
+    "/artifacts/{ArtifactID}/unpin": {
+      "post": {
+        "description": "ArtifactUnpinner allows artifact identified by ArtifactID to be purged by retention policy again. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Unpin artifact",
+        "operationId": "ArtifactUnpinner",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "ArtifactID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
       }
     },
-    "UnsupportedMediaType": {
-      "description": "Unsupported media type",
-      "schema": {
-        "$ref": "#/definitions/ErrResponse"
+    "/jobs": {
+      "post": {
+        "description": "adds new Job in Weles using recipe passed in YAML format. The recipe may be a template with placeholders of variables.",
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "jobs"
+        ],
+        "summary": "Add new job",
+        "operationId": "JobCreator",
+        "parameters": [
+          {
+            "type": "file",
+            "description": "is Job description yaml file.",
+            "name": "yamlfile",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "are values of variables used in Job description yaml file given in NAME=value form. Placeholders ${NAME} and ${NAME:-default} are replaced with these values, also in included snippets.",
+            "name": "variables",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Created",
+            "schema": {
+              "$ref": "#/definitions/JobID"
+            }
+          },
+          "415": {
+            "description": "Unsupported media type",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "422": {
+            "description": "Unprocessable entity",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
       }
-    }
-  },
-  "tags": [
-    {
-      "description": "Info and management of Weles jobs.",
-      "name": "jobs"
-    },
-    {
-      "description": "Info about all artifacts used by Weles jobs.",
-      "name": "artifacts"
-    },
-    {
-      "description": "Info about Weles (e.g. version)",
-      "name": "general"
-    }
-  ],
-  "externalDocs": {
-    "description": "TBD",
-    "url": "http://TBD.tbd"
-  }
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "This is a Weles server.   You can find out more about Weles at [http://tbd.tbd](http://tbd.tbd).",
-    "title": "Weles",
-    "termsOfService": "http://tbd.tbd/terms/",
-    "contact": {
-      "email": "tbd@tbd.com"
-    },
-    "license": {
-      "name": "Apache 2.0",
-      "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
     },
-    "version": "v1"
-  },
-  "host": "localhost:8088",
-  "basePath": "/api/v1",
-  "paths": {
-    "/artifacts/list": {
+    "/jobs/list": {
       "post": {
-        "description": "ArtifactLister returns information on filtered Weles artifacts.",
+        "description": "JobLister returns information on filtered Weles Jobs.",
         "consumes": [
           "application/json"
         ],
@@ -814,46 +1951,46 @@ func init() {
           "application/json"
         ],
         "tags": [
-          "artifacts"
+          "jobs"
         ],
-        "summary": "List artifacts with filter and sort features",
-        "operationId": "ArtifactLister",
+        "summary": "List jobs with filter and sort features",
+        "operationId": "JobLister",
         "parameters": [
           {
-            "description": "Artifact Filter and Sort object.",
-            "name": "artifactFilterAndSort",
+            "description": "Job Filter and Sort object.",
+            "name": "jobFilterAndSort",
             "in": "body",
             "schema": {
               "description": "Data for filtering and sorting Weles Jobs lists.",
               "type": "object",
               "properties": {
                 "Filter": {
-                  "$ref": "#/definitions/ArtifactFilter"
+                  "$ref": "#/definitions/JobFilter"
                 },
                 "Sorter": {
-                  "$ref": "#/definitions/ArtifactSorter"
+                  "$ref": "#/definitions/JobSorter"
                 }
               }
             }
           },
           {
-            "type": "integer",
-            "format": "int64",
-            "description": "ID of the last element from previous page.",
+            "type": "integer",
+            "format": "uint64",
+            "description": "JobID of the last element from previous page.",
             "name": "after",
             "in": "query"
           },
           {
             "type": "integer",
-            "format": "int64",
-            "description": "ID of first element from next page.",
+            "format": "uint64",
+            "description": "JobID of first element from next page.",
             "name": "before",
             "in": "query"
           },
           {
             "type": "integer",
             "format": "int32",
-            "description": "Custom page limit. Denotes number of ArtifactInfo structures that will be returned.",
+            "description": "Custom page limit. Denotes number of JobInfo structures that will be returned.",
             "name": "limit",
             "in": "query"
           }
@@ -864,7 +2001,7 @@ func init() {
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/ArtifactInfo"
+                "$ref": "#/definitions/JobInfo"
               }
             },
             "headers": {
@@ -876,12 +2013,12 @@ func init() {
               "Previous": {
                 "type": "string",
                 "format": "URI",
-                "description": "URI to request next page of data. Please note that the same body must be used as in initial request.\n"
+                "description": "URI to request previous page of data. Please note that the same body must be used as in initial request.\n"
               },
               "TotalRecords": {
                 "type": "integer",
                 "format": "uint64",
-                "description": "count of records currently fulfilling the requested ArtifactFilter. Please note that this value may change when requesting for the same data at a different moment in time.\n"
+                "description": "count of records currently fulfilling the requested JobFilter. Please note that this value may change when requesting for the same data at a different moment in time.\n"
               }
             }
           },
@@ -890,7 +2027,7 @@ func init() {
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/ArtifactInfo"
+                "$ref": "#/definitions/JobInfo"
               }
             },
             "headers": {
@@ -902,7 +2039,7 @@ func init() {
               "Previous": {
                 "type": "string",
                 "format": "URI",
-                "description": "URI to request next page of data. Please note that the same body must be used as in initial request.\n"
+                "description": "URI to request previous page of data. Please note that the same body must be used as in initial request.\n"
               },
               "RemainingRecords": {
                 "type": "integer",
@@ -912,7 +2049,7 @@ func init() {
               "TotalRecords": {
                 "type": "integer",
                 "format": "uint64",
-                "description": "count of records currently fulfilling the requested ArtifactFilter. Please note that this value may change when requesting for the same data at a different moment in time.\n"
+                "description": "count of records currently fulfilling requested JobFilter. Please note that this value may change when requesting for the same data at a different moment in time.\n"
               }
             }
           },
@@ -937,9 +2074,304 @@ func init() {
         }
       }
     },
-    "/jobs": {
+    "/jobs/validate": {
+      "post": {
+        "description": "JobValidator checks Job description passed in YAML format without creating a Job. Besides parsing and validating YAML, it verifies that device_type is available in Boruta and that URIs of artifacts are reachable. Problems which will certainly make the Job fail are reported as errors, others as warnings.",
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "jobs"
+        ],
+        "summary": "Validate job description",
+        "operationId": "JobValidator",
+        "parameters": [
+          {
+            "type": "file",
+            "description": "is Job description yaml file.",
+            "name": "yamlfile",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "are values of variables used in Job description yaml file given in NAME=value form, the same as passed to JobCreator.",
+            "name": "variables",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/JobValidation"
+            }
+          },
+          "415": {
+            "description": "Unsupported media type",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "422": {
+            "description": "Unprocessable entity",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
+      }
+    },
+    "/jobs/{JobID}/artifacts": {
+      "delete": {
+        "description": "JobArtifactsDeleter removes files and ArtifactDB records of all artifacts of Job identified by JobID. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header. Artifacts cannot be deleted if any of them is being downloaded or is used by an active Job.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Delete artifacts of job",
+        "operationId": "JobArtifactsDeleter",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "uint64",
+            "name": "JobID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
+      }
+    },
+    "/jobs/{JobID}/artifacts/archive": {
+      "get": {
+        "description": "JobArtifactsArchiver streams archive containing all READY artifacts of Job identified by JobID. Files are named after type and alias of artifacts. Archive contains also manifest.json file describing archived artifacts.",
+        "produces": [
+          "application/octet-stream",
+          "application/json"
+        ],
+        "tags": [
+          "artifacts"
+        ],
+        "summary": "Download artifacts of job as single archive",
+        "operationId": "JobArtifactsArchiver",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "uint64",
+            "name": "JobID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "IMAGE",
+                "RESULT",
+                "TEST",
+                "YAML"
+              ],
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Types of artifacts to be archived. All types are archived if omitted.",
+            "name": "type",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "tar.gz",
+              "zip"
+            ],
+            "type": "string",
+            "default": "tar.gz",
+            "description": "Format of the archive.",
+            "name": "format",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Content-Disposition": {
+                "type": "string",
+                "description": "Suggested name of the archive file."
+              }
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
+      }
+    },
+    "/jobs/{JobID}/cancel": {
+      "post": {
+        "description": "JobCanceler stops execution of Job identified by JobID.",
+        "consumes": [
+          "application/json"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "jobs"
+        ],
+        "summary": "Cancel existing job",
+        "operationId": "JobCanceler",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "uint64",
+            "name": "JobID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
+      }
+    },
+    "/schema/job": {
+      "get": {
+        "description": "JSON Schema (draft-04) of YAML job description accepted by Weles. It may be used by editors for autocompletion and linting of job files.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "general"
+        ],
+        "summary": "Show JSON Schema of job description",
+        "operationId": "JobSchema",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "object"
+            }
+          }
+        }
+      }
+    },
+    "/snippets": {
+      "get": {
+        "description": "SnippetLister returns all versions of snippets ordered by name and version.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "snippets"
+        ],
+        "summary": "List snippets",
+        "operationId": "SnippetLister",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "limits the list to versions of snippet with given name.",
+            "name": "name",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Snippet"
+              }
+            }
+          },
+          "500": {
+            "description": "Internal Server error",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
+            }
+          }
+        }
+      }
+    },
+    "/snippets/{SnippetName}": {
       "post": {
-        "description": "adds new Job in Weles using recipe passed in YAML format.",
+        "description": "SnippetCreator stores YAML fragment as a new version of snippet. Versions are numbered from 1 and cannot be modified, so snippet is updated by adding its new version. Jobs include snippets with \"include: name@vN\" (or \"include: name\" for the latest version) placed instead of a mapping or an item of a list. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header.",
         "consumes": [
           "multipart/form-data"
         ],
@@ -947,24 +2379,42 @@ func init() {
           "application/json"
         ],
         "tags": [
-          "jobs"
+          "snippets"
         ],
-        "summary": "Add new job",
-        "operationId": "JobCreator",
+        "summary": "Add new version of snippet",
+        "operationId": "SnippetCreator",
         "parameters": [
+          {
+            "type": "string",
+            "name": "SnippetName",
+            "in": "path",
+            "required": true
+          },
           {
             "type": "file",
-            "description": "is Job description yaml file.",
-            "name": "yamlfile",
+            "description": "is YAML fragment stored as the snippet.",
+            "name": "snippetfile",
             "in": "formData",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "describes content of the snippet.",
+            "name": "description",
+            "in": "formData"
           }
         ],
         "responses": {
           "201": {
             "description": "Created",
             "schema": {
-              "$ref": "#/definitions/JobID"
+              "$ref": "#/definitions/Snippet"
+            }
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrResponse"
             }
           },
           "415": {
@@ -988,122 +2438,37 @@ func init() {
         }
       }
     },
-    "/jobs/list": {
-      "post": {
-        "description": "JobLister returns information on filtered Weles Jobs.",
-        "consumes": [
-          "application/json"
-        ],
+    "/snippets/{SnippetName}/{SnippetVersion}": {
+      "get": {
+        "description": "SnippetGetter returns version of snippet.",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "jobs"
+          "snippets"
         ],
-        "summary": "List jobs with filter and sort features",
-        "operationId": "JobLister",
+        "summary": "Get snippet",
+        "operationId": "SnippetGetter",
         "parameters": [
           {
-            "description": "Job Filter and Sort object.",
-            "name": "jobFilterAndSort",
-            "in": "body",
-            "schema": {
-              "description": "Data for filtering and sorting Weles Jobs lists.",
-              "type": "object",
-              "properties": {
-                "Filter": {
-                  "$ref": "#/definitions/JobFilter"
-                },
-                "Sorter": {
-                  "$ref": "#/definitions/JobSorter"
-                }
-              }
-            }
-          },
-          {
-            "type": "integer",
-            "format": "uint64",
-            "description": "JobID of the last element from previous page.",
-            "name": "after",
-            "in": "query"
-          },
-          {
-            "type": "integer",
-            "format": "uint64",
-            "description": "JobID of first element from next page.",
-            "name": "before",
-            "in": "query"
+            "type": "string",
+            "name": "SnippetName",
+            "in": "path",
+            "required": true
           },
           {
             "type": "integer",
-            "format": "int32",
-            "description": "Custom page limit. Denotes number of JobInfo structures that will be returned.",
-            "name": "limit",
-            "in": "query"
+            "format": "int64",
+            "name": "SnippetVersion",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
             "description": "OK",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/JobInfo"
-              }
-            },
-            "headers": {
-              "Next": {
-                "type": "string",
-                "format": "URI",
-                "description": "URI to request next page of data. Please note that the same body must be used as in initial request.\n"
-              },
-              "Previous": {
-                "type": "string",
-                "format": "URI",
-                "description": "URI to request previous page of data. Please note that the same body must be used as in initial request.\n"
-              },
-              "TotalRecords": {
-                "type": "integer",
-                "format": "uint64",
-                "description": "count of records currently fulfilling the requested JobFilter. Please note that this value may change when requesting for the same data at a different moment in time.\n"
-              }
-            }
-          },
-          "206": {
-            "description": "Partial Content",
-            "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/JobInfo"
-              }
-            },
-            "headers": {
-              "Next": {
-                "type": "string",
-                "format": "URI",
-                "description": "URI to request next page of data. Please note that the same body must be used as in initial request.\n"
-              },
-              "Previous": {
-                "type": "string",
-                "format": "URI",
-                "description": "URI to request previous page of data. Please note that the same body must be used as in initial request.\n"
-              },
-              "RemainingRecords": {
-                "type": "integer",
-                "format": "uint64",
-                "description": "number of records after current page. Please note that this value may change when requesting for the same data at a different moment in time.\n"
-              },
-              "TotalRecords": {
-                "type": "integer",
-                "format": "uint64",
-                "description": "count of records currently fulfilling requested JobFilter. Please note that this value may change when requesting for the same data at a different moment in time.\n"
-              }
-            }
-          },
-          "400": {
-            "description": "Bad Request",
-            "schema": {
-              "$ref": "#/definitions/ErrResponse"
+              "$ref": "#/definitions/Snippet"
             }
           },
           "404": {
@@ -1119,27 +2484,28 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/jobs/{JobID}/cancel": {
-      "post": {
-        "description": "JobCanceler stops execution of Job identified by JobID.",
-        "consumes": [
-          "application/json"
-        ],
+      },
+      "delete": {
+        "description": "SnippetDeleter removes version of snippet. Request must be authorized with administrator token passed in \"Authorization: Bearer <token>\" header.",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "jobs"
+          "snippets"
         ],
-        "summary": "Cancel existing job",
-        "operationId": "JobCanceler",
+        "summary": "Delete snippet",
+        "operationId": "SnippetDeleter",
         "parameters": [
+          {
+            "type": "string",
+            "name": "SnippetName",
+            "in": "path",
+            "required": true
+          },
           {
             "type": "integer",
-            "format": "uint64",
-            "name": "JobID",
+            "format": "int64",
+            "name": "SnippetVersion",
             "in": "path",
             "required": true
           }
@@ -1245,12 +2611,64 @@ func init() {
             "$ref": "#/definitions/ArtifactAlias"
           }
         },
+        "AliasContains": {
+          "description": "selects artifacts with alias containing any of given substrings.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "AliasRegexp": {
+          "description": "selects artifacts with alias matching any of given regular expressions.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "CreatedAfter": {
+          "description": "selects artifacts created after given time.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "CreatedBefore": {
+          "description": "selects artifacts created before given time.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "ETag": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "JobID": {
           "type": "array",
           "items": {
             "$ref": "#/definitions/JobID"
           }
         },
+        "MaxSize": {
+          "description": "selects artifacts of at most given size in bytes. 0 means no limit.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "MimeType": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "MinSize": {
+          "description": "selects artifacts of at least given size in bytes.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "SHA256": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "Status": {
           "type": "array",
           "items": {
@@ -1262,6 +2680,20 @@ func init() {
           "items": {
             "$ref": "#/definitions/ArtifactType"
           }
+        },
+        "URIContains": {
+          "description": "selects artifacts with URI containing any of given substrings.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "URIRegexp": {
+          "description": "selects artifacts with URI matching any of given regular expressions.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         }
       }
     },
@@ -1274,15 +2706,52 @@ func init() {
         }
       ],
       "properties": {
+        "DownloadRate": {
+          "description": "is current download rate of the artifact in bytes per second.",
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "db:\"-\""
+        },
+        "ETag": {
+          "description": "is ETag returned by the source of the artifact.",
+          "type": "string"
+        },
         "ID": {
           "description": "unique identification of the artifact.",
           "type": "integer",
           "format": "int64",
           "x-go-custom-tag": "db:\",primarykey, autoincrement\""
         },
+        "LastModified": {
+          "description": "is Last-Modified value returned by the source of the artifact.",
+          "type": "string"
+        },
+        "MimeType": {
+          "description": "is detected MIME type of the ready artifact.",
+          "type": "string"
+        },
         "Path": {
           "$ref": "#/definitions/ArtifactPath"
         },
+        "Pinned": {
+          "description": "is set if the artifact is never purged by retention policy.",
+          "type": "boolean"
+        },
+        "ReceivedBytes": {
+          "description": "is number of bytes of the artifact downloaded so far.",
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "db:\"-\""
+        },
+        "SHA256": {
+          "description": "is hex encoded SHA256 digest of the ready artifact.",
+          "type": "string"
+        },
+        "Size": {
+          "description": "is size of the ready artifact in bytes.",
+          "type": "integer",
+          "format": "int64"
+        },
         "Status": {
           "$ref": "#/definitions/ArtifactStatus"
         },
@@ -1290,6 +2759,12 @@ func init() {
           "description": "is date of creating the artifact.",
           "type": "string",
           "format": "date-time"
+        },
+        "TotalBytes": {
+          "description": "is size of the artifact reported by the server. It is -1 if the size is unknown.",
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "db:\"-\""
         }
       }
     },
@@ -1298,10 +2773,16 @@ func init() {
       "type": "string"
     },
     "ArtifactSortBy": {
-      "description": "denotes the key for sorting list of all artifacts.\n* ID - sorting by artifact ID.\n",
+      "description": "denotes the key for sorting list of all artifacts.\n\n* ID - sorting by artifact ID.\n\n* Timestamp - sorting by date of creation of the artifact.\n\n* JobID - sorting by ID of the job the artifact belongs to.\n\n* Type - sorting by type of the artifact.\n\n* Status - sorting by status of the artifact.\n\n* Alias - sorting by alias of the artifact.\n\n* Size - sorting by size of the artifact file.\n\nWhen sorting is applied, and there are many artifacts with the same value of the key, they will be sorted by ID in the same order.\n",
       "type": "string",
       "enum": [
-        "ID"
+        "ID",
+        "Timestamp",
+        "JobID",
+        "Type",
+        "Status",
+        "Alias",
+        "Size"
       ]
     },
     "ArtifactSorter": {
@@ -1317,13 +2798,15 @@ func init() {
       }
     },
     "ArtifactStatus": {
-      "description": "describes artifact status and availability.\n\n* DOWNLOADING - artifact is currently being downloaded.\n\n* READY - artifact has been downloaded and is ready to use.\n\n* FAILED - file is not available for use (e.g. download failed).\n\n* PENDING - artifact download has not started yet.\n",
+      "description": "describes artifact status and availability.\n\n* DOWNLOADING - artifact is currently being downloaded.\n\n* READY - artifact has been downloaded and is ready to use.\n\n* FAILED - file is not available for use (e.g. download failed).\n\n* PENDING - artifact download has not started yet.\n\n* PURGED - file has been removed according to retention policy.\n\n* CANCELED - download has been canceled (e.g. Job has been canceled or failed).\n",
       "type": "string",
       "enum": [
         "DOWNLOADING",
         "READY",
         "FAILED",
-        "PENDING"
+        "PENDING",
+        "PURGED",
+        "CANCELED"
       ]
     },
     "ArtifactType": {
@@ -1472,6 +2955,58 @@ func init() {
         "CANCELED"
       ]
     },
+    "JobValidation": {
+      "description": "is a result of validating Job description without creating a Job.",
+      "type": "object",
+      "properties": {
+        "errors": {
+          "description": "lists problems which make the Job fail.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ValidationIssue"
+          }
+        },
+        "valid": {
+          "description": "is true if no errors were found. Job may have warnings.",
+          "type": "boolean"
+        },
+        "warnings": {
+          "description": "lists problems which may make the Job fail.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ValidationIssue"
+          }
+        }
+      }
+    },
+    "Snippet": {
+      "description": "is a named and versioned fragment of Job description which may be included in Jobs.",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "is YAML fragment included in Jobs.",
+          "type": "string"
+        },
+        "created": {
+          "description": "is time of adding the version.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "description": {
+          "description": "describes content of the snippet.",
+          "type": "string"
+        },
+        "name": {
+          "description": "identifies the snippet.",
+          "type": "string"
+        },
+        "version": {
+          "description": "is version of the snippet. Versions are numbered from 1.",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "SortOrder": {
       "description": "denotes direction of sorting of weles jobs or artifacts.\n\n* Ascending - from oldest to newest.\n\n* Descending - from newest to oldest.\n",
       "type": "string",
@@ -1480,6 +3015,23 @@ func init() {
         "Descending"
       ]
     },
+    "ValidationIssue": {
+      "description": "describes a problem found in Job description.",
+      "type": "object",
+      "properties": {
+        "column": {
+          "description": "is column of Job description where the problem was found. It is 0 if position of the problem is unknown.",
+          "type": "integer"
+        },
+        "line": {
+          "description": "is line of Job description where the problem was found. It is 0 if position of the problem is unknown.",
+          "type": "integer"
+        },
+        "message": {
+          "type": "string"
+        }
+      }
+    },
     "Version": {
       "description": "defines version of Weles API (and its state) and server.\n",
       "type": "object",
@@ -1511,6 +3063,12 @@ func init() {
         "$ref": "#/definitions/ErrResponse"
       }
     },
+    "Conflict": {
+      "description": "Conflict",
+      "schema": {
+        "$ref": "#/definitions/ErrResponse"
+      }
+    },
     "Forbidden": {
       "description": "Forbidden",
       "schema": {
@@ -1551,6 +3109,10 @@ func init() {
       "description": "Info about all artifacts used by Weles jobs.",
       "name": "artifacts"
     },
+    {
+      "description": "Library of reusable fragments of job descriptions.",
+      "name": "snippets"
+    },
     {
       "description": "Info about Weles (e.g. version)",
       "name": "general"
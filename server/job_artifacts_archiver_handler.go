@@ -0,0 +1,74 @@
+// Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/artifacts/archive"
+	"github.com/SamsungSLAV/weles/server/operations/artifacts"
+)
+
+// JobArtifactsArchiver is a handler which streams archive of ready artifacts of the job listed by
+// ArtifactManager.
+func (a *APIDefaults) JobArtifactsArchiver(params artifacts.JobArtifactsArchiverParams,
+) middleware.Responder {
+	format := archive.TarGz
+	if params.Format != nil {
+		format = *params.Format
+	}
+	filter := weles.ArtifactFilter{
+		JobID:  []weles.JobID{weles.JobID(params.JobID)},
+		Status: []weles.ArtifactStatus{weles.ArtifactStatusREADY},
+	}
+	for _, t := range params.Type {
+		filter.Type = append(filter.Type, weles.ArtifactType(t))
+	}
+	sorter := weles.ArtifactSorter{
+		SortOrder: weles.SortOrderAscending,
+		SortBy:    weles.ArtifactSortByID,
+	}
+
+	list, _, err := a.Managers.AM.ListArtifact(filter, sorter, weles.ArtifactPagination{})
+	switch err {
+	default:
+		return artifacts.NewJobArtifactsArchiverInternalServerError().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	case weles.ErrArtifactNotFound:
+		return artifacts.NewJobArtifactsArchiverNotFound().WithPayload(
+			&weles.ErrResponse{Message: weles.ErrArtifactNotFound.Error()})
+	case nil:
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		// Errors are passed to the reader, so that streaming of the response is aborted
+		// and the client does not receive truncated archive as a complete one.
+		err := archive.Write(pw, format, list)
+		if err != nil {
+			log.Printf("failed to archive artifacts of job %d: %s", params.JobID, err)
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return artifacts.NewJobArtifactsArchiverOK().WithPayload(pr).WithContentDisposition(
+		fmt.Sprintf("attachment; filename=\"job-%d-artifacts%s\"", params.JobID,
+			archive.Extension(format)))
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/artifacts/archive"
+	"github.com/SamsungSLAV/weles/mock"
+)
+
+var _ = Describe("JobArtifactsArchiverHandler", func() {
+
+	const content = "silverKangaroo"
+
+	var (
+		mockCtrl            *gomock.Controller
+		mockArtifactManager *mock.MockArtifactManager
+		testserver          *httptest.Server
+		artifactFile        *os.File
+		artifact            weles.ArtifactInfo
+	)
+
+	sorter := weles.ArtifactSorter{
+		SortOrder: weles.SortOrderAscending,
+		SortBy:    weles.ArtifactSortByID,
+	}
+
+	BeforeEach(func() {
+		mockCtrl, _, mockArtifactManager, _, testserver = testServerSetup()
+		var err error
+		artifactFile, err = ioutil.TempFile("", "weles-")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = artifactFile.WriteString(content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(artifactFile.Close()).To(Succeed())
+		// Result created by Downloader for a pull action, which is marked as ready
+		// by DryadJobRunner once the file is copied from the device.
+		artifact = weles.ArtifactInfo{
+			ArtifactDescription: weles.ArtifactDescription{
+				JobID: 17,
+				Type:  weles.ArtifactTypeRESULT,
+				Alias: "bronzeYak",
+			},
+			ID:        3,
+			Path:      weles.ArtifactPath(artifactFile.Name()),
+			Status:    weles.ArtifactStatusREADY,
+			Timestamp: strfmt.DateTime(time.Now().UTC()),
+			Size:      int64(len(content)),
+			MimeType:  "text/plain; charset=utf-8",
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+		testserver.Close()
+		Expect(os.Remove(artifactFile.Name())).To(Succeed())
+	})
+
+	getReq := func(query string) *http.Response {
+		resp, err := testserver.Client().Get(testserver.URL + basePath +
+			"/jobs/17/artifacts/archive" + query)
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	filter := func(types ...weles.ArtifactType) weles.ArtifactFilter {
+		return weles.ArtifactFilter{
+			JobID:  []weles.JobID{17},
+			Status: []weles.ArtifactStatus{weles.ArtifactStatusREADY},
+			Type:   types,
+		}
+	}
+
+	It("should stream tar.gz archive by default", func() {
+		mockArtifactManager.EXPECT().ListArtifact(filter(), sorter, weles.ArtifactPagination{}).
+			Return([]weles.ArtifactInfo{artifact}, weles.ListInfo{TotalRecords: 1}, nil)
+		resp := getReq("")
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(200))
+		Expect(resp.Header.Get("Content-Disposition")).To(Equal(
+			"attachment; filename=\"job-17-artifacts.tar.gz\""))
+
+		gz, err := gzip.NewReader(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		tr := tar.NewReader(gz)
+		hdr, err := tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hdr.Name).To(Equal(archive.ManifestName))
+		hdr, err = tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hdr.Name).To(Equal("RESULT/bronzeYak"))
+		data, err := ioutil.ReadAll(tr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(content))
+	})
+
+	It("should stream zip archive of requested types", func() {
+		mockArtifactManager.EXPECT().ListArtifact(
+			filter(weles.ArtifactTypeRESULT, weles.ArtifactTypeTEST), sorter,
+			weles.ArtifactPagination{}).
+			Return([]weles.ArtifactInfo{artifact}, weles.ListInfo{TotalRecords: 1}, nil)
+		resp := getReq("?format=zip&type=RESULT&type=TEST")
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(200))
+		Expect(resp.Header.Get("Content-Disposition")).To(Equal(
+			"attachment; filename=\"job-17-artifacts.zip\""))
+
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(zr.File).To(HaveLen(2))
+		Expect(zr.File[0].Name).To(Equal(archive.ManifestName))
+		Expect(zr.File[1].Name).To(Equal("RESULT/bronzeYak"))
+	})
+
+	It("should abort streaming if archiving fails", func() {
+		Expect(os.Remove(artifactFile.Name())).To(Succeed())
+		mockArtifactManager.EXPECT().ListArtifact(filter(), sorter, weles.ArtifactPagination{}).
+			Return([]weles.ArtifactInfo{artifact}, weles.ListInfo{TotalRecords: 1}, nil)
+		// Connection is dropped either before or after headers are sent.
+		resp, err := testserver.Client().Get(testserver.URL + basePath +
+			"/jobs/17/artifacts/archive")
+		if err == nil {
+			_, err = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+		Expect(err).To(HaveOccurred())
+
+		// Recreate the file removed in AfterEach.
+		Expect(ioutil.WriteFile(artifactFile.Name(), nil, 0600)).To(Succeed())
+	})
+
+	DescribeTable("server should respond with appropriate error",
+		func(erro error, statuscode int) {
+			mockArtifactManager.EXPECT().ListArtifact(filter(), sorter,
+				weles.ArtifactPagination{}).Return(nil, weles.ListInfo{}, erro)
+			resp := getReq("")
+			defer resp.Body.Close()
+
+			respBody, err := ioutil.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			errorEncoded, err := json.Marshal(weles.ErrResponse{Message: erro.Error()})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(respBody)).To(MatchJSON(string(errorEncoded)))
+			Expect(resp.StatusCode).To(Equal(statuscode))
+		},
+		Entry("no artifacts - 404", weles.ErrArtifactNotFound, 404),
+		Entry("unexpected error - 500", errors.New("Some other error"), 500),
+	)
+
+	DescribeTable("server should reject invalid query parameters",
+		func(query string) {
+			resp := getReq(query)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(422))
+		},
+		Entry("unknown format", "?format=rar"),
+		Entry("unknown type", "?type=BRASS"),
+	)
+})
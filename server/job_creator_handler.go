@@ -19,10 +19,13 @@ import (
 	"github.com/SamsungSLAV/weles/server/operations/jobs"
 	middleware "github.com/go-openapi/runtime/middleware"
 
+	"fmt"
 	"io/ioutil"
+	"strings"
 )
 
-// JobCreator is a handler which passes yaml file with job description to jobmanager.
+// JobCreator is a handler which passes yaml file with job description and values of
+// variables to jobmanager.
 func (m *Managers) JobCreator(params jobs.JobCreatorParams) middleware.Responder {
 	byteContainer, err := ioutil.ReadAll(params.Yamlfile)
 	if err != nil {
@@ -30,11 +33,38 @@ func (m *Managers) JobCreator(params jobs.JobCreatorParams) middleware.Responder
 			&weles.ErrResponse{Message: err.Error(), Type: ""})
 	}
 
-	jobID, err := m.JM.CreateJob(byteContainer)
+	vars, err := parseVariables(params.Variables)
 	if err != nil {
-		return jobs.NewJobCreatorInternalServerError().WithPayload(
+		return jobs.NewJobCreatorUnprocessableEntity().WithPayload(
 			&weles.ErrResponse{Message: err.Error(), Type: ""})
 	}
 
+	jobID, err := m.JM.CreateJob(byteContainer, vars)
+	if err != nil {
+		switch err.(type) {
+		case weles.ErrInvalidArgument:
+			return jobs.NewJobCreatorUnprocessableEntity().WithPayload(
+				&weles.ErrResponse{Message: err.Error(), Type: ""})
+		default:
+			return jobs.NewJobCreatorInternalServerError().WithPayload(
+				&weles.ErrResponse{Message: err.Error(), Type: ""})
+		}
+	}
+
 	return jobs.NewJobCreatorCreated().WithPayload(jobID)
 }
+
+// parseVariables converts variables given in NAME=value form to a map. If a variable
+// is given more than once, the last value is used.
+func parseVariables(variables []string) (map[string]string, error) {
+	vars := make(map[string]string, len(variables))
+	for _, v := range variables {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, weles.ErrInvalidArgument(
+				fmt.Sprintf("variable %q is not in NAME=value form", v))
+		}
+		vars[kv[0]] = kv[1]
+	}
+	return vars, nil
+}
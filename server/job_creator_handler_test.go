@@ -54,9 +54,13 @@ var _ = Describe("JobCreatorHandler", func() {
 	})
 
 	Describe("Creating a job", func() {
-		requestBody := func(fileName string, fieldName string, acceptH string) (req *http.Request) {
+		requestBody := func(fileName string, fieldName string, acceptH string,
+			variables ...string) (req *http.Request) {
 			bodyBuf := &bytes.Buffer{}
 			bodyWriter := multipart.NewWriter(bodyBuf)
+			for _, v := range variables {
+				Expect(bodyWriter.WriteField("variables", v)).To(Succeed())
+			}
 			//create new form-data header with provided key-value pair
 			fileWriter, err := bodyWriter.CreateFormFile(fieldName, fileName)
 			Expect(err).ToNot(HaveOccurred())
@@ -92,7 +96,8 @@ var _ = Describe("JobCreatorHandler", func() {
 					req := requestBody("test_sample.yml", "yamlfile", accept)
 					orgBody := mockInput("test_sample.yml")
 					client := testserver.Client()
-					mockJobManager.EXPECT().CreateJob(orgBody).Return(weles.JobID(1234), nil)
+					mockJobManager.EXPECT().CreateJob(orgBody, map[string]string{}).Return(
+						weles.JobID(1234), nil)
 
 					resp, err := client.Do(req)
 					Expect(err).ToNot(HaveOccurred())
@@ -116,8 +121,8 @@ var _ = Describe("JobCreatorHandler", func() {
 					req := requestBody("test_sample.yml", "yamlfile", accept)
 					orgBody := mockInput("test_sample.yml")
 					client := testserver.Client()
-					mockJobManager.EXPECT().CreateJob(orgBody).Return(weles.JobID(0), errors.New(
-						"Unparsable"))
+					mockJobManager.EXPECT().CreateJob(orgBody, map[string]string{}).Return(
+						weles.JobID(0), errors.New("Unparsable"))
 
 					resp, err := client.Do(req)
 					Expect(err).ToNot(HaveOccurred())
@@ -133,6 +138,57 @@ var _ = Describe("JobCreatorHandler", func() {
 			)
 		})
 
+		Context("server receives POST request with variables", func() {
+			It("should pass values of variables to CreateJob", func() {
+				req := requestBody("test_sample.yml", "yamlfile", JSON,
+					"IMAGE_URL=http://example.com/image.img?a=b", "BUILD=1", "EMPTY=", "BUILD=2")
+				orgBody := mockInput("test_sample.yml")
+				mockJobManager.EXPECT().CreateJob(orgBody, map[string]string{
+					"IMAGE_URL": "http://example.com/image.img?a=b",
+					"BUILD":     "2",
+					"EMPTY":     "",
+				}).Return(weles.JobID(1234), nil)
+
+				resp, err := testserver.Client().Do(req)
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+
+				Expect(resp.StatusCode).To(Equal(201))
+			})
+
+			It("should respond with 422 if template cannot be rendered", func() {
+				req := requestBody("test_sample.yml", "yamlfile", JSON)
+				orgBody := mockInput("test_sample.yml")
+				renderErr := weles.ErrInvalidArgument(`line 3, column 6: undefined variable "X"`)
+				mockJobManager.EXPECT().CreateJob(orgBody, map[string]string{}).Return(
+					weles.JobID(0), renderErr)
+
+				resp, err := testserver.Client().Do(req)
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+				respBody, err := ioutil.ReadAll(resp.Body)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(resp.StatusCode).To(Equal(422))
+				Expect(respBody).To(MatchJSON(
+					`{"message":"invalid argument: line 3, column 6: undefined variable \"X\""}`))
+			})
+
+			DescribeTable("should respond with 422 if variable is malformed",
+				func(variable string) {
+					req := requestBody("test_sample.yml", "yamlfile", JSON, variable)
+
+					resp, err := testserver.Client().Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+
+					Expect(resp.StatusCode).To(Equal(422))
+				},
+				Entry("without value", "BUILD"),
+				Entry("without name", "=1"),
+			)
+		})
+
 		Context("handler receives nil instead of file", func() {
 			It("should return unprocessable entity object", func() {
 				req, err := http.NewRequest(http.MethodPost, testserver.URL+"/api/v1/jobs/",
@@ -0,0 +1,33 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/SamsungSLAV/weles/parser"
+	"github.com/SamsungSLAV/weles/server/operations/general"
+)
+
+// JobSchema is JSON Schema of job description API endpoint handler.
+//
+// Due to go-swagger server generation, and how the API endpoint was designed, parameter to this
+// function will never be used.
+// nolint:unparam
+func (a *APIDefaults) JobSchema(params general.JobSchemaParams) middleware.Responder {
+	return general.NewJobSchemaOK().WithPayload(json.RawMessage(parser.JobSchema))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles/parser"
+)
+
+var _ = Describe("JobSchemaHandler", func() {
+	var testserver *httptest.Server
+
+	BeforeEach(func() {
+		_, _, _, _, testserver = testServerSetup()
+	})
+
+	AfterEach(func() {
+		testserver.Close()
+	})
+
+	It("should respond with JSON Schema of job description and 200 Status Code", func() {
+		client := testserver.Client()
+		req, err := http.NewRequest(http.MethodGet, testserver.URL+"/api/v1/schema/job", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := client.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal(JSON))
+		respBody, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(respBody)).To(MatchJSON(parser.JobSchema))
+	})
+})
@@ -0,0 +1,48 @@
+// Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server
+
+import (
+	"io/ioutil"
+
+	middleware "github.com/go-openapi/runtime/middleware"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/server/operations/jobs"
+)
+
+// JobValidator is a handler which passes yaml file with job description and values
+// of variables to jobmanager for validation. Job is not created.
+func (m *Managers) JobValidator(params jobs.JobValidatorParams) middleware.Responder {
+	byteContainer, err := ioutil.ReadAll(params.Yamlfile)
+	if err != nil {
+		return jobs.NewJobValidatorUnprocessableEntity().WithPayload(
+			&weles.ErrResponse{Message: err.Error(), Type: ""})
+	}
+
+	vars, err := parseVariables(params.Variables)
+	if err != nil {
+		return jobs.NewJobValidatorUnprocessableEntity().WithPayload(
+			&weles.ErrResponse{Message: err.Error(), Type: ""})
+	}
+
+	result, err := m.JM.ValidateJob(byteContainer, vars)
+	if err != nil {
+		return jobs.NewJobValidatorInternalServerError().WithPayload(
+			&weles.ErrResponse{Message: err.Error(), Type: ""})
+	}
+
+	return jobs.NewJobValidatorOK().WithPayload(&result)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/mock"
+	"github.com/SamsungSLAV/weles/server"
+	"github.com/SamsungSLAV/weles/server/operations/jobs"
+)
+
+var _ = Describe("JobValidatorHandler", func() {
+
+	var (
+		mockCtrl       *gomock.Controller
+		mockJobManager *mock.MockJobManager
+		apiDefaults    *server.APIDefaults
+		testserver     *httptest.Server
+	)
+
+	yaml := []byte("device_type: qemu\njob_name: validation\npriority: low\n")
+
+	BeforeEach(func() {
+		mockCtrl, mockJobManager, _, apiDefaults, testserver = testServerSetup()
+	})
+
+	AfterEach(func() {
+		testserver.Close()
+		mockCtrl.Finish()
+	})
+
+	validate := func(variables ...string) (int, string) {
+		bodyBuf := &bytes.Buffer{}
+		bodyWriter := multipart.NewWriter(bodyBuf)
+		fileWriter, err := bodyWriter.CreateFormFile("yamlfile", "job.yml")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = fileWriter.Write(yaml)
+		Expect(err).ToNot(HaveOccurred())
+		for _, v := range variables {
+			Expect(bodyWriter.WriteField("variables", v)).To(Succeed())
+		}
+		Expect(bodyWriter.Close()).To(Succeed())
+
+		req, err := http.NewRequest(http.MethodPost, testserver.URL+"/api/v1/jobs/validate",
+			bodyBuf)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Content-Type", bodyWriter.FormDataContentType())
+
+		resp, err := testserver.Client().Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return resp.StatusCode, string(body)
+	}
+
+	It("should respond with 200 and result of validation", func() {
+		mockJobManager.EXPECT().ValidateJob(yaml, map[string]string{}).Return(weles.JobValidation{
+			Errors: []*weles.ValidationIssue{
+				{Line: 3, Column: 11, Message: `invalid priority "urgent"`},
+			},
+			Warnings: []*weles.ValidationIssue{
+				{Message: "URI:<http://example.com> is not reachable : 404 Not Found"},
+			},
+		}, nil)
+
+		status, body := validate()
+
+		Expect(status).To(Equal(200))
+		Expect(body).To(MatchJSON(`{
+			"errors": [{"line": 3, "column": 11, "message": "invalid priority \"urgent\""}],
+			"warnings": [{"message": "URI:<http://example.com> is not reachable : 404 Not Found"}]
+		}`))
+	})
+
+	It("should respond with 200 and valid result without problems", func() {
+		mockJobManager.EXPECT().ValidateJob(yaml, map[string]string{}).Return(weles.JobValidation{
+			Valid:    true,
+			Errors:   []*weles.ValidationIssue{},
+			Warnings: []*weles.ValidationIssue{},
+		}, nil)
+
+		status, body := validate()
+
+		Expect(status).To(Equal(200))
+		Expect(body).To(MatchJSON(`{"valid": true, "errors": [], "warnings": []}`))
+	})
+
+	It("should pass values of variables to ValidateJob", func() {
+		mockJobManager.EXPECT().ValidateJob(yaml,
+			map[string]string{"DEVICE": "rpi3", "EMPTY": ""}).Return(weles.JobValidation{
+			Valid:    true,
+			Errors:   []*weles.ValidationIssue{},
+			Warnings: []*weles.ValidationIssue{},
+		}, nil)
+
+		status, _ := validate("DEVICE=rpi3", "EMPTY=")
+
+		Expect(status).To(Equal(200))
+	})
+
+	It("should respond with 422 if variable is malformed", func() {
+		status, body := validate("DEVICE")
+
+		Expect(status).To(Equal(422))
+		Expect(body).To(MatchJSON(
+			`{"message": "invalid argument: variable \"DEVICE\" is not in NAME=value form"}`))
+	})
+
+	It("should respond with 500 if validation fails", func() {
+		mockJobManager.EXPECT().ValidateJob(yaml, map[string]string{}).Return(weles.JobValidation{},
+			errors.New("Boruta is gone"))
+
+		status, body := validate()
+
+		Expect(status).To(Equal(500))
+		Expect(body).To(MatchJSON(`{"message": "Boruta is gone"}`))
+	})
+
+	It("should return unprocessable entity object if file cannot be read", func() {
+		req, err := http.NewRequest(http.MethodPost, testserver.URL+"/api/v1/jobs/validate",
+			errReader(0))
+		Expect(err).ToNot(HaveOccurred())
+		params := jobs.JobValidatorParams{Yamlfile: errReader(0), HTTPRequest: req}
+
+		ret := apiDefaults.Managers.JobValidator(params)
+		Expect(ret.(*jobs.JobValidatorUnprocessableEntity).Payload).To(
+			Equal(&weles.ErrResponse{Message: "reader error"}))
+	})
+})
@@ -18,10 +18,12 @@ import (
 	"github.com/SamsungSLAV/weles"
 )
 
-// Managers provide implementation of JobManager and ArtifactManager interfaces.
+// Managers provide implementation of JobManager, ArtifactManager and SnippetManager
+// interfaces.
 type Managers struct {
 	JM weles.JobManager
 	AM weles.ArtifactManager
+	SM weles.SnippetManager
 }
 
 // APIDefaults contains interface implementations (Managers) and default values
@@ -29,10 +31,14 @@ type Managers struct {
 type APIDefaults struct {
 	Managers  *Managers
 	PageLimit int32
+	// AdminToken authorizes requests deleting, pinning and unpinning artifacts and
+	// creating and deleting snippets. Such requests are refused if it is empty.
+	AdminToken string
 }
 
-// NewManagers creates managers struct and assigns JobManager and ArtifactManager implementation
-// to it.
-func NewManagers(jm weles.JobManager, am weles.ArtifactManager) (m *Managers) {
-	return &Managers{JM: jm, AM: am}
+// NewManagers creates managers struct and assigns JobManager, ArtifactManager and
+// SnippetManager implementation to it.
+func NewManagers(jm weles.JobManager, am weles.ArtifactManager, sm weles.SnippetManager,
+) (m *Managers) {
+	return &Managers{JM: jm, AM: am, SM: sm}
 }
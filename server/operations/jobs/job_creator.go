@@ -48,7 +48,7 @@ func NewJobCreator(ctx *middleware.Context, handler JobCreatorHandler) *JobCreat
 
 Add new job
 
-adds new Job in Weles using recipe passed in YAML format.
+adds new Job in Weles using recipe passed in YAML format. The recipe may be a template with placeholders of variables.
 
 */
 type JobCreator struct {
@@ -28,6 +28,8 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+
+	strfmt "github.com/go-openapi/strfmt"
 )
 
 // NewJobCreatorParams creates a new JobCreatorParams object
@@ -46,6 +48,10 @@ type JobCreatorParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*are values of variables used in Job description yaml file given in NAME=value form. Placeholders ${NAME} and ${NAME:-default} are replaced with these values, also in included snippets.
+	  In: formData
+	*/
+	Variables []string
 	/*is Job description yaml file.
 	  Required: true
 	  In: formData
@@ -69,6 +75,12 @@ func (o *JobCreatorParams) BindRequest(r *http.Request, route *middleware.Matche
 			return errors.New(400, "%v", err)
 		}
 	}
+	fds := runtime.Values(r.Form)
+
+	fdVariables, fdhkVariables, _ := fds.GetOK("variables")
+	if err := o.bindVariables(fdVariables, fdhkVariables, route.Formats); err != nil {
+		res = append(res, err)
+	}
 
 	yamlfile, yamlfileHeader, err := r.FormFile("yamlfile")
 	if err != nil {
@@ -86,6 +98,29 @@ func (o *JobCreatorParams) BindRequest(r *http.Request, route *middleware.Matche
 	return nil
 }
 
+// bindVariables binds and validates array parameter Variables from formData.
+//
+// Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
+func (o *JobCreatorParams) bindVariables(rawData []string, hasKey bool, formats strfmt.Registry) error {
+
+	// CollectionFormat: multi
+	variablesIC := rawData
+	if len(variablesIC) == 0 {
+		return nil
+	}
+
+	var variablesIR []string
+	for _, variablesIV := range variablesIC {
+		variablesI := variablesIV
+
+		variablesIR = append(variablesIR, variablesI)
+	}
+
+	o.Variables = variablesIR
+
+	return nil
+}
+
 // bindYamlfile binds file parameter Yamlfile.
 //
 // The only supported validations on files are MinLength and MaxLength
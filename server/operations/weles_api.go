@@ -37,6 +37,7 @@ import (
 	"github.com/SamsungSLAV/weles/server/operations/artifacts"
 	"github.com/SamsungSLAV/weles/server/operations/general"
 	"github.com/SamsungSLAV/weles/server/operations/jobs"
+	"github.com/SamsungSLAV/weles/server/operations/snippets"
 )
 
 // NewWelesAPI creates a new Weles instance
@@ -56,10 +57,29 @@ func NewWelesAPI(spec *loads.Document) *WelesAPI {
 		BearerAuthenticator:   security.BearerAuth,
 		JSONConsumer:          runtime.JSONConsumer(),
 		MultipartformConsumer: runtime.DiscardConsumer,
+		BinProducer:           runtime.ByteStreamProducer(),
 		JSONProducer:          runtime.JSONProducer(),
+		ArtifactsArtifactDeleterHandler: artifacts.ArtifactDeleterHandlerFunc(func(params artifacts.ArtifactDeleterParams) middleware.Responder {
+			return middleware.NotImplemented("operation ArtifactsArtifactDeleter has not yet been implemented")
+		}),
 		ArtifactsArtifactListerHandler: artifacts.ArtifactListerHandlerFunc(func(params artifacts.ArtifactListerParams) middleware.Responder {
 			return middleware.NotImplemented("operation ArtifactsArtifactLister has not yet been implemented")
 		}),
+		ArtifactsArtifactPinnerHandler: artifacts.ArtifactPinnerHandlerFunc(func(params artifacts.ArtifactPinnerParams) middleware.Responder {
+			return middleware.NotImplemented("operation ArtifactsArtifactPinner has not yet been implemented")
+		}),
+		ArtifactsArtifactUnpinnerHandler: artifacts.ArtifactUnpinnerHandlerFunc(func(params artifacts.ArtifactUnpinnerParams) middleware.Responder {
+			return middleware.NotImplemented("operation ArtifactsArtifactUnpinner has not yet been implemented")
+		}),
+		ArtifactsArtifactUploaderHandler: artifacts.ArtifactUploaderHandlerFunc(func(params artifacts.ArtifactUploaderParams) middleware.Responder {
+			return middleware.NotImplemented("operation ArtifactsArtifactUploader has not yet been implemented")
+		}),
+		ArtifactsJobArtifactsArchiverHandler: artifacts.JobArtifactsArchiverHandlerFunc(func(params artifacts.JobArtifactsArchiverParams) middleware.Responder {
+			return middleware.NotImplemented("operation ArtifactsJobArtifactsArchiver has not yet been implemented")
+		}),
+		ArtifactsJobArtifactsDeleterHandler: artifacts.JobArtifactsDeleterHandlerFunc(func(params artifacts.JobArtifactsDeleterParams) middleware.Responder {
+			return middleware.NotImplemented("operation ArtifactsJobArtifactsDeleter has not yet been implemented")
+		}),
 		JobsJobCancelerHandler: jobs.JobCancelerHandlerFunc(func(params jobs.JobCancelerParams) middleware.Responder {
 			return middleware.NotImplemented("operation JobsJobCanceler has not yet been implemented")
 		}),
@@ -69,6 +89,24 @@ func NewWelesAPI(spec *loads.Document) *WelesAPI {
 		JobsJobListerHandler: jobs.JobListerHandlerFunc(func(params jobs.JobListerParams) middleware.Responder {
 			return middleware.NotImplemented("operation JobsJobLister has not yet been implemented")
 		}),
+		GeneralJobSchemaHandler: general.JobSchemaHandlerFunc(func(params general.JobSchemaParams) middleware.Responder {
+			return middleware.NotImplemented("operation GeneralJobSchema has not yet been implemented")
+		}),
+		JobsJobValidatorHandler: jobs.JobValidatorHandlerFunc(func(params jobs.JobValidatorParams) middleware.Responder {
+			return middleware.NotImplemented("operation JobsJobValidator has not yet been implemented")
+		}),
+		SnippetsSnippetCreatorHandler: snippets.SnippetCreatorHandlerFunc(func(params snippets.SnippetCreatorParams) middleware.Responder {
+			return middleware.NotImplemented("operation SnippetsSnippetCreator has not yet been implemented")
+		}),
+		SnippetsSnippetDeleterHandler: snippets.SnippetDeleterHandlerFunc(func(params snippets.SnippetDeleterParams) middleware.Responder {
+			return middleware.NotImplemented("operation SnippetsSnippetDeleter has not yet been implemented")
+		}),
+		SnippetsSnippetGetterHandler: snippets.SnippetGetterHandlerFunc(func(params snippets.SnippetGetterParams) middleware.Responder {
+			return middleware.NotImplemented("operation SnippetsSnippetGetter has not yet been implemented")
+		}),
+		SnippetsSnippetListerHandler: snippets.SnippetListerHandlerFunc(func(params snippets.SnippetListerParams) middleware.Responder {
+			return middleware.NotImplemented("operation SnippetsSnippetLister has not yet been implemented")
+		}),
 		GeneralVersionHandler: general.VersionHandlerFunc(func(params general.VersionParams) middleware.Responder {
 			return middleware.NotImplemented("operation GeneralVersion has not yet been implemented")
 		}),
@@ -102,17 +140,43 @@ type WelesAPI struct {
 	// MultipartformConsumer registers a consumer for a "multipart/form-data" mime type
 	MultipartformConsumer runtime.Consumer
 
+	// BinProducer registers a producer for a "application/octet-stream" mime type
+	BinProducer runtime.Producer
 	// JSONProducer registers a producer for a "application/json" mime type
 	JSONProducer runtime.Producer
 
+	// ArtifactsArtifactDeleterHandler sets the operation handler for the artifact deleter operation
+	ArtifactsArtifactDeleterHandler artifacts.ArtifactDeleterHandler
 	// ArtifactsArtifactListerHandler sets the operation handler for the artifact lister operation
 	ArtifactsArtifactListerHandler artifacts.ArtifactListerHandler
+	// ArtifactsArtifactPinnerHandler sets the operation handler for the artifact pinner operation
+	ArtifactsArtifactPinnerHandler artifacts.ArtifactPinnerHandler
+	// ArtifactsArtifactUnpinnerHandler sets the operation handler for the artifact unpinner operation
+	ArtifactsArtifactUnpinnerHandler artifacts.ArtifactUnpinnerHandler
+	// ArtifactsArtifactUploaderHandler sets the operation handler for the artifact uploader operation
+	ArtifactsArtifactUploaderHandler artifacts.ArtifactUploaderHandler
+	// ArtifactsJobArtifactsArchiverHandler sets the operation handler for the job artifacts archiver operation
+	ArtifactsJobArtifactsArchiverHandler artifacts.JobArtifactsArchiverHandler
+	// ArtifactsJobArtifactsDeleterHandler sets the operation handler for the job artifacts deleter operation
+	ArtifactsJobArtifactsDeleterHandler artifacts.JobArtifactsDeleterHandler
 	// JobsJobCancelerHandler sets the operation handler for the job canceler operation
 	JobsJobCancelerHandler jobs.JobCancelerHandler
 	// JobsJobCreatorHandler sets the operation handler for the job creator operation
 	JobsJobCreatorHandler jobs.JobCreatorHandler
 	// JobsJobListerHandler sets the operation handler for the job lister operation
 	JobsJobListerHandler jobs.JobListerHandler
+	// GeneralJobSchemaHandler sets the operation handler for the job schema operation
+	GeneralJobSchemaHandler general.JobSchemaHandler
+	// JobsJobValidatorHandler sets the operation handler for the job validator operation
+	JobsJobValidatorHandler jobs.JobValidatorHandler
+	// SnippetsSnippetCreatorHandler sets the operation handler for the snippet creator operation
+	SnippetsSnippetCreatorHandler snippets.SnippetCreatorHandler
+	// SnippetsSnippetDeleterHandler sets the operation handler for the snippet deleter operation
+	SnippetsSnippetDeleterHandler snippets.SnippetDeleterHandler
+	// SnippetsSnippetGetterHandler sets the operation handler for the snippet getter operation
+	SnippetsSnippetGetterHandler snippets.SnippetGetterHandler
+	// SnippetsSnippetListerHandler sets the operation handler for the snippet lister operation
+	SnippetsSnippetListerHandler snippets.SnippetListerHandler
 	// GeneralVersionHandler sets the operation handler for the version operation
 	GeneralVersionHandler general.VersionHandler
 
@@ -178,14 +242,42 @@ func (o *WelesAPI) Validate() error {
 		unregistered = append(unregistered, "MultipartformConsumer")
 	}
 
+	if o.BinProducer == nil {
+		unregistered = append(unregistered, "BinProducer")
+	}
+
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
 
+	if o.ArtifactsArtifactDeleterHandler == nil {
+		unregistered = append(unregistered, "artifacts.ArtifactDeleterHandler")
+	}
+
 	if o.ArtifactsArtifactListerHandler == nil {
 		unregistered = append(unregistered, "artifacts.ArtifactListerHandler")
 	}
 
+	if o.ArtifactsArtifactPinnerHandler == nil {
+		unregistered = append(unregistered, "artifacts.ArtifactPinnerHandler")
+	}
+
+	if o.ArtifactsArtifactUnpinnerHandler == nil {
+		unregistered = append(unregistered, "artifacts.ArtifactUnpinnerHandler")
+	}
+
+	if o.ArtifactsArtifactUploaderHandler == nil {
+		unregistered = append(unregistered, "artifacts.ArtifactUploaderHandler")
+	}
+
+	if o.ArtifactsJobArtifactsArchiverHandler == nil {
+		unregistered = append(unregistered, "artifacts.JobArtifactsArchiverHandler")
+	}
+
+	if o.ArtifactsJobArtifactsDeleterHandler == nil {
+		unregistered = append(unregistered, "artifacts.JobArtifactsDeleterHandler")
+	}
+
 	if o.JobsJobCancelerHandler == nil {
 		unregistered = append(unregistered, "jobs.JobCancelerHandler")
 	}
@@ -198,6 +290,30 @@ func (o *WelesAPI) Validate() error {
 		unregistered = append(unregistered, "jobs.JobListerHandler")
 	}
 
+	if o.GeneralJobSchemaHandler == nil {
+		unregistered = append(unregistered, "general.JobSchemaHandler")
+	}
+
+	if o.JobsJobValidatorHandler == nil {
+		unregistered = append(unregistered, "jobs.JobValidatorHandler")
+	}
+
+	if o.SnippetsSnippetCreatorHandler == nil {
+		unregistered = append(unregistered, "snippets.SnippetCreatorHandler")
+	}
+
+	if o.SnippetsSnippetDeleterHandler == nil {
+		unregistered = append(unregistered, "snippets.SnippetDeleterHandler")
+	}
+
+	if o.SnippetsSnippetGetterHandler == nil {
+		unregistered = append(unregistered, "snippets.SnippetGetterHandler")
+	}
+
+	if o.SnippetsSnippetListerHandler == nil {
+		unregistered = append(unregistered, "snippets.SnippetListerHandler")
+	}
+
 	if o.GeneralVersionHandler == nil {
 		unregistered = append(unregistered, "general.VersionHandler")
 	}
@@ -261,6 +377,9 @@ func (o *WelesAPI) ProducersFor(mediaTypes []string) map[string]runtime.Producer
 		case "application/json":
 			result["application/json"] = o.JSONProducer
 
+		case "application/octet-stream":
+			result["application/octet-stream"] = o.BinProducer
+
 		}
 
 		if p, ok := o.customProducers[mt]; ok {
@@ -303,11 +422,41 @@ func (o *WelesAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/artifacts/{ArtifactID}"] = artifacts.NewArtifactDeleter(o.context, o.ArtifactsArtifactDeleterHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/artifacts/list"] = artifacts.NewArtifactLister(o.context, o.ArtifactsArtifactListerHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/artifacts/{ArtifactID}/pin"] = artifacts.NewArtifactPinner(o.context, o.ArtifactsArtifactPinnerHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/artifacts/{ArtifactID}/unpin"] = artifacts.NewArtifactUnpinner(o.context, o.ArtifactsArtifactUnpinnerHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/artifacts"] = artifacts.NewArtifactUploader(o.context, o.ArtifactsArtifactUploaderHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/jobs/{JobID}/artifacts/archive"] = artifacts.NewJobArtifactsArchiver(o.context, o.ArtifactsJobArtifactsArchiverHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/jobs/{JobID}/artifacts"] = artifacts.NewJobArtifactsDeleter(o.context, o.ArtifactsJobArtifactsDeleterHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -323,6 +472,36 @@ func (o *WelesAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/jobs/list"] = jobs.NewJobLister(o.context, o.JobsJobListerHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/schema/job"] = general.NewJobSchema(o.context, o.GeneralJobSchemaHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/jobs/validate"] = jobs.NewJobValidator(o.context, o.JobsJobValidatorHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/snippets/{SnippetName}"] = snippets.NewSnippetCreator(o.context, o.SnippetsSnippetCreatorHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/snippets/{SnippetName}/{SnippetVersion}"] = snippets.NewSnippetDeleter(o.context, o.SnippetsSnippetDeleterHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/snippets/{SnippetName}/{SnippetVersion}"] = snippets.NewSnippetGetter(o.context, o.SnippetsSnippetGetterHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/snippets"] = snippets.NewSnippetLister(o.context, o.SnippetsSnippetListerHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -63,7 +63,8 @@ func testServerSetup() (mockCtrl *gomock.Controller, mockJobManager *mock.MockJo
 	api := operations.NewWelesAPI(swaggerSpec)
 	srv := server.NewServer(api)
 	apiDefaults = &server.APIDefaults{
-		Managers: server.NewManagers(mockJobManager, mockArtifactManager),
+		Managers: server.NewManagers(mockJobManager, mockArtifactManager,
+			mock.NewMockSnippetManager(mockCtrl)),
 	}
 	srv.WelesConfigureAPI(apiDefaults)
 	testserver = httptest.NewServer(srv.GetHandler())
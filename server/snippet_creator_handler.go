@@ -0,0 +1,56 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server
+
+import (
+	"io/ioutil"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/server/operations/snippets"
+)
+
+// SnippetCreator is a handler which passes uploaded YAML fragment to SnippetManager
+// to be stored as a new version of snippet.
+func (a *APIDefaults) SnippetCreator(params snippets.SnippetCreatorParams,
+) middleware.Responder {
+	if !a.authorized(params.HTTPRequest) {
+		return snippets.NewSnippetCreatorForbidden().WithPayload(
+			&weles.ErrResponse{Message: weles.ErrNotAuthorized.Error()})
+	}
+	content, err := ioutil.ReadAll(params.Snippetfile)
+	if err != nil {
+		return snippets.NewSnippetCreatorUnprocessableEntity().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	}
+	var description string
+	if params.Description != nil {
+		description = *params.Description
+	}
+
+	snippet, err := a.Managers.SM.CreateSnippet(params.SnippetName, content, description)
+	if err != nil {
+		switch err.(type) {
+		case weles.ErrInvalidArgument:
+			return snippets.NewSnippetCreatorUnprocessableEntity().WithPayload(
+				&weles.ErrResponse{Message: err.Error()})
+		default:
+			return snippets.NewSnippetCreatorInternalServerError().WithPayload(
+				&weles.ErrResponse{Message: err.Error()})
+		}
+	}
+	return snippets.NewSnippetCreatorCreated().WithPayload(&snippet)
+}
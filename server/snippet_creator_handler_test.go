@@ -0,0 +1,136 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/mock"
+	"github.com/SamsungSLAV/weles/server"
+	"github.com/SamsungSLAV/weles/server/operations/snippets"
+)
+
+var _ = Describe("SnippetCreatorHandler", func() {
+	const (
+		content = "boot:\n  login: root\n"
+		token   = "s3cr3t"
+	)
+
+	var (
+		mockCtrl           *gomock.Controller
+		mockSnippetManager *mock.MockSnippetManager
+		apiDefaults        *server.APIDefaults
+		testserver         *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mockCtrl, _, _, apiDefaults, testserver = testServerSetup()
+		mockSnippetManager = apiDefaults.Managers.SM.(*mock.MockSnippetManager)
+		apiDefaults.AdminToken = token
+	})
+
+	AfterEach(func() {
+		testserver.Close()
+		mockCtrl.Finish()
+	})
+
+	post := func(name, description, auth string) (int, string) {
+		bodyBuf := &bytes.Buffer{}
+		bodyWriter := multipart.NewWriter(bodyBuf)
+		fileWriter, err := bodyWriter.CreateFormFile("snippetfile", "boot.yml")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = fileWriter.Write([]byte(content))
+		Expect(err).ToNot(HaveOccurred())
+		if description != OMIT {
+			Expect(bodyWriter.WriteField("description", description)).To(Succeed())
+		}
+		Expect(bodyWriter.Close()).To(Succeed())
+
+		req, err := http.NewRequest(http.MethodPost,
+			testserver.URL+basePath+"/snippets/"+name, bodyBuf)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Content-Type", bodyWriter.FormDataContentType())
+		if auth != OMIT {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := testserver.Client().Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return resp.StatusCode, string(body)
+	}
+
+	DescribeTable("should store new version of snippet",
+		func(description, expected string) {
+			mockSnippetManager.EXPECT().CreateSnippet("tizen-boot", []byte(content), expected).
+				Return(weles.Snippet{Name: "tizen-boot", Version: 3, Content: content,
+					Description: expected}, nil)
+
+			status, body := post("tizen-boot", description, "Bearer "+token)
+			Expect(status).To(Equal(http.StatusCreated))
+			Expect(body).To(ContainSubstring(`"version":3`))
+			Expect(body).To(ContainSubstring(`"name":"tizen-boot"`))
+		},
+		Entry("with description", "root login", "root login"),
+		Entry("without description", OMIT, ""),
+	)
+
+	DescribeTable("should refuse unauthorized request",
+		func(auth string) {
+			status, body := post("tizen-boot", OMIT, auth)
+			Expect(status).To(Equal(http.StatusForbidden))
+			Expect(body).To(MatchJSON(`{"message": "` + weles.ErrNotAuthorized.Error() + `"}`))
+		},
+		Entry("missing token", OMIT),
+		Entry("invalid token", "Bearer invalid"),
+	)
+
+	DescribeTable("should respond with appropriate error",
+		func(err error, status int) {
+			mockSnippetManager.EXPECT().CreateSnippet("tizen-boot", []byte(content), "").
+				Return(weles.Snippet{}, err)
+
+			retStatus, body := post("tizen-boot", OMIT, "Bearer "+token)
+			Expect(retStatus).To(Equal(status))
+			Expect(body).To(MatchJSON(`{"message": "` + err.Error() + `"}`))
+		},
+		Entry("invalid snippet - 422",
+			weles.ErrInvalidArgument("snippet must be a YAML mapping or list"), 422),
+		Entry("unexpected error - 500", errors.New("db error"), 500),
+	)
+
+	It("should respond with 422 if file cannot be read", func() {
+		req := httptest.NewRequest(http.MethodPost, basePath+"/snippets/boot", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		params := snippets.SnippetCreatorParams{HTTPRequest: req, SnippetName: "boot",
+			Snippetfile: errReader(0)}
+
+		ret := apiDefaults.SnippetCreator(params)
+		Expect(ret.(*snippets.SnippetCreatorUnprocessableEntity).Payload).To(
+			Equal(&weles.ErrResponse{Message: "reader error"}))
+	})
+})
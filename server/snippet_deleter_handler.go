@@ -0,0 +1,43 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server
+
+import (
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/server/operations/snippets"
+)
+
+// SnippetDeleter is a handler which passes name and version of snippet to be deleted
+// to SnippetManager.
+func (a *APIDefaults) SnippetDeleter(params snippets.SnippetDeleterParams,
+) middleware.Responder {
+	if !a.authorized(params.HTTPRequest) {
+		return snippets.NewSnippetDeleterForbidden().WithPayload(
+			&weles.ErrResponse{Message: weles.ErrNotAuthorized.Error()})
+	}
+	err := a.Managers.SM.DeleteSnippet(params.SnippetName, params.SnippetVersion)
+	switch err {
+	case nil:
+		return snippets.NewSnippetDeleterNoContent()
+	case weles.ErrSnippetNotFound:
+		return snippets.NewSnippetDeleterNotFound().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	default:
+		return snippets.NewSnippetDeleterInternalServerError().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	}
+}
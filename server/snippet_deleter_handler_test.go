@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/mock"
+	"github.com/SamsungSLAV/weles/server"
+)
+
+var _ = Describe("SnippetDeleterHandler", func() {
+	const token = "s3cr3t"
+
+	var (
+		mockCtrl           *gomock.Controller
+		mockSnippetManager *mock.MockSnippetManager
+		apiDefaults        *server.APIDefaults
+		testserver         *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mockCtrl, _, _, apiDefaults, testserver = testServerSetup()
+		mockSnippetManager = apiDefaults.Managers.SM.(*mock.MockSnippetManager)
+		apiDefaults.AdminToken = token
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+		testserver.Close()
+	})
+
+	deleteReq := func(auth string) (int, string) {
+		req, err := http.NewRequest(http.MethodDelete,
+			testserver.URL+basePath+"/snippets/tizen-boot/3", nil)
+		Expect(err).ToNot(HaveOccurred())
+		if auth != OMIT {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := testserver.Client().Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return resp.StatusCode, string(body)
+	}
+
+	It("should respond with 204 Status Code", func() {
+		mockSnippetManager.EXPECT().DeleteSnippet("tizen-boot", int64(3))
+
+		status, _ := deleteReq("Bearer " + token)
+		Expect(status).To(Equal(http.StatusNoContent))
+	})
+
+	It("should refuse unauthorized request", func() {
+		status, body := deleteReq("Bearer invalid")
+		Expect(status).To(Equal(http.StatusForbidden))
+		Expect(body).To(MatchJSON(`{"message": "` + weles.ErrNotAuthorized.Error() + `"}`))
+	})
+
+	DescribeTable("should respond with appropriate error",
+		func(err error, status int) {
+			mockSnippetManager.EXPECT().DeleteSnippet("tizen-boot", int64(3)).Return(err)
+
+			retStatus, body := deleteReq("Bearer " + token)
+			Expect(retStatus).To(Equal(status))
+			Expect(body).To(MatchJSON(`{"message": "` + err.Error() + `"}`))
+		},
+		Entry("snippet does not exist - 404", weles.ErrSnippetNotFound, 404),
+		Entry("unexpected error - 500", errors.New("db error"), 500),
+	)
+})
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/mock"
+	"github.com/SamsungSLAV/weles/server"
+)
+
+var _ = Describe("SnippetGetterHandler", func() {
+	var (
+		mockCtrl           *gomock.Controller
+		mockSnippetManager *mock.MockSnippetManager
+		testserver         *httptest.Server
+	)
+
+	BeforeEach(func() {
+		var apiDefaults *server.APIDefaults
+		mockCtrl, _, _, apiDefaults, testserver = testServerSetup()
+		mockSnippetManager = apiDefaults.Managers.SM.(*mock.MockSnippetManager)
+	})
+
+	AfterEach(func() {
+		testserver.Close()
+		mockCtrl.Finish()
+	})
+
+	get := func(path string) (int, string) {
+		resp, err := testserver.Client().Get(testserver.URL + basePath + path)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return resp.StatusCode, string(body)
+	}
+
+	It("should respond with snippet", func() {
+		mockSnippetManager.EXPECT().GetSnippet("tizen-boot", int64(3)).Return(
+			weles.Snippet{Name: "tizen-boot", Version: 3, Content: "login: root\n"}, nil)
+
+		status, body := get("/snippets/tizen-boot/3")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(ContainSubstring(`"content":"login: root\n"`))
+	})
+
+	DescribeTable("should respond with appropriate error",
+		func(err error, status int) {
+			mockSnippetManager.EXPECT().GetSnippet("tizen-boot", int64(3)).Return(
+				weles.Snippet{}, err)
+
+			retStatus, body := get("/snippets/tizen-boot/3")
+			Expect(retStatus).To(Equal(status))
+			Expect(body).To(MatchJSON(`{"message": "` + err.Error() + `"}`))
+		},
+		Entry("snippet does not exist - 404", weles.ErrSnippetNotFound, 404),
+		Entry("unexpected error - 500", errors.New("db error"), 500),
+	)
+})
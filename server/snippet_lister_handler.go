@@ -0,0 +1,41 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server
+
+import (
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/server/operations/snippets"
+)
+
+// SnippetLister is a handler which passes name of snippets to be listed to SnippetManager.
+// All snippets are listed if the name is not set.
+func (m *Managers) SnippetLister(params snippets.SnippetListerParams) middleware.Responder {
+	var name string
+	if params.Name != nil {
+		name = *params.Name
+	}
+	list, err := m.SM.ListSnippets(name)
+	if err != nil {
+		return snippets.NewSnippetListerInternalServerError().WithPayload(
+			&weles.ErrResponse{Message: err.Error()})
+	}
+	payload := make([]*weles.Snippet, len(list))
+	for i := range list {
+		payload[i] = &list[i]
+	}
+	return snippets.NewSnippetListerOK().WithPayload(payload)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Samsung Electronics Co., Ltd All Rights Reserved
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package server_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+	"github.com/SamsungSLAV/weles/mock"
+	"github.com/SamsungSLAV/weles/server"
+)
+
+var _ = Describe("SnippetListerHandler", func() {
+	var (
+		mockCtrl           *gomock.Controller
+		mockSnippetManager *mock.MockSnippetManager
+		testserver         *httptest.Server
+	)
+
+	BeforeEach(func() {
+		var apiDefaults *server.APIDefaults
+		mockCtrl, _, _, apiDefaults, testserver = testServerSetup()
+		mockSnippetManager = apiDefaults.Managers.SM.(*mock.MockSnippetManager)
+	})
+
+	AfterEach(func() {
+		testserver.Close()
+		mockCtrl.Finish()
+	})
+
+	get := func(query string) (int, string) {
+		resp, err := testserver.Client().Get(testserver.URL + basePath + "/snippets" + query)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return resp.StatusCode, string(body)
+	}
+
+	It("should list all snippets", func() {
+		created := strfmt.DateTime(time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC))
+		mockSnippetManager.EXPECT().ListSnippets("").Return([]weles.Snippet{
+			{Name: "boot", Version: 1, Content: "login: root\n", Created: created},
+			{Name: "boot", Version: 2, Content: "login: user\n", Description: "user login"},
+		}, nil)
+
+		status, body := get("")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(`[
+			{"name": "boot", "version": 1, "content": "login: root\n",
+			 "created": "2018-05-01T12:00:00.000Z"},
+			{"name": "boot", "version": 2, "content": "login: user\n",
+			 "description": "user login", "created": "0001-01-01T00:00:00.000Z"}
+		]`))
+	})
+
+	It("should list versions of snippet with given name", func() {
+		mockSnippetManager.EXPECT().ListSnippets("boot").Return([]weles.Snippet{}, nil)
+
+		status, body := get("?name=boot")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(`[]`))
+	})
+
+	It("should respond with 500 if SnippetManager fails", func() {
+		mockSnippetManager.EXPECT().ListSnippets("").Return(nil, errors.New("db error"))
+
+		status, body := get("")
+		Expect(status).To(Equal(http.StatusInternalServerError))
+		Expect(body).To(MatchJSON(`{"message": "db error"}`))
+	})
+})
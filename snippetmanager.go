@@ -0,0 +1,43 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// File snippetmanager.go defines SnippetManager interface.
+
+package weles
+
+// SnippetManager provides access to library of snippets - named and versioned fragments
+// of Job descriptions which may be included in Jobs. Versions of a snippet are numbered
+// from 1 and cannot be modified.
+type SnippetManager interface {
+	// CreateSnippet stores content as a new version of snippet with given name.
+	// Content must be a YAML mapping or list. ErrInvalidArgument is returned
+	// if name or content is not valid.
+	CreateSnippet(name string, content []byte, description string) (Snippet, error)
+
+	// GetSnippet returns version of snippet with given name. The latest version
+	// is returned if version is 0.
+	GetSnippet(name string, version int64) (Snippet, error)
+
+	// ListSnippets returns all versions of snippets ordered by name and version.
+	// Only versions of snippet with given name are returned if name is not empty.
+	ListSnippets(name string) ([]Snippet, error)
+
+	// DeleteSnippet removes version of snippet with given name.
+	DeleteSnippet(name string, version int64) error
+
+	// Close gracefully closes SnippetManager.
+	Close() error
+}
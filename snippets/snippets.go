@@ -0,0 +1,204 @@
+/*
+ *  Copyright (c) 2017-2018 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+// Package snippets is responsible for Weles' library of snippets - named and versioned
+// fragments of Job descriptions which may be included in Jobs.
+package snippets
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/go-openapi/strfmt"
+	// sqlite3 driver is used for storing snippets.
+	_ "github.com/mattn/go-sqlite3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+const (
+	sqlite3Driver      = "sqlite3"
+	sqlite3BusyTimeout = "?_busy_timeout=5000"
+	sqlite3MaxOpenConn = 1
+)
+
+// namePattern matches valid names of snippets.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// lastVersion is a row of last_versions table. It keeps the last version of a snippet,
+// so numbers of deleted versions are never reused.
+type lastVersion struct {
+	Name    string
+	Version int64
+}
+
+// Library implements SnippetManager interface. Snippets are stored in sqlite database.
+type Library struct {
+	handler *sql.DB
+	dbmap   *gorp.DbMap
+}
+
+// NewSnippetManager opens database db located in dir, creating it if needed,
+// and returns new instance of SnippetManager.
+func NewSnippetManager(db, dir string) (*Library, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	handler, err := sql.Open(sqlite3Driver, filepath.Join(dir, db)+sqlite3BusyTimeout)
+	if err != nil {
+		return nil, errors.New("failed to open snippets database: " + err.Error())
+	}
+	handler.SetMaxOpenConns(sqlite3MaxOpenConn)
+
+	l := &Library{
+		handler: handler,
+		dbmap:   &gorp.DbMap{Db: handler, Dialect: gorp.SqliteDialect{}},
+	}
+	l.dbmap.AddTableWithName(weles.Snippet{}, "snippets").SetKeys(false, "Name", "Version")
+	l.dbmap.AddTableWithName(lastVersion{}, "last_versions").SetKeys(false, "Name")
+	if err = l.dbmap.CreateTablesIfNotExists(); err != nil {
+		if err2 := handler.Close(); err2 != nil {
+			log.Println("Failed to close snippets database: " + err2.Error())
+		}
+		return nil, errors.New("failed to create snippets tables: " + err.Error())
+	}
+	return l, nil
+}
+
+// validate checks name and content of a new snippet.
+func validate(name string, content []byte) error {
+	if !namePattern.MatchString(name) {
+		return weles.ErrInvalidArgument(fmt.Sprintf("invalid snippet name %q", name))
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return weles.ErrInvalidArgument("invalid snippet content: " + err.Error())
+	}
+	if len(doc.Content) == 0 || (doc.Content[0].Kind != yaml.MappingNode &&
+		doc.Content[0].Kind != yaml.SequenceNode) {
+		return weles.ErrInvalidArgument("snippet must be a YAML mapping or list")
+	}
+	return nil
+}
+
+// CreateSnippet is part of implementation of SnippetManager interface.
+func (l *Library) CreateSnippet(name string, content []byte, description string,
+) (snippet weles.Snippet, err error) {
+	if err = validate(name, content); err != nil {
+		return weles.Snippet{}, err
+	}
+	trans, err := l.dbmap.Begin()
+	if err != nil {
+		return weles.Snippet{}, errors.New("failed to open transaction: " + err.Error())
+	}
+	defer func() {
+		if err != nil {
+			if err2 := trans.Rollback(); err2 != nil {
+				log.Printf("%v occurred when creating snippet, "+
+					"trying to rollback transaction failed: %v", err, err2)
+			}
+		}
+	}()
+	last := lastVersion{Name: name}
+	err = trans.SelectOne(&last, "select * from last_versions where Name=?", name)
+	switch err {
+	case nil:
+		last.Version++
+		_, err = trans.Update(&last)
+	case sql.ErrNoRows:
+		last.Version = 1
+		err = trans.Insert(&last)
+	}
+	if err != nil {
+		return weles.Snippet{}, err
+	}
+	snippet = weles.Snippet{
+		Name:        name,
+		Version:     last.Version,
+		Description: description,
+		Content:     string(content),
+		Created:     strfmt.DateTime(time.Now().UTC()),
+	}
+	if err = trans.Insert(&snippet); err != nil {
+		return weles.Snippet{}, err
+	}
+	if err = trans.Commit(); err != nil {
+		return weles.Snippet{}, errors.New("failed to commit transaction: " + err.Error())
+	}
+	return snippet, nil
+}
+
+// GetSnippet is part of implementation of SnippetManager interface.
+func (l *Library) GetSnippet(name string, version int64) (weles.Snippet, error) {
+	var snippet weles.Snippet
+	var err error
+	if version == 0 {
+		err = l.dbmap.SelectOne(&snippet,
+			"select * from snippets where Name=? order by Version desc limit 1", name)
+	} else {
+		err = l.dbmap.SelectOne(&snippet,
+			"select * from snippets where Name=? and Version=?", name, version)
+	}
+	if err == sql.ErrNoRows {
+		return weles.Snippet{}, weles.ErrSnippetNotFound
+	}
+	return snippet, err
+}
+
+// ListSnippets is part of implementation of SnippetManager interface.
+func (l *Library) ListSnippets(name string) ([]weles.Snippet, error) {
+	query := "select * from snippets"
+	var args []interface{}
+	if name != "" {
+		query += " where Name=?"
+		args = append(args, name)
+	}
+	snippets := []weles.Snippet{}
+	_, err := l.dbmap.Select(&snippets, query+" order by Name, Version", args...)
+	if err != nil {
+		return nil, err
+	}
+	return snippets, nil
+}
+
+// DeleteSnippet is part of implementation of SnippetManager interface.
+func (l *Library) DeleteSnippet(name string, version int64) error {
+	res, err := l.dbmap.Exec("delete from snippets where Name=? and Version=?", name, version)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return weles.ErrSnippetNotFound
+	}
+	return nil
+}
+
+// Close is part of implementation of SnippetManager interface.
+func (l *Library) Close() error {
+	return l.handler.Close()
+}
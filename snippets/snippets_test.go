@@ -0,0 +1,173 @@
+/*
+ *  Copyright (c) 2017 Samsung Electronics Co., Ltd All Rights Reserved
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License
+ */
+
+package snippets
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SamsungSLAV/weles"
+)
+
+var _ = Describe("Library", func() {
+	var (
+		tealOtter *Library
+		tmpDir    string
+	)
+
+	const (
+		boot  = "boot:\n  login: root\n"
+		cases = "- case_name: smoke\n- case_name: stress\n"
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "weles-")
+		Expect(err).ToNot(HaveOccurred())
+		tealOtter, err = NewSnippetManager("snippets.db", tmpDir)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(tealOtter.Close()).To(Succeed())
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	It("should number versions of each snippet from 1", func() {
+		first, err := tealOtter.CreateSnippet("tizen-boot", []byte(boot), "first")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first.Version).To(BeEquivalentTo(1))
+		Expect(first.Name).To(Equal("tizen-boot"))
+		Expect(first.Content).To(Equal(boot))
+		Expect(first.Description).To(Equal("first"))
+		Expect(time.Time(first.Created)).To(BeTemporally("~", time.Now(), time.Minute))
+
+		second, err := tealOtter.CreateSnippet("tizen-boot", []byte(boot), "second")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second.Version).To(BeEquivalentTo(2))
+
+		other, err := tealOtter.CreateSnippet("smoke.cases", []byte(cases), "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(other.Version).To(BeEquivalentTo(1))
+	})
+
+	It("should get versions of snippet", func() {
+		first, err := tealOtter.CreateSnippet("tizen-boot", []byte(boot), "first")
+		Expect(err).ToNot(HaveOccurred())
+		second, err := tealOtter.CreateSnippet("tizen-boot", []byte(boot), "second")
+		Expect(err).ToNot(HaveOccurred())
+
+		s, err := tealOtter.GetSnippet("tizen-boot", 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Description).To(Equal(first.Description))
+		Expect(s.Content).To(Equal(first.Content))
+
+		By("Latest version should be returned if version is 0")
+		s, err = tealOtter.GetSnippet("tizen-boot", 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Version).To(Equal(second.Version))
+		Expect(s.Description).To(Equal(second.Description))
+
+		_, err = tealOtter.GetSnippet("tizen-boot", 3)
+		Expect(err).To(Equal(weles.ErrSnippetNotFound))
+		_, err = tealOtter.GetSnippet("missing", 0)
+		Expect(err).To(Equal(weles.ErrSnippetNotFound))
+	})
+
+	It("should list snippets ordered by name and version", func() {
+		list, err := tealOtter.ListSnippets("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list).To(BeEmpty())
+
+		for _, name := range []string{"tizen-boot", "smoke", "tizen-boot"} {
+			_, err = tealOtter.CreateSnippet(name, []byte(boot), "")
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		list, err = tealOtter.ListSnippets("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list).To(HaveLen(3))
+		for i, expected := range []struct {
+			name    string
+			version int64
+		}{{"smoke", 1}, {"tizen-boot", 1}, {"tizen-boot", 2}} {
+			Expect(list[i].Name).To(Equal(expected.name))
+			Expect(list[i].Version).To(Equal(expected.version))
+		}
+
+		list, err = tealOtter.ListSnippets("smoke")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list).To(HaveLen(1))
+		Expect(list[0].Name).To(Equal("smoke"))
+	})
+
+	It("should delete version of snippet and never reuse its number", func() {
+		for i := 0; i < 2; i++ {
+			_, err := tealOtter.CreateSnippet("tizen-boot", []byte(boot), "")
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		Expect(tealOtter.DeleteSnippet("tizen-boot", 2)).To(Succeed())
+		_, err := tealOtter.GetSnippet("tizen-boot", 2)
+		Expect(err).To(Equal(weles.ErrSnippetNotFound))
+		Expect(tealOtter.DeleteSnippet("tizen-boot", 2)).To(Equal(weles.ErrSnippetNotFound))
+
+		s, err := tealOtter.CreateSnippet("tizen-boot", []byte(boot), "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Version).To(BeEquivalentTo(3))
+	})
+
+	It("should keep snippets after reopening", func() {
+		_, err := tealOtter.CreateSnippet("tizen-boot", []byte(boot), "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tealOtter.Close()).To(Succeed())
+
+		tealOtter, err = NewSnippetManager("snippets.db", tmpDir)
+		Expect(err).ToNot(HaveOccurred())
+		s, err := tealOtter.GetSnippet("tizen-boot", 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Content).To(Equal(boot))
+	})
+
+	DescribeTable("should refuse invalid snippets",
+		func(name, content string, expected error) {
+			_, err := tealOtter.CreateSnippet(name, []byte(content), "")
+			Expect(err).To(Equal(expected))
+			list, err := tealOtter.ListSnippets("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(list).To(BeEmpty())
+		},
+		Entry("with empty name", "", boot,
+			weles.ErrInvalidArgument(`invalid snippet name ""`)),
+		Entry("with version in name", "tizen-boot@v1", boot,
+			weles.ErrInvalidArgument(`invalid snippet name "tizen-boot@v1"`)),
+		Entry("with path in name", "../boot", boot,
+			weles.ErrInvalidArgument(`invalid snippet name "../boot"`)),
+		Entry("with scalar content", "boot", "login",
+			weles.ErrInvalidArgument("snippet must be a YAML mapping or list")),
+		Entry("with empty content", "boot", "",
+			weles.ErrInvalidArgument("snippet must be a YAML mapping or list")),
+		Entry("with broken content", "boot", "boot: [root",
+			weles.ErrInvalidArgument("invalid snippet content: "+
+				"yaml: line 1: did not find expected ',' or ']'")),
+	)
+})